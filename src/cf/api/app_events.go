@@ -28,7 +28,7 @@ type EventEntity struct {
 }
 
 type AppEventsRepository interface {
-	ListEvents(appGuid string) (events chan []cf.EventFields, statusChan chan net.ApiResponse)
+	ListEvents(appGuid string, cb func([]cf.EventFields) bool) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerAppEventsRepository struct {
@@ -42,42 +42,32 @@ func NewCloudControllerAppEventsRepository(config *configuration.Configuration,
 	return
 }
 
-func (repo CloudControllerAppEventsRepository) ListEvents(appGuid string) (eventChan chan []cf.EventFields, statusChan chan net.ApiResponse) {
-
-	eventChan = make(chan []cf.EventFields, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := fmt.Sprintf("/v2/apps/%s/events", appGuid)
-		for path != "" {
-			url := fmt.Sprintf("%s%s", repo.config.Target, path)
-			eventResources := &PaginatedEventResources{}
-			apiResponse := repo.gateway.GetResource(url, repo.config.AccessToken, eventResources)
-			if apiResponse.IsNotSuccessful() {
-				statusChan <- apiResponse
-				close(eventChan)
-				close(statusChan)
-				return
-			}
+func (repo CloudControllerAppEventsRepository) ListEvents(appGuid string, cb func([]cf.EventFields) bool) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/apps/%s/events", appGuid)
+	return NewPaginatedIterator(path, func(path string) (interface{}, string, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.EventFields))
+	})
+}
 
-			events := []cf.EventFields{}
-			for _, resource := range eventResources.Resources {
-				events = append(events, cf.EventFields{
-					Timestamp:       resource.Entity.Timestamp,
-					ExitDescription: resource.Entity.ExitDescription,
-					ExitStatus:      resource.Entity.ExitStatus,
-					InstanceIndex:   resource.Entity.InstanceIndex,
-				})
-			}
-			if len(events) > 0 {
-				eventChan <- events
-			}
+func (repo CloudControllerAppEventsRepository) findNextWithPath(path string) (events []cf.EventFields, nextUrl string, apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s%s", repo.config.Target, path)
+	eventResources := &PaginatedEventResources{}
+	apiResponse = repo.gateway.GetResource(url, repo.config.GetAccessToken(), eventResources)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
 
-			path = eventResources.NextURL
-		}
-		close(eventChan)
-		close(statusChan)
-	}()
+	for _, resource := range eventResources.Resources {
+		events = append(events, cf.EventFields{
+			Timestamp:       resource.Entity.Timestamp,
+			ExitDescription: resource.Entity.ExitDescription,
+			ExitStatus:      resource.Entity.ExitStatus,
+			InstanceIndex:   resource.Entity.InstanceIndex,
+		})
+	}
 
+	nextUrl = eventResources.NextURL
 	return
 }
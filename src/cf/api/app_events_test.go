@@ -81,8 +81,6 @@ func TestListEvents(t *testing.T) {
 	}
 	repo := NewCloudControllerAppEventsRepository(config, net.NewCloudControllerGateway())
 
-	eventChan, apiErr := repo.ListEvents("my-app-guid")
-
 	firstExpectedTime, err := time.Parse(APP_EVENT_TIMESTAMP_FORMAT, "2013-10-07T16:51:07+00:00")
 	secondExpectedTime, err := time.Parse(APP_EVENT_TIMESTAMP_FORMAT, "2013-10-07T17:51:07+00:00")
 	expectedEvents := []cf.EventFields{
@@ -101,14 +99,13 @@ func TestListEvents(t *testing.T) {
 	}
 
 	list := []cf.EventFields{}
-	for events := range eventChan {
+	apiResponse := repo.ListEvents("my-app-guid", func(events []cf.EventFields) bool {
 		list = append(list, events...)
-	}
-
-	_, open := <-apiErr
+		return true
+	})
 
 	assert.NoError(t, err)
-	assert.False(t, open)
+	assert.True(t, apiResponse.IsSuccessful())
 	assert.Equal(t, list, expectedEvents)
 	assert.True(t, handler.AllRequestsCalled())
 }
@@ -130,13 +127,14 @@ func TestListEventsWithNoEvents(t *testing.T) {
 		AccessToken: "BEARER my_access_token",
 	}
 	repo := NewCloudControllerAppEventsRepository(config, net.NewCloudControllerGateway())
-	eventChan, apiErr := repo.ListEvents("my-app-guid")
-
-	_, ok := <-eventChan
-	_, open := <-apiErr
+	called := false
+	apiResponse := repo.ListEvents("my-app-guid", func(events []cf.EventFields) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
-	assert.False(t, open)
+	assert.False(t, called)
+	assert.True(t, apiResponse.IsSuccessful())
 	assert.True(t, handler.AllRequestsCalled())
 }
 
@@ -153,8 +151,6 @@ func TestListEventsNotFound(t *testing.T) {
 		AccessToken: "BEARER my_access_token",
 	}
 	repo := NewCloudControllerAppEventsRepository(config, net.NewCloudControllerGateway())
-	eventChan, apiErr := repo.ListEvents("my-app-guid")
-
 	firstExpectedTime, err := time.Parse(APP_EVENT_TIMESTAMP_FORMAT, "2013-10-07T16:51:07+00:00")
 	expectedEvents := []cf.EventFields{
 		{
@@ -166,11 +162,10 @@ func TestListEventsNotFound(t *testing.T) {
 	}
 
 	list := []cf.EventFields{}
-	for events := range eventChan {
+	apiResponse := repo.ListEvents("my-app-guid", func(events []cf.EventFields) bool {
 		list = append(list, events...)
-	}
-
-	apiResponse := <-apiErr
+		return true
+	})
 
 	assert.NoError(t, err)
 	assert.Equal(t, list, expectedEvents)
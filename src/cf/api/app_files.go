@@ -23,7 +23,7 @@ func NewCloudControllerAppFilesRepository(config *configuration.Configuration, g
 
 func (repo CloudControllerAppFilesRepository) ListFiles(appGuid, path string) (files string, apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/apps/%s/instances/0/files/%s", repo.config.Target, appGuid, path)
-	request, apiResponse := repo.gateway.NewRequest("GET", url, repo.config.AccessToken, nil)
+	request, apiResponse := repo.gateway.NewRequest("GET", url, repo.config.GetAccessToken(), nil)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
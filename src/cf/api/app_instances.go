@@ -21,6 +21,9 @@ type StatsApiResponse map[string]InstanceStatsApiResponse
 
 type InstanceStatsApiResponse struct {
 	Stats struct {
+		Host      string
+		Port      int
+		Uptime    int64
 		DiskQuota uint64 `json:"disk_quota"`
 		MemQuota  uint64 `json:"mem_quota"`
 		Usage     struct {
@@ -33,6 +36,7 @@ type InstanceStatsApiResponse struct {
 
 type AppInstancesRepository interface {
 	GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse)
+	DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerAppInstancesRepository struct {
@@ -48,7 +52,7 @@ func NewCloudControllerAppInstancesRepository(config *configuration.Configuratio
 
 func (repo CloudControllerAppInstancesRepository) GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/instances", repo.config.Target, appGuid)
-	request, apiResponse := repo.gateway.NewRequest("GET", path, repo.config.AccessToken, nil)
+	request, apiResponse := repo.gateway.NewRequest("GET", path, repo.config.GetAccessToken(), nil)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -76,10 +80,15 @@ func (repo CloudControllerAppInstancesRepository) GetInstances(appGuid string) (
 	return repo.updateInstancesWithStats(appGuid, instances)
 }
 
+func (repo CloudControllerAppInstancesRepository) DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/apps/%s/instances/%d", repo.config.Target, appGuid, index)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
+}
+
 func (repo CloudControllerAppInstancesRepository) updateInstancesWithStats(guid string, instances []cf.AppInstanceFields) (updatedInst []cf.AppInstanceFields, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/stats", repo.config.Target, guid)
 	statsResponse := StatsApiResponse{}
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, &statsResponse)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), &statsResponse)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -92,6 +101,9 @@ func (repo CloudControllerAppInstancesRepository) updateInstancesWithStats(guid
 		}
 
 		instance := instances[index]
+		instance.Host = v.Stats.Host
+		instance.Port = v.Stats.Port
+		instance.Uptime = time.Duration(v.Stats.Uptime) * time.Second
 		instance.CpuUsage = v.Stats.Usage.Cpu
 		instance.DiskQuota = v.Stats.DiskQuota
 		instance.DiskUsage = v.Stats.Usage.Disk
@@ -31,6 +31,9 @@ var appStatsRequest = testapi.NewCloudControllerTestRequest(testnet.TestRequest{
   },
   "0":{
     "stats": {
+        "host": "10.0.0.1",
+        "port": 61035,
+        "uptime": 3725,
         "disk_quota": 1073741824,
         "mem_quota": 67108864,
         "usage": {
@@ -76,6 +79,9 @@ func TestAppInstancesGetInstances(t *testing.T) {
 
 	instance0 := instances[0]
 	assert.Equal(t, instance0.Since, time.Unix(1379522342, 0))
+	assert.Equal(t, instance0.Host, "10.0.0.1")
+	assert.Equal(t, instance0.Port, 61035)
+	assert.Equal(t, instance0.Uptime, time.Hour+2*time.Minute+5*time.Second)
 	assert.Exactly(t, instance0.DiskQuota, uint64(1073741824))
 	assert.Exactly(t, instance0.DiskUsage, uint64(56037376))
 	assert.Exactly(t, instance0.MemQuota, uint64(67108864))
@@ -83,6 +89,21 @@ func TestAppInstancesGetInstances(t *testing.T) {
 	assert.Equal(t, instance0.CpuUsage, 3.659571249238058e-05)
 }
 
+func TestAppInstancesDeleteInstance(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "DELETE",
+		Path:     "/v2/apps/my-cool-app-guid/instances/1",
+		Response: testnet.TestResponse{Status: http.StatusNoContent},
+	})
+
+	ts, handler, repo := createAppInstancesRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	apiResponse := repo.DeleteInstance("my-cool-app-guid", 1)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
 func createAppInstancesRepo(t *testing.T, requests []testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo AppInstancesRepository) {
 	ts, handler = testnet.NewTLSServer(t, requests)
 	space := cf.SpaceFields{}
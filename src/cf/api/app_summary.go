@@ -30,6 +30,9 @@ type ApplicationFromSummary struct {
 	Urls             []string
 	State            string
 	SpaceGuid        string `json:"space_guid"`
+	Command          string
+	Buildpack        string
+	EnvironmentJson  map[string]interface{} `json:"environment_json"`
 }
 
 func (resource ApplicationFromSummary) ToFields() (app cf.ApplicationFields) {
@@ -42,6 +45,9 @@ func (resource ApplicationFromSummary) ToFields() (app cf.ApplicationFields) {
 	app.RunningInstances = resource.RunningInstances
 	app.Memory = resource.Memory
 	app.SpaceGuid = resource.SpaceGuid
+	app.Command = resource.Command
+	app.BuildpackUrl = resource.Buildpack
+	app.EnvironmentVars = resource.EnvironmentJson
 
 	return
 }
@@ -60,6 +66,7 @@ func (resource ApplicationFromSummary) ToModel() (app cf.AppSummary) {
 type RouteSummary struct {
 	Guid   string
 	Host   string
+	Path   string
 	Domain DomainSummary
 }
 
@@ -71,6 +78,7 @@ func (resource RouteSummary) ToModel() (route cf.RouteSummary) {
 
 	route.Guid = resource.Guid
 	route.Host = resource.Host
+	route.Path = resource.Path
 	route.Domain = domain
 	return
 }
@@ -83,6 +91,7 @@ type DomainSummary struct {
 
 type AppSummaryRepository interface {
 	GetSummariesInCurrentSpace() (apps []cf.AppSummary, apiResponse net.ApiResponse)
+	GetSummariesInSpace(spaceGuid string) (apps []cf.AppSummary, apiResponse net.ApiResponse)
 	GetSummary(appGuid string) (summary cf.AppSummary, apiResponse net.ApiResponse)
 }
 
@@ -98,10 +107,14 @@ func NewCloudControllerAppSummaryRepository(config *configuration.Configuration,
 }
 
 func (repo CloudControllerAppSummaryRepository) GetSummariesInCurrentSpace() (apps []cf.AppSummary, apiResponse net.ApiResponse) {
+	return repo.GetSummariesInSpace(repo.config.SpaceFields.Guid)
+}
+
+func (repo CloudControllerAppSummaryRepository) GetSummariesInSpace(spaceGuid string) (apps []cf.AppSummary, apiResponse net.ApiResponse) {
 	resources := new(ApplicationSummaries)
 
-	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, repo.config.SpaceFields.Guid)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, spaceGuid)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -115,7 +128,7 @@ func (repo CloudControllerAppSummaryRepository) GetSummariesInCurrentSpace() (ap
 func (repo CloudControllerAppSummaryRepository) GetSummary(appGuid string) (summary cf.AppSummary, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/summary", repo.config.Target, appGuid)
 	summaryResponse := new(ApplicationFromSummary)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, summaryResponse)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), summaryResponse)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -107,6 +107,46 @@ func TestGetAppSummariesInCurrentSpace(t *testing.T) {
 	assert.Equal(t, app2.Memory, uint64(512))
 }
 
+func TestGetAppSummariesInSpace(t *testing.T) {
+	getAppSummariesRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/spaces/other-space-guid/summary",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `
+{
+  "apps":[
+    {
+      "guid":"app-1-guid",
+      "name":"app1",
+      "memory":128,
+      "instances":1,
+      "state":"STOPPED",
+      "command":"bundle exec rackup",
+      "buildpack":"https://github.com/example/buildpack.git",
+      "environment_json":{"RAILS_ENV":"production","WORKERS":4,"CACHE":{"host":"localhost","ports":[11211]}}
+    }
+  ]
+}`},
+	})
+
+	ts, handler, repo := createAppSummaryRepo(t, []testnet.TestRequest{getAppSummariesRequest})
+	defer ts.Close()
+
+	apps, apiResponse := repo.GetSummariesInSpace("other-space-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, len(apps), 1)
+
+	app := apps[0]
+	assert.Equal(t, app.Name, "app1")
+	assert.Equal(t, app.Command, "bundle exec rackup")
+	assert.Equal(t, app.BuildpackUrl, "https://github.com/example/buildpack.git")
+	assert.Equal(t, app.EnvironmentVars, map[string]interface{}{
+		"RAILS_ENV": "production",
+		"WORKERS":   float64(4),
+		"CACHE":     map[string]interface{}{"host": "localhost", "ports": []interface{}{float64(11211)}},
+	})
+}
+
 func createAppSummaryRepo(t *testing.T, requests []testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo AppSummaryRepository) {
 	ts, handler = testnet.NewTLSServer(t, requests)
 	space := cf.SpaceFields{}
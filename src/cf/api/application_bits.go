@@ -6,6 +6,10 @@ import (
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fileutils"
@@ -26,6 +30,10 @@ type AppFileResource struct {
 
 type ApplicationBitsRepository interface {
 	UploadApp(appGuid, dir string) (apiResponse net.ApiResponse)
+	CopyBits(sourceAppGuid, targetAppGuid string) (apiResponse net.ApiResponse)
+	DownloadDroplet(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse)
+	DownloadBits(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse)
+	UploadDroplet(appGuid, dropletPath string) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -83,6 +91,133 @@ func (repo CloudControllerApplicationBitsRepository) UploadApp(appGuid string, a
 	return
 }
 
+func (repo CloudControllerApplicationBitsRepository) CopyBits(sourceAppGuid, targetAppGuid string) (apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/v2/apps/%s/copy_bits", repo.config.Target, targetAppGuid)
+	body := fmt.Sprintf(`{"source_app_guid":"%s"}`, sourceAppGuid)
+
+	request, apiResponse := repo.gateway.NewRequest("POST", url, repo.config.GetAccessToken(), strings.NewReader(body))
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	response := &Resource{}
+	_, apiResponse = repo.gateway.PerformPollingRequestForJSONResponse(request, response)
+	return
+}
+
+func (repo CloudControllerApplicationBitsRepository) DownloadDroplet(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/v2/apps/%s/droplet/download", repo.config.Target, appGuid)
+	return repo.download(url, destination, progress)
+}
+
+func (repo CloudControllerApplicationBitsRepository) DownloadBits(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/v2/apps/%s/download", repo.config.Target, appGuid)
+	return repo.download(url, destination, progress)
+}
+
+// download calls progress each time part of the file has been written; total is
+// -1 when the server did not send a content length.
+func (repo CloudControllerApplicationBitsRepository) download(url string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	request, apiResponse := repo.gateway.NewRequest("GET", url, repo.config.GetAccessToken(), nil)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	body, total, headers, apiResponse := repo.gateway.PerformRequestForBody(request)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+	defer body.Close()
+
+	sha1Hash := sha1.New()
+	md5Hash := md5.New()
+	if progress == nil {
+		progress = func(downloaded, total int64) {}
+	}
+
+	writer := &progressWriter{
+		writer:   io.MultiWriter(destination, sha1Hash, md5Hash),
+		total:    total,
+		progress: progress,
+	}
+
+	_, err := io.Copy(writer, body)
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error downloading", err)
+		return
+	}
+
+	if total >= 0 && writer.written != total {
+		apiResponse = net.NewApiResponseWithMessage("Download incomplete: received %d of %d bytes", writer.written, total)
+		return
+	}
+
+	expectedMd5 := headers.Get("Content-MD5")
+	if expectedMd5 != "" && expectedMd5 != base64.StdEncoding.EncodeToString(md5Hash.Sum(nil)) {
+		apiResponse = net.NewApiResponseWithMessage("Checksum mismatch: the downloaded file does not match the Content-MD5 sent by the server")
+		return
+	}
+
+	sha1Sum = hex.EncodeToString(sha1Hash.Sum(nil))
+	return
+}
+
+type progressWriter struct {
+	writer   io.Writer
+	written  int64
+	total    int64
+	progress func(downloaded, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	w.written += int64(n)
+	w.progress(w.written, w.total)
+	return
+}
+
+func (repo CloudControllerApplicationBitsRepository) UploadDroplet(appGuid, dropletPath string) (apiResponse net.ApiResponse) {
+	droplet, err := os.Open(dropletPath)
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error opening droplet", err)
+		return
+	}
+	defer droplet.Close()
+
+	url := fmt.Sprintf("%s/v2/apps/%s/droplet/upload", repo.config.Target, appGuid)
+
+	fileutils.TempFile("droplet-requests", func(requestFile *os.File, err error) {
+		if err != nil {
+			apiResponse = net.NewApiResponseWithError("Error creating tmp file", err)
+			return
+		}
+
+		writer := multipart.NewWriter(requestFile)
+		part, err := writer.CreateFormFile("droplet", filepath.Base(dropletPath))
+		if err == nil {
+			_, err = io.Copy(part, droplet)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			apiResponse = net.NewApiResponseWithError("Error writing to tmp file", err)
+			return
+		}
+
+		var request *net.Request
+		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.GetAccessToken(), requestFile)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+		request.HttpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+		response := &Resource{}
+		_, apiResponse = repo.gateway.PerformPollingRequestForJSONResponse(request, response)
+	})
+	return
+}
+
 func (repo CloudControllerApplicationBitsRepository) uploadBits(appGuid string, zipFile *os.File, presentResourcesJson []byte) (apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/apps/%s/bits", repo.config.Target, appGuid)
 
@@ -99,7 +234,7 @@ func (repo CloudControllerApplicationBitsRepository) uploadBits(appGuid string,
 		}
 
 		var request *net.Request
-		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, requestFile)
+		request, apiResponse = repo.gateway.NewRequest("PUT", url, repo.config.GetAccessToken(), requestFile)
 		if apiResponse.IsNotSuccessful() {
 			return
 		}
@@ -224,7 +359,7 @@ func (repo CloudControllerApplicationBitsRepository) getFilesToUpload(allAppFile
 	}
 
 	path := fmt.Sprintf("%s/v2/resource_match", repo.config.Target)
-	req, apiResponse := repo.gateway.NewRequest("PUT", path, repo.config.AccessToken, bytes.NewReader(allAppFilesJson))
+	req, apiResponse := repo.gateway.NewRequest("PUT", path, repo.config.GetAccessToken(), bytes.NewReader(allAppFilesJson))
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -2,13 +2,20 @@ package api
 
 import (
 	"archive/zip"
+	"bytes"
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
 	"fileutils"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -260,3 +267,167 @@ func testUploadApp(t *testing.T, dir string, requests []testnet.TestRequest) (ap
 
 	return
 }
+
+func TestCopyBits(t *testing.T) {
+	copyBitsRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:  "POST",
+		Path:    "/v2/apps/target-app-guid/copy_bits",
+		Matcher: testnet.RequestBodyMatcher(`{"source_app_guid":"source-app-guid"}`),
+		Response: testnet.TestResponse{
+			Status: http.StatusCreated,
+			Body: `
+{
+	"metadata":{
+		"guid": "my-job-guid",
+		"url": "/v2/jobs/my-job-guid"
+	}
+}`},
+	})
+
+	requests := []testnet.TestRequest{
+		copyBitsRequest,
+		createProgressEndpoint("running"),
+		createProgressEndpoint("finished"),
+	}
+
+	apiResponse := testCopyBits(t, requests)
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestCopyBitsWhenTheJobFails(t *testing.T) {
+	copyBitsRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "POST",
+		Path:   "/v2/apps/target-app-guid/copy_bits",
+		Response: testnet.TestResponse{
+			Status: http.StatusCreated,
+			Body:   `{"metadata":{"guid": "my-job-guid", "url": "/v2/jobs/my-job-guid"}}`,
+		},
+	})
+
+	requests := []testnet.TestRequest{
+		copyBitsRequest,
+		createProgressEndpoint("failed"),
+	}
+
+	apiResponse := testCopyBits(t, requests)
+	assert.True(t, apiResponse.IsNotSuccessful())
+}
+
+func testCopyBits(t *testing.T, requests []testnet.TestRequest) (apiResponse net.ApiResponse) {
+	ts, handler := testnet.NewTLSServer(t, requests)
+	defer ts.Close()
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	gateway := net.NewCloudControllerGateway()
+	gateway.PollingThrottle = time.Duration(0)
+	repo := NewCloudControllerApplicationBitsRepository(config, gateway, cf.ApplicationZipper{})
+
+	apiResponse = repo.CopyBits("source-app-guid", "target-app-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	return
+}
+
+func TestDownloadDroplet(t *testing.T) {
+	contents := "droplet contents\n"
+	md5Sum := md5.Sum([]byte(contents))
+	sha1Sum := sha1.Sum([]byte(contents))
+
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/apps/my-app-guid/droplet/download",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body:   "droplet contents",
+			Header: http.Header{
+				"Content-Type": {"application/octet-stream"},
+				"Content-MD5":  {base64.StdEncoding.EncodeToString(md5Sum[:])},
+			},
+		},
+	})
+
+	ts, handler, repo := createAppBitsRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	destination := &bytes.Buffer{}
+	var downloaded int64
+	checksum, apiResponse := repo.DownloadDroplet("my-app-guid", destination, func(written, total int64) {
+		downloaded = written
+	})
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, destination.String(), contents)
+	assert.Equal(t, downloaded, int64(len(contents)))
+	assert.Equal(t, checksum, hex.EncodeToString(sha1Sum[:]))
+}
+
+func TestDownloadBitsWithABadChecksum(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/apps/my-app-guid/download",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body:   "package contents",
+			Header: http.Header{"Content-MD5": {"bm90IHRoZSByaWdodCBzdW0="}},
+		},
+	})
+
+	ts, handler, repo := createAppBitsRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	checksum, apiResponse := repo.DownloadBits("my-app-guid", ioutil.Discard, nil)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Checksum mismatch")
+	assert.Equal(t, checksum, "")
+}
+
+func TestUploadDroplet(t *testing.T) {
+	dropletFile, err := ioutil.TempFile("", "droplet")
+	assert.NoError(t, err)
+	defer os.Remove(dropletFile.Name())
+	dropletFile.WriteString("droplet contents")
+	dropletFile.Close()
+
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "PUT",
+		Path:   "/v2/apps/my-app-guid/droplet/upload",
+		Matcher: func(t *testing.T, request *http.Request) {
+			file, _, err := request.FormFile("droplet")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer file.Close()
+
+			contents, err := ioutil.ReadAll(file)
+			assert.NoError(t, err)
+			assert.Equal(t, string(contents), "droplet contents")
+		},
+		Response: testnet.TestResponse{
+			Status: http.StatusCreated,
+			Body:   `{"metadata":{"guid": "my-job-guid", "url": "/v2/jobs/my-job-guid"}}`,
+		},
+	})
+
+	ts, handler, repo := createAppBitsRepo(t, []testnet.TestRequest{request, createProgressEndpoint("finished")})
+	defer ts.Close()
+
+	apiResponse := repo.UploadDroplet("my-app-guid", dropletFile.Name())
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func createAppBitsRepo(t *testing.T, requests []testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo ApplicationBitsRepository) {
+	ts, handler = testnet.NewTLSServer(t, requests)
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	gateway := net.NewCloudControllerGateway()
+	gateway.PollingThrottle = time.Duration(0)
+	repo = NewCloudControllerApplicationBitsRepository(config, gateway, cf.ApplicationZipper{})
+	return
+}
@@ -12,6 +12,7 @@ import (
 
 type AppRouteEntity struct {
 	Host   string
+	Path   string
 	Domain Resource
 }
 
@@ -23,6 +24,7 @@ type AppRouteResource struct {
 func (resource AppRouteResource) ToFields() (route cf.RouteFields) {
 	route.Guid = resource.Metadata.Guid
 	route.Host = resource.Entity.Host
+	route.Path = resource.Entity.Path
 	return
 }
 
@@ -34,14 +36,18 @@ func (resource AppRouteResource) ToModel() (route cf.RouteSummary) {
 }
 
 type ApplicationEntity struct {
-	Name            string
-	State           string
-	SpaceGuid       string `json:"space_guid"`
-	Instances       int
-	Memory          int
-	Stack           StackResource
-	Routes          []AppRouteResource
-	EnvironmentJson map[string]string `json:"environment_json"`
+	Name                    string
+	State                   string
+	SpaceGuid               string `json:"space_guid"`
+	Instances               int
+	Memory                  int
+	DiskQuota               int    `json:"disk_quota"`
+	DockerImage             string `json:"docker_image"`
+	HealthCheckType         string `json:"health_check_type"`
+	HealthCheckHttpEndpoint string `json:"health_check_http_endpoint"`
+	Stack                   StackResource
+	Routes                  []AppRouteResource
+	EnvironmentJson         map[string]interface{} `json:"environment_json"`
 }
 
 type ApplicationResource struct {
@@ -56,6 +62,10 @@ func (resource ApplicationResource) ToFields() (app cf.ApplicationFields) {
 	app.State = strings.ToLower(resource.Entity.State)
 	app.InstanceCount = resource.Entity.Instances
 	app.Memory = uint64(resource.Entity.Memory)
+	app.DiskQuota = uint64(resource.Entity.DiskQuota)
+	app.DockerImage = resource.Entity.DockerImage
+	app.HealthCheckType = resource.Entity.HealthCheckType
+	app.HealthCheckHttpEndpoint = resource.Entity.HealthCheckHttpEndpoint
 	app.SpaceGuid = resource.Entity.SpaceGuid
 	return
 }
@@ -77,7 +87,9 @@ type PaginatedApplicationResources struct {
 type ApplicationRepository interface {
 	Create(params cf.AppParams) (createdApp cf.Application, apiResponse net.ApiResponse)
 	Read(name string) (app cf.Application, apiResponse net.ApiResponse)
+	ReadFromSpace(name, spaceGuid string) (app cf.Application, apiResponse net.ApiResponse)
 	Update(appGuid string, params cf.AppParams) (updatedApp cf.Application, apiResponse net.ApiResponse)
+	Restage(appGuid string) (restagedApp cf.Application, apiResponse net.ApiResponse)
 	Delete(appGuid string) (apiResponse net.ApiResponse)
 }
 
@@ -100,7 +112,7 @@ func (repo CloudControllerApplicationRepository) Create(params cf.AppParams) (cr
 
 	path := fmt.Sprintf("%s/v2/apps", repo.config.Target)
 	resource := new(ApplicationResource)
-	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, strings.NewReader(data), resource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(data), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -110,9 +122,13 @@ func (repo CloudControllerApplicationRepository) Create(params cf.AppParams) (cr
 }
 
 func (repo CloudControllerApplicationRepository) Read(name string) (app cf.Application, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/spaces/%s/apps?q=name%s&inline-relations-depth=1", repo.config.Target, repo.config.SpaceFields.Guid, "%3A"+name)
+	return repo.ReadFromSpace(name, repo.config.SpaceFields.Guid)
+}
+
+func (repo CloudControllerApplicationRepository) ReadFromSpace(name, spaceGuid string) (app cf.Application, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/spaces/%s/apps?q=name%s&inline-relations-depth=1", repo.config.Target, spaceGuid, "%3A"+name)
 	appResources := new(PaginatedApplicationResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, appResources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), appResources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -135,7 +151,7 @@ func (repo CloudControllerApplicationRepository) Update(appGuid string, params c
 
 	path := fmt.Sprintf("%s/v2/apps/%s?inline-relations-depth=1", repo.config.Target, appGuid)
 	resource := new(ApplicationResource)
-	apiResponse = repo.gateway.UpdateResourceForResponse(path, repo.config.AccessToken, strings.NewReader(data), resource)
+	apiResponse = repo.gateway.UpdateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(data), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -144,9 +160,23 @@ func (repo CloudControllerApplicationRepository) Update(appGuid string, params c
 	return
 }
 
+func (repo CloudControllerApplicationRepository) Restage(appGuid string) (restagedApp cf.Application, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/apps/%s/restage", repo.config.Target, appGuid)
+	resource := new(ApplicationResource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(""), resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	restagedApp = resource.ToModel()
+	return
+}
+
 var allowedAppKeys = []string{
 	"buildpack",
 	"command",
+	"disk_quota",
+	"docker_image",
 	"instances",
 	"memory",
 	"name",
@@ -154,6 +184,8 @@ var allowedAppKeys = []string{
 	"stack_guid",
 	"state",
 	"health_check_timeout",
+	"health_check_type",
+	"health_check_http_endpoint",
 }
 
 func (repo CloudControllerApplicationRepository) formatAppJSON(input cf.AppParams) (data string, apiResponse net.ApiResponse) {
@@ -209,5 +241,5 @@ func (repo CloudControllerApplicationRepository) formatAppJSON(input cf.AppParam
 
 func (repo CloudControllerApplicationRepository) Delete(appGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s?recursive=true", repo.config.Target, appGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
@@ -29,6 +29,9 @@ var singleAppResponse = testnet.TestResponse{
       		"baz": "boom"
     	},
         "memory": 128,
+        "disk_quota": 512,
+        "health_check_type": "http",
+        "health_check_http_endpoint": "/health",
         "instances": 1,
         "state": "STOPPED",
         "stack": {
@@ -78,8 +81,11 @@ func TestFindByName(t *testing.T) {
 	assert.Equal(t, app.Name, "App1")
 	assert.Equal(t, app.Guid, "app1-guid")
 	assert.Equal(t, app.Memory, uint64(128))
+	assert.Equal(t, app.DiskQuota, uint64(512))
+	assert.Equal(t, app.HealthCheckType, "http")
+	assert.Equal(t, app.HealthCheckHttpEndpoint, "/health")
 	assert.Equal(t, app.InstanceCount, 1)
-	assert.Equal(t, app.EnvironmentVars, map[string]string{"foo": "bar", "baz": "boom"})
+	assert.Equal(t, app.EnvironmentVars, map[string]interface{}{"foo": "bar", "baz": "boom"})
 	assert.Equal(t, app.Routes[0].Host, "app1")
 	assert.Equal(t, app.Routes[0].Domain.Name, "cfapps.io")
 	assert.Equal(t, app.Stack.Name, "awesome-stacks-ahoy")
@@ -98,6 +104,19 @@ func TestFindByNameWhenAppIsNotFound(t *testing.T) {
 	assert.True(t, apiResponse.IsNotFound())
 }
 
+func TestFindByNameInAnotherSpace(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(findAppRequest)
+	request.Path = "/v2/spaces/other-space-guid/apps?q=name%3AApp1&inline-relations-depth=1"
+
+	ts, handler, repo := createAppRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	app, apiResponse := repo.ReadFromSpace("App1", "other-space-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, app.Guid, "app1-guid")
+}
+
 func TestSetEnv(t *testing.T) {
 	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "PUT",
@@ -293,6 +312,46 @@ func TestUpdateApplication(t *testing.T) {
 	assert.Equal(t, updatedApp.Guid, "my-cool-app-guid")
 }
 
+func TestUpdateApplicationDiskQuotaAndHealthCheck(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/my-app-guid",
+		Matcher:  testnet.RequestBodyMatcher(`{"disk_quota":2048,"health_check_type":"http","health_check_http_endpoint":"/health"}`),
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: updateApplicationResponse},
+	})
+
+	ts, handler, repo := createAppRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	params := cf.NewEmptyAppParams()
+	params.Set("disk_quota", uint64(2048))
+	params.Set("health_check_type", "http")
+	params.Set("health_check_http_endpoint", "/health")
+
+	_, apiResponse := repo.Update("my-app-guid", params)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestUpdateApplicationDockerImage(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "PUT",
+		Path:     "/v2/apps/my-app-guid",
+		Matcher:  testnet.RequestBodyMatcher(`{"docker_image":"repo/my-app:1.0"}`),
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: updateApplicationResponse},
+	})
+
+	ts, handler, repo := createAppRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	params := cf.NewEmptyAppParams()
+	params.Set("docker_image", "repo/my-app:1.0")
+
+	_, apiResponse := repo.Update("my-app-guid", params)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
 func TestUpdateApplicationSetCommandToNull(t *testing.T) {
 	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "PUT",
@@ -348,6 +407,26 @@ func TestUpdateRejectsInproperNames(t *testing.T) {
 	assert.True(t, apiResponse.IsSuccessful())
 }
 
+func TestRestageApplication(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "POST",
+		Path:   "/v2/apps/my-cool-app-guid/restage",
+		Response: testnet.TestResponse{
+			Status: http.StatusCreated,
+			Body:   updateApplicationResponse,
+		},
+	})
+
+	ts, handler, repo := createAppRepo(t, []testnet.TestRequest{request})
+	defer ts.Close()
+
+	restagedApp, apiResponse := repo.Restage("my-cool-app-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, restagedApp.Name, "my-cool-app")
+	assert.Equal(t, restagedApp.Guid, "my-cool-app-guid")
+}
+
 func TestDeleteApplication(t *testing.T) {
 	deleteApplicationRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "DELETE",
@@ -1,18 +1,29 @@
 package api
 
 import (
+	"cf"
 	"cf/configuration"
 	"cf/net"
 	"cf/terminal"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
-	"os"
 	"strings"
 )
 
+const (
+	defaultClientId = "cf"
+
+	passwordGrant          = "password"
+	refreshTokenGrant      = "refresh_token"
+	clientCredentialsGrant = "client_credentials"
+)
+
 type AuthenticationRepository interface {
 	Authenticate(email string, password string) (apiResponse net.ApiResponse)
+	AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse)
+	AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse)
 	RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse)
 }
 
@@ -33,36 +44,72 @@ func (uaa UAAAuthenticationRepository) Authenticate(email string, password strin
 	data := url.Values{
 		"username":   {email},
 		"password":   {password},
-		"grant_type": {"password"},
+		"grant_type": {passwordGrant},
 		"scope":      {""},
 	}
 
-	apiResponse = uaa.getAuthToken(data)
+	apiResponse = uaa.getAuthToken(defaultClientId, "", data)
 	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
 		apiResponse.Message = "Password is incorrect, please try again."
 	}
 	return
 }
 
-func (uaa UAAAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+func (uaa UAAAuthenticationRepository) AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse) {
 	data := url.Values{
-		"refresh_token": {uaa.config.RefreshToken},
-		"grant_type":    {"refresh_token"},
-		"scope":         {""},
+		"passcode":   {passcode},
+		"grant_type": {passwordGrant},
+		"scope":      {""},
 	}
 
-	apiResponse = uaa.getAuthToken(data)
-	updatedToken = uaa.config.AccessToken
+	apiResponse = uaa.getAuthToken(defaultClientId, "", data)
+	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
+		apiResponse.Message = "Passcode is incorrect or expired, please try again."
+	}
+	return
+}
 
-	if apiResponse.IsError() {
-		fmt.Printf("%s\n\n", terminal.NotLoggedInText())
-		os.Exit(1)
+func (uaa UAAAuthenticationRepository) AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse) {
+	data := url.Values{
+		"grant_type": {clientCredentialsGrant},
 	}
 
+	apiResponse = uaa.getAuthToken(clientId, clientSecret, data)
+	if apiResponse.IsNotSuccessful() && apiResponse.StatusCode == 401 {
+		apiResponse.Message = "Client credentials are incorrect, please try again."
+	}
 	return
 }
 
-func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiResponse net.ApiResponse) {
+func (uaa UAAAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+	if uaa.config.HasClientCredentials() {
+		apiResponse = uaa.getAuthToken(uaa.config.ClientId, uaa.config.ClientSecret, url.Values{
+			"grant_type": {clientCredentialsGrant},
+		})
+	} else {
+		apiResponse = uaa.getAuthToken(defaultClientId, "", url.Values{
+			"refresh_token": {uaa.config.GetRefreshToken()},
+			"grant_type":    {refreshTokenGrant},
+			"scope":         {""},
+		})
+	}
+	updatedToken = uaa.config.GetAccessToken()
+
+	if apiResponse.IsError() && isRejectedGrant(apiResponse) {
+		apiResponse = net.NewApiResponseWithMessage(
+			"Your session has expired. Use '%s' to log in again.",
+			terminal.CommandColor(cf.Name()+" login"),
+		)
+	}
+
+	return
+}
+
+func isRejectedGrant(apiResponse net.ApiResponse) bool {
+	return apiResponse.StatusCode == http.StatusBadRequest || apiResponse.StatusCode == http.StatusUnauthorized
+}
+
+func (uaa UAAAuthenticationRepository) getAuthToken(clientId, clientSecret string, data url.Values) (apiResponse net.ApiResponse) {
 	type uaaErrorResponse struct {
 		Code        string `json:"error"`
 		Description string `json:"error_description"`
@@ -76,7 +123,8 @@ func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiRespons
 	}
 
 	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthorizationEndpoint)
-	request, apiResponse := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte("cf:")), strings.NewReader(data.Encode()))
+	credentials := base64.StdEncoding.EncodeToString([]byte(clientId + ":" + clientSecret))
+	request, apiResponse := uaa.gateway.NewRequest("POST", path, "Basic "+credentials, strings.NewReader(data.Encode()))
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -94,8 +142,16 @@ func (uaa UAAAuthenticationRepository) getAuthToken(data url.Values) (apiRespons
 		return
 	}
 
-	uaa.config.AccessToken = fmt.Sprintf("%s %s", response.TokenType, response.AccessToken)
-	uaa.config.RefreshToken = response.RefreshToken
+	uaa.config.SetTokens(fmt.Sprintf("%s %s", response.TokenType, response.AccessToken), response.RefreshToken)
+
+	if data.Get("grant_type") == clientCredentialsGrant {
+		uaa.config.ClientId = clientId
+		uaa.config.ClientSecret = clientSecret
+	} else {
+		uaa.config.ClientId = ""
+		uaa.config.ClientSecret = ""
+	}
+
 	err := uaa.configRepo.Save()
 	if err != nil {
 		apiResponse = net.NewApiResponseWithError("Error setting configuration", err)
@@ -141,3 +141,148 @@ func setupAuthWithEndpoint(t *testing.T, request testnet.TestRequest) (ts *httpt
 	auth = NewUAAAuthenticationRepository(gateway, configRepo)
 	return
 }
+
+var successfulPasscodeLoginRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Header: authHeaders,
+	Matcher: func(t *testing.T, request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			assert.Fail(t, "Failed to parse form: %s", err)
+			return
+		}
+
+		assert.Equal(t, request.Form.Get("passcode"), "my-passcode", "Passcode did not match.")
+		assert.Equal(t, request.Form.Get("grant_type"), "password", "Grant type did not match.")
+		assert.Empty(t, request.Form.Get("username"))
+	},
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "access_token": "my_access_token",
+  "token_type": "BEARER",
+  "refresh_token": "my_refresh_token"
+} `},
+}
+
+func TestSuccessfullyLoggingInWithPasscode(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, successfulPasscodeLoginRequest)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithPasscode("my-passcode")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, savedConfig.AccessToken, "BEARER my_access_token")
+	assert.Equal(t, savedConfig.RefreshToken, "my_refresh_token")
+	assert.False(t, savedConfig.HasClientCredentials())
+}
+
+func TestUnsuccessfullyLoggingInWithPasscode(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, unsuccessfulLoginRequest)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithPasscode("expired-passcode")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.Message, "Passcode is incorrect or expired, please try again.")
+	assert.Empty(t, savedConfig.AccessToken)
+}
+
+var clientCredentialsRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Header: http.Header{
+		"accept":        {"application/json"},
+		"content-type":  {"application/x-www-form-urlencoded"},
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("my-client:my-secret"))},
+	},
+	Matcher: func(t *testing.T, request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			assert.Fail(t, "Failed to parse form: %s", err)
+			return
+		}
+
+		assert.Equal(t, request.Form.Get("grant_type"), "client_credentials", "Grant type did not match.")
+	},
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "access_token": "my_client_token",
+  "token_type": "BEARER"
+} `},
+}
+
+func TestSuccessfullyLoggingInWithClientCredentials(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, clientCredentialsRequest)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithClientCredentials("my-client", "my-secret")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, savedConfig.AccessToken, "BEARER my_client_token")
+	assert.Empty(t, savedConfig.RefreshToken)
+	assert.Equal(t, savedConfig.ClientId, "my-client")
+	assert.Equal(t, savedConfig.ClientSecret, "my-secret")
+}
+
+func TestUnsuccessfullyLoggingInWithClientCredentials(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, unsuccessfulLoginRequest)
+	defer ts.Close()
+
+	apiResponse := auth.AuthenticateWithClientCredentials("my-client", "wrong-secret")
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.Message, "Client credentials are incorrect, please try again.")
+	assert.Empty(t, savedConfig.ClientId)
+	assert.Empty(t, savedConfig.ClientSecret)
+}
+
+func TestRefreshingClientTokenUsesClientCredentialsGrant(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, clientCredentialsRequest)
+	defer ts.Close()
+
+	auth.config.ClientId = "my-client"
+	auth.config.ClientSecret = "my-secret"
+
+	updatedToken, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, updatedToken, "BEARER my_client_token")
+	assert.Equal(t, testconfig.SavedConfiguration.ClientId, "my-client")
+}
+
+var expiredRefreshTokenRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Response: testnet.TestResponse{
+		Status: http.StatusUnauthorized,
+		Body:   `{"error":"invalid_token","error_description":"Invalid refresh token (expired)"}`,
+	},
+}
+
+func TestRefreshingWithAnExpiredRefreshTokenReturnsAnError(t *testing.T) {
+	ts, handler, auth := setupAuthWithEndpoint(t, expiredRefreshTokenRequest)
+	defer ts.Close()
+
+	auth.config.AccessToken = "BEARER old_access_token"
+	auth.config.RefreshToken = "expired_refresh_token"
+
+	_, apiResponse := auth.RefreshAuthToken()
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsError())
+	assert.Contains(t, apiResponse.Message, "Your session has expired")
+}
@@ -62,7 +62,7 @@ func (repo CloudControllerBuildpackBitsRepository) uploadBits(buildpack cf.Build
 			return
 		}
 
-		request, apiResponse := repo.gateway.NewRequest("PUT", url, repo.config.AccessToken, requestFile)
+		request, apiResponse := repo.gateway.NewRequest("PUT", url, repo.config.GetAccessToken(), requestFile)
 		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
 		request.HttpReq.Header.Set("Content-Type", contentType)
 		if apiResponse.IsNotSuccessful() {
@@ -31,7 +31,7 @@ type BuildpackEntity struct {
 
 type BuildpackRepository interface {
 	FindByName(name string) (buildpack cf.Buildpack, apiResponse net.ApiResponse)
-	ListBuildpacks(stop chan bool) (buildpacksChan chan []cf.Buildpack, statusChan chan net.ApiResponse)
+	ListBuildpacks(cb func([]cf.Buildpack) bool) (apiResponse net.ApiResponse)
 	Create(name string, position *int) (createdBuildpack cf.Buildpack, apiResponse net.ApiResponse)
 	Delete(buildpackGuid string) (apiResponse net.ApiResponse)
 	Update(buildpack cf.Buildpack) (updatedBuildpack cf.Buildpack, apiResponse net.ApiResponse)
@@ -48,42 +48,12 @@ func NewCloudControllerBuildpackRepository(config *configuration.Configuration,
 	return
 }
 
-func (repo CloudControllerBuildpackRepository) ListBuildpacks(stop chan bool) (buildpacksChan chan []cf.Buildpack, statusChan chan net.ApiResponse) {
-	buildpacksChan = make(chan []cf.Buildpack, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := buildpacks_path
-
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					buildpacks  []cf.Buildpack
-					apiResponse net.ApiResponse
-				)
-				buildpacks, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(buildpacksChan)
-					close(statusChan)
-					return
-				}
-
-				if len(buildpacks) > 0 {
-					buildpacksChan <- buildpacks
-				}
-			}
-		}
-		close(buildpacksChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
-
-	return
+func (repo CloudControllerBuildpackRepository) ListBuildpacks(cb func([]cf.Buildpack) bool) (apiResponse net.ApiResponse) {
+	return NewPaginatedIterator(buildpacks_path, func(path string) (interface{}, string, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Buildpack))
+	})
 }
 
 func (repo CloudControllerBuildpackRepository) FindByName(name string) (buildpack cf.Buildpack, apiResponse net.ApiResponse) {
@@ -105,7 +75,7 @@ func (repo CloudControllerBuildpackRepository) FindByName(name string) (buildpac
 func (repo CloudControllerBuildpackRepository) findNextWithPath(path string) (buildpacks []cf.Buildpack, nextUrl string, apiResponse net.ApiResponse) {
 	response := new(PaginatedBuildpackResources)
 
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, response)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), response)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -129,7 +99,7 @@ func (repo CloudControllerBuildpackRepository) Create(name string, position *int
 	}
 
 	resource := new(BuildpackResource)
-	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, bytes.NewReader(body), resource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), bytes.NewReader(body), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -140,7 +110,7 @@ func (repo CloudControllerBuildpackRepository) Create(name string, position *int
 
 func (repo CloudControllerBuildpackRepository) Delete(buildpackGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s%s/%s", repo.config.Target, buildpacks_path, buildpackGuid)
-	apiResponse = repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	apiResponse = repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 	return
 }
 
@@ -155,7 +125,7 @@ func (repo CloudControllerBuildpackRepository) Update(buildpack cf.Buildpack) (u
 	}
 
 	resource := new(BuildpackResource)
-	apiResponse = repo.gateway.UpdateResourceForResponse(path, repo.config.AccessToken, bytes.NewReader(body), resource)
+	apiResponse = repo.gateway.UpdateResourceForResponse(path, repo.config.GetAccessToken(), bytes.NewReader(body), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
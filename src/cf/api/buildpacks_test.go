@@ -57,10 +57,6 @@ func TestBuildpacksListBuildpacks(t *testing.T) {
 	ts, handler, repo := createBuildpackRepo(t, firstRequest, secondRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	buildpacksChan, statusChan := repo.ListBuildpacks(stopChan)
-
 	one := 1
 	buildpack := cf.Buildpack{}
 	buildpack.Guid = "buildpack1-guid"
@@ -76,10 +72,10 @@ func TestBuildpacksListBuildpacks(t *testing.T) {
 	expectedBuildpacks := []cf.Buildpack{buildpack, buildpack2}
 
 	buildpacks := []cf.Buildpack{}
-	for chunk := range buildpacksChan {
+	apiResponse := repo.ListBuildpacks(func(chunk []cf.Buildpack) bool {
 		buildpacks = append(buildpacks, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, buildpacks, expectedBuildpacks)
 	assert.True(t, handler.AllRequestsCalled())
@@ -99,14 +95,13 @@ func TestBuildpacksListBuildpacksWithNoBuildpacks(t *testing.T) {
 	ts, handler, repo := createBuildpackRepo(t, emptyBuildpacksRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	buildpacksChan, statusChan := repo.ListBuildpacks(stopChan)
-
-	_, ok := <-buildpacksChan
-	apiResponse := <-statusChan
+	called := false
+	apiResponse := repo.ListBuildpacks(func(chunk []cf.Buildpack) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.False(t, called)
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsSuccessful())
 }
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"cf/configuration"
+	"cf/net"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// CurlRepository performs raw requests. Error statuses are returned like any
+// other response; the api response only fails when no response came back.
+type CurlRepository interface {
+	Request(method, path string, headers []string, body string) (resHeaders, resBody string, apiResponse net.ApiResponse)
+}
+
+type CloudControllerCurlRepository struct {
+	config  *configuration.Configuration
+	gateway net.Gateway
+}
+
+func NewCloudControllerCurlRepository(config *configuration.Configuration, gateway net.Gateway) (repo CloudControllerCurlRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo CloudControllerCurlRepository) Request(method, path string, headers []string, body string) (resHeaders, resBody string, apiResponse net.ApiResponse) {
+	url := fmt.Sprintf("%s/%s", repo.config.Target, strings.TrimLeft(path, "/"))
+
+	var bodyReader io.ReadSeeker
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+
+	req, apiResponse := repo.gateway.NewRequest(method, url, repo.config.GetAccessToken(), bodyReader)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	err := mergeHeaders(req.HttpReq.Header, strings.Join(headers, "\n"))
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error parsing headers", err)
+		return
+	}
+
+	statusCode, responseHeaders, resBytes, apiResponse := repo.gateway.PerformRequestForRawResponse(req)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	resHeaders = presentResponseHeaders(statusCode, responseHeaders)
+	resBody = string(resBytes)
+	return
+}
+
+func mergeHeaders(destination http.Header, headerString string) (err error) {
+	headerString = strings.TrimSpace(headerString)
+	if headerString == "" {
+		return
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(headerString + "\r\n\r\n")))
+	headers, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return
+	}
+
+	for key, values := range headers {
+		destination.Del(key)
+		for _, value := range values {
+			destination.Add(key, value)
+		}
+	}
+	return
+}
+
+func presentResponseHeaders(statusCode int, headers http.Header) string {
+	writer := new(bytes.Buffer)
+	fmt.Fprintf(writer, "HTTP/1.1 %d %s\r\n", statusCode, http.StatusText(statusCode))
+	headers.Write(writer)
+	return writer.String()
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"cf/configuration"
+	"cf/net"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	testapi "testhelpers/api"
+	testnet "testhelpers/net"
+	"testing"
+)
+
+func TestCurlGetRequest(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/endpoint",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Header: http.Header{"Content-Type": {"application/json"}},
+			Body:   `{"resources": []}`,
+		},
+	})
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	headers, body, apiResponse := repo.Request("GET", "/v2/endpoint", []string{}, "")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Contains(t, headers, "HTTP/1.1 200 OK")
+	assert.Contains(t, headers, "Content-Type: application/json")
+	assert.Contains(t, body, `{"resources": []}`)
+}
+
+func TestCurlPostRequestWithCustomHeaders(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:  "POST",
+		Path:    "/v2/endpoint",
+		Matcher: testnet.RequestBodyMatcherWithContentType(`q=name:my-app`, "application/x-www-form-urlencoded"),
+		Response: testnet.TestResponse{
+			Status: http.StatusCreated,
+			Body:   `{}`,
+		},
+	})
+	req.Header.Set("X-Custom", "custom-value")
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	headers, _, apiResponse := repo.Request("POST", "v2/endpoint", []string{"Content-Type: application/x-www-form-urlencoded", "X-Custom: custom-value"}, "q=name:my-app")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Contains(t, headers, "HTTP/1.1 201 Created")
+}
+
+func TestCurlWithInvalidHeaders(t *testing.T) {
+	ts, _, repo := createCurlRepo(t, testnet.TestRequest{})
+	defer ts.Close()
+
+	_, _, apiResponse := repo.Request("GET", "/v2/endpoint", []string{"not a header"}, "")
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Error parsing headers")
+}
+
+func TestCurlWhenTheServerReturnsAnError(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/not-there",
+		Response: testnet.TestResponse{
+			Status: http.StatusNotFound,
+			Body:   `{"code": 10000, "description": "Unknown request"}`,
+		},
+	})
+
+	ts, handler, repo := createCurlRepo(t, req)
+	defer ts.Close()
+
+	headers, body, apiResponse := repo.Request("GET", "/v2/not-there", []string{}, "")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, apiResponse.StatusCode, http.StatusNotFound)
+	assert.Contains(t, headers, "HTTP/1.1 404 Not Found")
+	assert.Equal(t, body, `{"code": 10000, "description": "Unknown request"}`)
+}
+
+func createCurlRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo CurlRepository) {
+	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
+
+	config := &configuration.Configuration{
+		AccessToken: "BEARER my_access_token",
+		Target:      ts.URL,
+	}
+	gateway := net.NewCloudControllerGateway()
+	repo = NewCloudControllerCurlRepository(config, gateway)
+	return
+}
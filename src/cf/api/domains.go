@@ -43,7 +43,7 @@ type DomainEntity struct {
 }
 
 type DomainRepository interface {
-	ListDomainsForOrg(orgGuid string, stop chan bool) (domainsChan chan []cf.Domain, statusChan chan net.ApiResponse)
+	ListDomainsForOrg(orgGuid string, cb func([]cf.Domain) bool) (apiResponse net.ApiResponse)
 	FindByName(name string) (domain cf.Domain, apiResponse net.ApiResponse)
 	FindByNameInCurrentSpace(name string) (domain cf.Domain, apiResponse net.ApiResponse)
 	FindByNameInOrg(name string, owningOrgGuid string) (domain cf.Domain, apiResponse net.ApiResponse)
@@ -65,49 +65,22 @@ func NewCloudControllerDomainRepository(config *configuration.Configuration, gat
 	return
 }
 
-func (repo CloudControllerDomainRepository) ListDomainsForOrg(orgGuid string, stop chan bool) (domainsChan chan []cf.Domain, statusChan chan net.ApiResponse) {
-	domainsChan = make(chan []cf.Domain, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := "/v2/domains?inline-relations-depth=1"
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					allDomains      []cf.Domain
-					domainsToReturn []cf.Domain
-					apiResponse     net.ApiResponse
-				)
-
-				allDomains, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(domainsChan)
-					close(statusChan)
-					return
-				}
-
-				for _, d := range allDomains {
-					if repo.isOrgDomain(orgGuid, d.DomainFields) {
-						domainsToReturn = append(domainsToReturn, d)
-					}
-				}
-
-				if len(domainsToReturn) > 0 {
-					domainsChan <- domainsToReturn
-				}
+func (repo CloudControllerDomainRepository) ListDomainsForOrg(orgGuid string, cb func([]cf.Domain) bool) (apiResponse net.ApiResponse) {
+	return NewPaginatedIterator("/v2/domains?inline-relations-depth=1", func(path string) (page interface{}, nextUrl string, apiResponse net.ApiResponse) {
+		allDomains, nextUrl, apiResponse := repo.findNextWithPath(path)
+
+		orgDomains := []cf.Domain{}
+		for _, d := range allDomains {
+			if repo.isOrgDomain(orgGuid, d.DomainFields) {
+				orgDomains = append(orgDomains, d)
 			}
 		}
-		close(domainsChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
 
-	return
+		page = orgDomains
+		return
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Domain))
+	})
 }
 
 func (repo CloudControllerDomainRepository) isOrgDomain(orgGuid string, domain cf.DomainFields) bool {
@@ -117,7 +90,7 @@ func (repo CloudControllerDomainRepository) isOrgDomain(orgGuid string, domain c
 func (repo CloudControllerDomainRepository) findNextWithPath(path string) (domains []cf.Domain, nextUrl string, apiResponse net.ApiResponse) {
 	domainResources := new(PaginatedDomainResources)
 
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, domainResources)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), domainResources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -185,7 +158,7 @@ func (repo CloudControllerDomainRepository) Create(domainName string, owningOrgG
 	)
 
 	resource := new(DomainResource)
-	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, strings.NewReader(data), resource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(data), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -197,20 +170,20 @@ func (repo CloudControllerDomainRepository) Create(domainName string, owningOrgG
 func (repo CloudControllerDomainRepository) CreateSharedDomain(domainName string) (apiResponse net.ApiResponse) {
 	path := repo.config.Target + "/v2/domains"
 	data := fmt.Sprintf(`{"name":"%s","wildcard":true}`, domainName)
-	return repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(data))
+	return repo.gateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(data))
 }
 
 func (repo CloudControllerDomainRepository) Delete(domainGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/domains/%s?recursive=true", repo.config.Target, domainGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
 
 func (repo CloudControllerDomainRepository) Map(domainGuid string, spaceGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/spaces/%s/domains/%s", repo.config.Target, spaceGuid, domainGuid)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, nil)
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), nil)
 }
 
 func (repo CloudControllerDomainRepository) Unmap(domainGuid string, spaceGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/spaces/%s/domains/%s", repo.config.Target, spaceGuid, domainGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
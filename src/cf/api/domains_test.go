@@ -91,15 +91,11 @@ func TestDomainListDomainsForOrg(t *testing.T) {
 	ts, handler, repo := createDomainRepo(t, []testnet.TestRequest{firstPageDomainsRequest, secondPageDomainsRequest})
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	domainsChan, statusChan := repo.ListDomainsForOrg("my-org-guid", stopChan)
-
 	domains := []cf.Domain{}
-	for chunk := range domainsChan {
+	apiResponse := repo.ListDomainsForOrg("my-org-guid", func(chunk []cf.Domain) bool {
 		domains = append(domains, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, len(domains), 3)
 	assert.Equal(t, domains[0].Guid, "domain1-guid")
@@ -120,19 +116,13 @@ func TestDomainListDomainsForOrgWithNoDomains(t *testing.T) {
 	ts, handler, repo := createDomainRepo(t, []testnet.TestRequest{emptyDomainsRequest})
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	domainsChan, statusChan := repo.ListDomainsForOrg("my-org-guid", stopChan)
-
 	domains := []cf.Domain{}
-	for chunk := range domainsChan {
+	apiResponse := repo.ListDomainsForOrg("my-org-guid", func(chunk []cf.Domain) bool {
 		domains = append(domains, chunk...)
-	}
-
-	_, ok := <-domainsChan
-	apiResponse := <-statusChan
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.Equal(t, len(domains), 0)
 	assert.True(t, apiResponse.IsSuccessful())
 	assert.True(t, handler.AllRequestsCalled())
 }
@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"os"
 	"regexp"
 	"strings"
 )
@@ -13,8 +14,19 @@ const (
 	uaaEndpointPrefix  = "uaa"
 )
 
+var endpointOverrideEnvVars = map[cf.EndpointType]string{
+	cf.UaaEndpointKey:         "CF_UAA_ENDPOINT",
+	cf.LoggregatorEndpointKey: "CF_LOGGREGATOR_ENDPOINT",
+	cf.DopplerEndpointKey:     "CF_DOPPLER_ENDPOINT",
+	cf.RoutingApiEndpointKey:  "CF_ROUTING_API_ENDPOINT",
+}
+
+func EndpointOverrideEnvVar(name cf.EndpointType) string {
+	return endpointOverrideEnvVars[name]
+}
+
 type EndpointRepository interface {
-	UpdateEndpoint(endpoint string) (finalEndpoint string, apiResponse net.ApiResponse)
+	UpdateEndpoint(endpoint string, allowInsecureHttp bool) (finalEndpoint string, apiResponse net.ApiResponse)
 	GetEndpoint(name cf.EndpointType) (endpoint string, apiResponse net.ApiResponse)
 }
 
@@ -31,27 +43,55 @@ func NewEndpointRepository(config *configuration.Configuration, gateway net.Gate
 	return
 }
 
-func (repo RemoteEndpointRepository) UpdateEndpoint(endpoint string) (finalEndpoint string, apiResponse net.ApiResponse) {
+func (repo RemoteEndpointRepository) UpdateEndpoint(endpoint string, allowInsecureHttp bool) (finalEndpoint string, apiResponse net.ApiResponse) {
 	endpointMissingScheme := !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://")
 
 	if endpointMissingScheme {
 		finalEndpoint = "https://" + endpoint
 		apiResponse = repo.doUpdateEndpoint(finalEndpoint)
-
 		if apiResponse.IsNotSuccessful() {
-			finalEndpoint = "http://" + endpoint
-			apiResponse = repo.doUpdateEndpoint(finalEndpoint)
+			apiResponse = net.NewApiResponseWithMessage(
+				"Could not connect to %s\n%s\nTo use plain http, run the command again with an explicit http:// endpoint and --allow-insecure-http.",
+				finalEndpoint, apiResponse.Message)
 		}
 		return
 	}
 
 	finalEndpoint = endpoint
 
+	if strings.HasPrefix(endpoint, "http://") {
+		if !allowInsecureHttp && !repo.config.IsInsecureHttpAllowed(endpoint) {
+			apiResponse = net.NewApiResponseWithMessage(
+				"Refusing to use insecure http endpoint %s\nTo allow it, run the command again with --allow-insecure-http.", endpoint)
+			return
+		}
+
+		apiResponse = repo.doUpdateEndpoint(finalEndpoint)
+		if apiResponse.IsSuccessful() {
+			apiResponse = repo.allowInsecureHttp(finalEndpoint)
+		}
+		return
+	}
+
 	apiResponse = repo.doUpdateEndpoint(finalEndpoint)
 
 	return
 }
 
+func (repo RemoteEndpointRepository) allowInsecureHttp(endpoint string) (apiResponse net.ApiResponse) {
+	if repo.config.IsInsecureHttpAllowed(endpoint) {
+		return
+	}
+
+	repo.config.InsecureHttpTargets = append(repo.config.InsecureHttpTargets, endpoint)
+
+	err := repo.configRepo.Save()
+	if err != nil {
+		apiResponse = net.NewApiResponseWithError("Error saving config", err)
+	}
+	return
+}
+
 func (repo RemoteEndpointRepository) doUpdateEndpoint(endpoint string) (apiResponse net.ApiResponse) {
 	request, apiResponse := repo.gateway.NewRequest("GET", endpoint+"/v2/info", "", nil)
 	if apiResponse.IsNotSuccessful() {
@@ -59,8 +99,14 @@ func (repo RemoteEndpointRepository) doUpdateEndpoint(endpoint string) (apiRespo
 	}
 
 	type infoResponse struct {
-		ApiVersion            string `json:"api_version"`
-		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		ApiVersion               string `json:"api_version"`
+		AuthorizationEndpoint    string `json:"authorization_endpoint"`
+		TokenEndpoint            string `json:"token_endpoint"`
+		LoggingEndpoint          string `json:"logging_endpoint"`
+		DopplerLoggingEndpoint   string `json:"doppler_logging_endpoint"`
+		RoutingEndpoint          string `json:"routing_endpoint"`
+		MinCliVersion            string `json:"min_cli_version"`
+		MinRecommendedCliVersion string `json:"min_recommended_cli_version"`
 	}
 
 	serverResponse := new(infoResponse)
@@ -76,6 +122,12 @@ func (repo RemoteEndpointRepository) doUpdateEndpoint(endpoint string) (apiRespo
 	repo.config.Target = endpoint
 	repo.config.ApiVersion = serverResponse.ApiVersion
 	repo.config.AuthorizationEndpoint = serverResponse.AuthorizationEndpoint
+	repo.config.UaaEndpoint = serverResponse.TokenEndpoint
+	repo.config.LoggregatorEndpoint = serverResponse.LoggingEndpoint
+	repo.config.DopplerEndpoint = serverResponse.DopplerLoggingEndpoint
+	repo.config.RoutingApiEndpoint = serverResponse.RoutingEndpoint
+	repo.config.MinCliVersion = serverResponse.MinCliVersion
+	repo.config.MinRecommendedCliVersion = serverResponse.MinRecommendedCliVersion
 
 	err := repo.configRepo.Save()
 	if err != nil {
@@ -85,6 +137,11 @@ func (repo RemoteEndpointRepository) doUpdateEndpoint(endpoint string) (apiRespo
 }
 
 func (repo RemoteEndpointRepository) GetEndpoint(name cf.EndpointType) (endpoint string, apiResponse net.ApiResponse) {
+	endpoint = os.Getenv(EndpointOverrideEnvVar(name))
+	if endpoint != "" {
+		return
+	}
+
 	switch name {
 	case cf.CloudControllerEndpointKey:
 		return repo.cloudControllerEndpoint()
@@ -92,6 +149,10 @@ func (repo RemoteEndpointRepository) GetEndpoint(name cf.EndpointType) (endpoint
 		return repo.uaaControllerEndpoint()
 	case cf.LoggregatorEndpointKey:
 		return repo.loggregatorEndpoint()
+	case cf.DopplerEndpointKey:
+		return repo.advertisedEndpoint(repo.config.DopplerEndpoint, "Doppler")
+	case cf.RoutingApiEndpointKey:
+		return repo.advertisedEndpoint(repo.config.RoutingApiEndpoint, "Routing API")
 	}
 
 	apiResponse = net.NewNotFoundApiResponse("Endpoint type %s is unkown", string(name))
@@ -110,11 +171,17 @@ func (repo RemoteEndpointRepository) cloudControllerEndpoint() (endpoint string,
 }
 
 func (repo RemoteEndpointRepository) uaaControllerEndpoint() (endpoint string, apiResponse net.ApiResponse) {
+	if repo.config.UaaEndpoint != "" {
+		endpoint = repo.config.UaaEndpoint
+		return
+	}
+
 	if repo.config.AuthorizationEndpoint == "" {
 		apiResponse = net.NewApiResponseWithMessage("Endpoint missing from config file")
 		return
 	}
 
+	// older cloud controllers do not advertise a token endpoint
 	endpoint = strings.Replace(repo.config.AuthorizationEndpoint, authEndpointPrefix, uaaEndpointPrefix, 1)
 
 	return
@@ -126,6 +193,12 @@ func (repo RemoteEndpointRepository) loggregatorEndpoint() (endpoint string, api
 		return
 	}
 
+	if repo.config.LoggregatorEndpoint != "" {
+		endpoint = repo.config.LoggregatorEndpoint
+		return
+	}
+
+	// older cloud controllers do not advertise a logging endpoint
 	re := regexp.MustCompile(`^http(s?)://[^\.]+\.(.+)\/?`)
 
 	endpoint = re.ReplaceAllString(repo.config.Target, "ws${1}://loggregator.${2}")
@@ -137,3 +210,13 @@ func (repo RemoteEndpointRepository) loggregatorEndpoint() (endpoint string, api
 	}
 	return
 }
+
+func (repo RemoteEndpointRepository) advertisedEndpoint(advertised, description string) (endpoint string, apiResponse net.ApiResponse) {
+	if advertised == "" {
+		apiResponse = net.NewNotFoundApiResponse("%s endpoint is not advertised by %s", description, repo.config.Target)
+		return
+	}
+
+	endpoint = advertised
+	return
+}
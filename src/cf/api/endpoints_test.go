@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	testconfig "testhelpers/configuration"
 	"testing"
@@ -51,7 +52,7 @@ func TestUpdateEndpointWhenUrlIsValidHttpsInfoEndpoint(t *testing.T) {
 	config.OrganizationFields = org
 	config.SpaceFields = space
 
-	repo.UpdateEndpoint(ts.URL)
+	repo.UpdateEndpoint(ts.URL, false)
 
 	savedConfig := testconfig.SavedConfiguration
 
@@ -86,7 +87,7 @@ func TestUpdateEndpointWhenUrlIsAlreadyTargeted(t *testing.T) {
 	config.OrganizationFields = org
 	config.SpaceFields = space
 
-	repo.UpdateEndpoint(ts.URL)
+	repo.UpdateEndpoint(ts.URL, false)
 
 	assert.Equal(t, config.OrganizationFields, org)
 	assert.Equal(t, config.SpaceFields, space)
@@ -103,7 +104,7 @@ func TestUpdateEndpointWhenUrlIsMissingSchemeAndHttpsEndpointExists(t *testing.T
 	defer ts.Close()
 
 	schemelessURL := strings.Replace(ts.URL, "https://", "", 1)
-	endpoint, apiResponse := repo.UpdateEndpoint(schemelessURL)
+	endpoint, apiResponse := repo.UpdateEndpoint(schemelessURL, false)
 	assert.Equal(t, "https://"+schemelessURL, endpoint)
 
 	assert.True(t, apiResponse.IsSuccessful())
@@ -116,7 +117,7 @@ func TestUpdateEndpointWhenUrlIsMissingSchemeAndHttpsEndpointExists(t *testing.T
 	assert.Equal(t, savedConfig.ApiVersion, "42.0.0")
 }
 
-func TestUpdateEndpointWhenUrlIsMissingSchemeAndHttpEndpointExists(t *testing.T) {
+func TestUpdateEndpointWhenUrlIsMissingSchemeNeverFallsBackToHttp(t *testing.T) {
 	configRepo := testconfig.FakeConfigRepository{}
 	configRepo.Delete()
 	configRepo.Login()
@@ -126,17 +127,78 @@ func TestUpdateEndpointWhenUrlIsMissingSchemeAndHttpEndpointExists(t *testing.T)
 
 	schemelessURL := strings.Replace(ts.URL, "http://", "", 1)
 
-	endpoint, apiResponse := repo.UpdateEndpoint(schemelessURL)
-	assert.Equal(t, "http://"+schemelessURL, endpoint)
+	_, apiResponse := repo.UpdateEndpoint(schemelessURL, true)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Could not connect to https://"+schemelessURL)
 
+	_, apiResponse = repo.UpdateEndpoint(ts.URL, true)
 	assert.True(t, apiResponse.IsSuccessful())
 
-	savedConfig := testconfig.SavedConfiguration
+	_, apiResponse = repo.UpdateEndpoint(schemelessURL, false)
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Could not connect to https://"+schemelessURL)
 
-	assert.Equal(t, savedConfig.AccessToken, "")
-	assert.Equal(t, savedConfig.AuthorizationEndpoint, "https://login.example.com")
-	assert.Equal(t, savedConfig.Target, ts.URL)
-	assert.Equal(t, savedConfig.ApiVersion, "42.0.0")
+	config, _ := configRepo.Get()
+	assert.Equal(t, config.Target, ts.URL)
+}
+
+func TestUpdateEndpointWhenUrlIsMissingSchemeDoesNotFallBackToHttp(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	configRepo.Login()
+
+	ts, repo := createInsecureEndpointRepoForUpdate(configRepo, validApiInfoEndpoint)
+	defer ts.Close()
+
+	schemelessURL := strings.Replace(ts.URL, "http://", "", 1)
+
+	_, apiResponse := repo.UpdateEndpoint(schemelessURL, false)
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Could not connect to https://"+schemelessURL)
+	assert.Contains(t, apiResponse.Message, "Error performing request")
+	assert.Contains(t, apiResponse.Message, "--allow-insecure-http")
+
+	config, _ := configRepo.Get()
+	assert.Equal(t, config.Target, "https://api.run.pivotal.io")
+}
+
+func TestUpdateEndpointRefusesHttpWithoutOptIn(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	configRepo.Login()
+
+	ts, repo := createInsecureEndpointRepoForUpdate(configRepo, validApiInfoEndpoint)
+	defer ts.Close()
+
+	_, apiResponse := repo.UpdateEndpoint(ts.URL, false)
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Contains(t, apiResponse.Message, "Refusing to use insecure http endpoint")
+
+	config, _ := configRepo.Get()
+	assert.Equal(t, config.Target, "https://api.run.pivotal.io")
+}
+
+func TestUpdateEndpointRemembersHttpOptInPerTarget(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	configRepo.Login()
+
+	ts, repo := createInsecureEndpointRepoForUpdate(configRepo, validApiInfoEndpoint)
+	defer ts.Close()
+
+	_, apiResponse := repo.UpdateEndpoint(ts.URL, true)
+	assert.True(t, apiResponse.IsSuccessful())
+
+	_, apiResponse = repo.UpdateEndpoint(ts.URL, false)
+	assert.True(t, apiResponse.IsSuccessful())
+
+	otherTs, _ := createInsecureEndpointRepoForUpdate(configRepo, validApiInfoEndpoint)
+	defer otherTs.Close()
+
+	_, apiResponse = repo.UpdateEndpoint(otherTs.URL, false)
+	assert.True(t, apiResponse.IsNotSuccessful())
 }
 
 var notFoundApiEndpoint = func(w http.ResponseWriter, r *http.Request) {
@@ -150,7 +212,7 @@ func TestUpdateEndpointWhenEndpointReturns404(t *testing.T) {
 	ts, repo := createEndpointRepoForUpdate(configRepo, notFoundApiEndpoint)
 	defer ts.Close()
 
-	_, apiResponse := repo.UpdateEndpoint(ts.URL)
+	_, apiResponse := repo.UpdateEndpoint(ts.URL, false)
 
 	assert.True(t, apiResponse.IsNotSuccessful())
 }
@@ -166,7 +228,7 @@ func TestUpdateEndpointWhenEndpointReturnsInvalidJson(t *testing.T) {
 	ts, repo := createEndpointRepoForUpdate(configRepo, invalidJsonResponseApiEndpoint)
 	defer ts.Close()
 
-	_, apiResponse := repo.UpdateEndpoint(ts.URL)
+	_, apiResponse := repo.UpdateEndpoint(ts.URL, false)
 
 	assert.True(t, apiResponse.IsNotSuccessful())
 }
@@ -237,3 +299,83 @@ func createEndpointRepoForGet(config *configuration.Configuration) (repo Endpoin
 	repo = NewEndpointRepository(config, net.NewCloudControllerGateway(), configRepo)
 	return
 }
+
+var advertisingApiInfoEndpoint = func(w http.ResponseWriter, r *http.Request) {
+	infoResponse := `
+{
+  "name": "vcap",
+  "authorization_endpoint": "https://login.sys.corp.example",
+  "token_endpoint": "https://uaa.sys.corp.example",
+  "logging_endpoint": "wss://logs.apps.corp.example:8443",
+  "doppler_logging_endpoint": "wss://doppler.apps.corp.example:8443",
+  "routing_endpoint": "https://api.sys.corp.example/routing",
+  "min_cli_version": "6.0.0",
+  "min_recommended_cli_version": "6.1.0",
+  "api_version": "2.1.0"
+} `
+	fmt.Fprintln(w, infoResponse)
+}
+
+func TestUpdateEndpointCapturesAdvertisedEndpoints(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+	configRepo.Login()
+
+	ts, repo := createEndpointRepoForUpdate(configRepo, advertisingApiInfoEndpoint)
+	defer ts.Close()
+
+	_, apiResponse := repo.UpdateEndpoint(ts.URL, false)
+	assert.True(t, apiResponse.IsSuccessful())
+
+	savedConfig := testconfig.SavedConfiguration
+	assert.Equal(t, savedConfig.AuthorizationEndpoint, "https://login.sys.corp.example")
+	assert.Equal(t, savedConfig.UaaEndpoint, "https://uaa.sys.corp.example")
+	assert.Equal(t, savedConfig.LoggregatorEndpoint, "wss://logs.apps.corp.example:8443")
+	assert.Equal(t, savedConfig.DopplerEndpoint, "wss://doppler.apps.corp.example:8443")
+	assert.Equal(t, savedConfig.RoutingApiEndpoint, "https://api.sys.corp.example/routing")
+	assert.Equal(t, savedConfig.MinCliVersion, "6.0.0")
+	assert.Equal(t, savedConfig.MinRecommendedCliVersion, "6.1.0")
+}
+
+func TestGetEndpointPrefersAdvertisedEndpoints(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:                "https://api.sys.corp.example",
+		AuthorizationEndpoint: "https://login.sys.corp.example",
+		UaaEndpoint:           "https://uaa.sys.corp.example",
+		LoggregatorEndpoint:   "wss://logs.apps.corp.example:8443",
+		DopplerEndpoint:       "wss://doppler.apps.corp.example:8443",
+	}
+
+	repo := createEndpointRepoForGet(config)
+
+	endpoint, apiResponse := repo.GetEndpoint(cf.UaaEndpointKey)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, endpoint, "https://uaa.sys.corp.example")
+
+	endpoint, apiResponse = repo.GetEndpoint(cf.LoggregatorEndpointKey)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, endpoint, "wss://logs.apps.corp.example:8443")
+
+	endpoint, apiResponse = repo.GetEndpoint(cf.DopplerEndpointKey)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, endpoint, "wss://doppler.apps.corp.example:8443")
+
+	_, apiResponse = repo.GetEndpoint(cf.RoutingApiEndpointKey)
+	assert.True(t, apiResponse.IsNotFound())
+}
+
+func TestGetEndpointWithEnvironmentOverrides(t *testing.T) {
+	os.Setenv("CF_LOGGREGATOR_ENDPOINT", "wss://override.example.com:443")
+	defer os.Setenv("CF_LOGGREGATOR_ENDPOINT", "")
+
+	config := &configuration.Configuration{
+		Target:              "https://api.sys.corp.example",
+		LoggregatorEndpoint: "wss://logs.apps.corp.example:8443",
+	}
+
+	repo := createEndpointRepoForGet(config)
+
+	endpoint, apiResponse := repo.GetEndpoint(cf.LoggregatorEndpointKey)
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, endpoint, "wss://override.example.com:443")
+}
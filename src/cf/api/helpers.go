@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"generic"
 )
@@ -30,7 +31,11 @@ func mapToJsonValues(params generic.Map) (vals []string) {
 		case uint64:
 			vals = append(vals, fmt.Sprintf(`"%s":%d`, key, val))
 		default:
-			vals = append(vals, fmt.Sprintf(`"%s":%s`, key, val))
+			encoded, err := json.Marshal(val)
+			if err != nil {
+				encoded = []byte(fmt.Sprintf("%s", val))
+			}
+			vals = append(vals, fmt.Sprintf(`"%s":%s`, key, encoded))
 		}
 	})
 	return
@@ -62,7 +62,7 @@ func (repo LoggregatorLogsRepository) connectToWebsocket(location string, onConn
 		return
 	}
 
-	config.Header.Add("Authorization", repo.config.AccessToken)
+	config.Header.Add("Authorization", repo.config.GetAccessToken())
 	config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
 
 	ws, err := websocket.DialConfig(config)
@@ -21,8 +21,9 @@ type OrganizationResource struct {
 }
 
 type PaginatedOrganizationResources struct {
-	Resources []OrganizationResource
-	NextUrl   string `json:"next_url"`
+	Resources  []OrganizationResource
+	NextUrl    string `json:"next_url"`
+	TotalPages int    `json:"total_pages"`
 }
 
 func (resource OrganizationResource) ToFields() (fields cf.OrganizationFields) {
@@ -52,11 +53,12 @@ func (resource OrganizationResource) ToModel() (org cf.Organization) {
 }
 
 type OrganizationRepository interface {
-	ListOrgs(stop chan bool) (orgsChan chan []cf.Organization, statusChan chan net.ApiResponse)
+	ListOrgs(cb func([]cf.Organization) bool) (apiResponse net.ApiResponse)
 	FindByName(name string) (org cf.Organization, apiResponse net.ApiResponse)
 	Create(name string) (apiResponse net.ApiResponse)
 	Rename(orgGuid string, name string) (apiResponse net.ApiResponse)
 	Delete(orgGuid string) (apiResponse net.ApiResponse)
+	GetMemoryUsage(orgGuid string) (usage uint64, apiResponse net.ApiResponse)
 }
 
 type CloudControllerOrganizationRepository struct {
@@ -70,53 +72,24 @@ func NewCloudControllerOrganizationRepository(config *configuration.Configuratio
 	return
 }
 
-func (repo CloudControllerOrganizationRepository) ListOrgs(stop chan bool) (orgsChan chan []cf.Organization, statusChan chan net.ApiResponse) {
-	orgsChan = make(chan []cf.Organization, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := "/v2/organizations"
-
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					organizations []cf.Organization
-					apiResponse   net.ApiResponse
-				)
-				organizations, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(orgsChan)
-					close(statusChan)
-					return
-				}
-
-				if len(organizations) > 0 {
-					orgsChan <- organizations
-				}
-			}
-		}
-		close(orgsChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
-
-	return
+func (repo CloudControllerOrganizationRepository) ListOrgs(cb func([]cf.Organization) bool) (apiResponse net.ApiResponse) {
+	return NewParallelPaginatedIterator("/v2/organizations", func(path string) (interface{}, string, int, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Organization))
+	})
 }
 
-func (repo CloudControllerOrganizationRepository) findNextWithPath(path string) (orgs []cf.Organization, nextUrl string, apiResponse net.ApiResponse) {
+func (repo CloudControllerOrganizationRepository) findNextWithPath(path string) (orgs []cf.Organization, nextUrl string, totalPages int, apiResponse net.ApiResponse) {
 	orgResources := new(PaginatedOrganizationResources)
 
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, orgResources)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), orgResources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
 	nextUrl = orgResources.NextUrl
+	totalPages = orgResources.TotalPages
 
 	for _, r := range orgResources.Resources {
 		orgs = append(orgs, r.ToModel())
@@ -127,7 +100,7 @@ func (repo CloudControllerOrganizationRepository) findNextWithPath(path string)
 func (repo CloudControllerOrganizationRepository) FindByName(name string) (org cf.Organization, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("/v2/organizations?q=name%s&inline-relations-depth=1", "%3A"+strings.ToLower(name))
 
-	orgs, _, apiResponse := repo.findNextWithPath(path)
+	orgs, _, _, apiResponse := repo.findNextWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -144,16 +117,26 @@ func (repo CloudControllerOrganizationRepository) FindByName(name string) (org c
 func (repo CloudControllerOrganizationRepository) Create(name string) (apiResponse net.ApiResponse) {
 	url := repo.config.Target + "/v2/organizations"
 	data := fmt.Sprintf(`{"name":"%s"}`, name)
-	return repo.gateway.CreateResource(url, repo.config.AccessToken, strings.NewReader(data))
+	return repo.gateway.CreateResource(url, repo.config.GetAccessToken(), strings.NewReader(data))
 }
 
 func (repo CloudControllerOrganizationRepository) Rename(orgGuid string, name string) (apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/organizations/%s", repo.config.Target, orgGuid)
 	data := fmt.Sprintf(`{"name":"%s"}`, name)
-	return repo.gateway.UpdateResource(url, repo.config.AccessToken, strings.NewReader(data))
+	return repo.gateway.UpdateResource(url, repo.config.GetAccessToken(), strings.NewReader(data))
 }
 
 func (repo CloudControllerOrganizationRepository) Delete(orgGuid string) (apiResponse net.ApiResponse) {
 	url := fmt.Sprintf("%s/v2/organizations/%s?recursive=true", repo.config.Target, orgGuid)
-	return repo.gateway.DeleteResource(url, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(url, repo.config.GetAccessToken())
+}
+
+func (repo CloudControllerOrganizationRepository) GetMemoryUsage(orgGuid string) (usage uint64, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/organizations/%s/memory_usage", repo.config.Target, orgGuid)
+	response := &struct {
+		MemoryUsage uint64 `json:"memory_usage_in_mb"`
+	}{}
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), response)
+	usage = response.MemoryUsage
+	return
 }
@@ -17,6 +17,7 @@ func TestOrganizationsListOrgs(t *testing.T) {
 		Method: "GET",
 		Path:   "/v2/organizations",
 		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+		"total_pages": 2,
 		"next_url": "/v2/organizations?page=2",
 		"resources": [
 			{
@@ -44,15 +45,11 @@ func TestOrganizationsListOrgs(t *testing.T) {
 	ts, handler, repo := createOrganizationRepo(t, firstPageOrgsRequest, secondPageOrgsRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	orgsChan, statusChan := repo.ListOrgs(stopChan)
-
 	orgs := []cf.Organization{}
-	for chunk := range orgsChan {
+	apiResponse := repo.ListOrgs(func(chunk []cf.Organization) bool {
 		orgs = append(orgs, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, len(orgs), 3)
 	assert.Equal(t, orgs[0].Guid, "org1-guid")
@@ -73,14 +70,13 @@ func TestOrganizationsListOrgsWithNoOrgs(t *testing.T) {
 	ts, handler, repo := createOrganizationRepo(t, emptyOrgsRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	orgsChan, statusChan := repo.ListOrgs(stopChan)
-
-	_, ok := <-orgsChan
-	apiResponse := <-statusChan
+	called := false
+	apiResponse := repo.ListOrgs(func(chunk []cf.Organization) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.False(t, called)
 	assert.True(t, apiResponse.IsSuccessful())
 	assert.True(t, handler.AllRequestsCalled())
 }
@@ -196,6 +192,22 @@ func TestDeleteOrganization(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
+func TestGetOrganizationMemoryUsage(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "GET",
+		Path:     "/v2/organizations/my-org-guid/memory_usage",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"memory_usage_in_mb": 2048}`},
+	})
+
+	ts, handler, repo := createOrganizationRepo(t, req)
+	defer ts.Close()
+
+	usage, apiResponse := repo.GetMemoryUsage("my-org-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, usage, uint64(2048))
+}
+
 func createOrganizationRepo(t *testing.T, reqs ...testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo OrganizationRepository) {
 	ts, handler = testnet.NewTLSServer(t, reqs)
 
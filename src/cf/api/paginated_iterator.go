@@ -0,0 +1,208 @@
+package api
+
+import (
+	"cf"
+	"cf/net"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// pageFetcher loads the page at path. The page is a typed slice such as
+// []cf.Route; an empty nextPath marks the last page.
+type pageFetcher func(path string) (page interface{}, nextPath string, apiResponse net.ApiResponse)
+
+// countedPageFetcher is a pageFetcher that also returns the total_pages of the
+// listing, or 0 when the response does not include it.
+type countedPageFetcher func(path string) (page interface{}, nextPath string, totalPages int, apiResponse net.ApiResponse)
+
+// PaginatedIterator walks a paginated cloud controller listing. The next page
+// is fetched in the background while the current one is being consumed.
+// Close must be called when the caller stops early; ForEachPage does so.
+type PaginatedIterator struct {
+	pages    chan interface{}
+	done     chan bool
+	finished chan bool
+	stopOnce sync.Once
+
+	apiResponse net.ApiResponse
+}
+
+func NewPaginatedIterator(path string, fetch pageFetcher) (iterator *PaginatedIterator) {
+	iterator = newPaginatedIterator()
+
+	go func() {
+		defer iterator.finish()
+		iterator.followPages(path, fetch)
+	}()
+	return
+}
+
+// NewParallelPaginatedIterator reads the first page to learn how many pages
+// there are, then fetches the rest in batches of cf.MAX_PARALLEL_REQUESTS
+// concurrent requests. Pages are still returned in order. Listings that do not
+// report their total pages are walked one page at a time.
+func NewParallelPaginatedIterator(path string, fetch countedPageFetcher) (iterator *PaginatedIterator) {
+	iterator = newPaginatedIterator()
+
+	go func() {
+		defer iterator.finish()
+		iterator.fetchPagesInParallel(path, fetch)
+	}()
+	return
+}
+
+func newPaginatedIterator() *PaginatedIterator {
+	return &PaginatedIterator{
+		pages:    make(chan interface{}),
+		done:     make(chan bool),
+		finished: make(chan bool),
+	}
+}
+
+func (iterator *PaginatedIterator) finish() {
+	close(iterator.pages)
+	close(iterator.finished)
+}
+
+func (iterator *PaginatedIterator) followPages(path string, fetch pageFetcher) {
+	for path != "" {
+		if iterator.stopped() {
+			return
+		}
+
+		page, nextPath, apiResponse := fetch(path)
+		if apiResponse.IsNotSuccessful() {
+			iterator.apiResponse = apiResponse
+			return
+		}
+		path = nextPath
+
+		if !iterator.deliver(page) {
+			return
+		}
+	}
+}
+
+func (iterator *PaginatedIterator) fetchPagesInParallel(path string, fetch countedPageFetcher) {
+	firstPage, nextPath, totalPages, apiResponse := fetch(path)
+	if apiResponse.IsNotSuccessful() {
+		iterator.apiResponse = apiResponse
+		return
+	}
+
+	if !iterator.deliver(firstPage) {
+		return
+	}
+
+	if totalPages < 2 {
+		iterator.followPages(nextPath, func(path string) (page interface{}, nextPath string, apiResponse net.ApiResponse) {
+			page, nextPath, _, apiResponse = fetch(path)
+			return
+		})
+		return
+	}
+
+	for first := 2; first <= totalPages; first += cf.MAX_PARALLEL_REQUESTS {
+		if iterator.stopped() {
+			return
+		}
+
+		count := totalPages - first + 1
+		if count > cf.MAX_PARALLEL_REQUESTS {
+			count = cf.MAX_PARALLEL_REQUESTS
+		}
+
+		pages := make([]interface{}, count)
+		apiResponses := make([]net.ApiResponse, count)
+		cf.RunInParallel(count, count, func(index int) {
+			pages[index], _, _, apiResponses[index] = fetch(pagePath(path, first+index))
+		})
+
+		for index, page := range pages {
+			if apiResponses[index].IsNotSuccessful() {
+				iterator.apiResponse = apiResponses[index]
+				return
+			}
+			if !iterator.deliver(page) {
+				return
+			}
+		}
+	}
+}
+
+// deliver hands a non-empty page to the reader and reports whether to go on.
+func (iterator *PaginatedIterator) deliver(page interface{}) bool {
+	if isEmptyPage(page) {
+		return !iterator.stopped()
+	}
+
+	select {
+	case iterator.pages <- page:
+		return true
+	case <-iterator.done:
+		return false
+	}
+}
+
+func (iterator *PaginatedIterator) stopped() bool {
+	select {
+	case <-iterator.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Next blocks until the next non-empty page is available. ok is false once
+// every page has been read, the listing failed, or the iterator was closed.
+func (iterator *PaginatedIterator) Next() (page interface{}, ok bool) {
+	page, ok = <-iterator.pages
+	return
+}
+
+// Err waits for the iterator to finish and returns the error that stopped it, if any.
+func (iterator *PaginatedIterator) Err() (apiResponse net.ApiResponse) {
+	<-iterator.finished
+	return iterator.apiResponse
+}
+
+// Close stops fetching and waits for the background goroutine to exit.
+func (iterator *PaginatedIterator) Close() {
+	iterator.stopOnce.Do(func() {
+		close(iterator.done)
+	})
+	<-iterator.finished
+}
+
+// ForEachPage calls cb with every page until cb returns false, then closes the iterator.
+func (iterator *PaginatedIterator) ForEachPage(cb func(page interface{}) bool) (apiResponse net.ApiResponse) {
+	defer iterator.Close()
+
+	for {
+		page, ok := iterator.Next()
+		if !ok {
+			break
+		}
+
+		if !cb(page) {
+			return
+		}
+	}
+
+	return iterator.Err()
+}
+
+func isEmptyPage(page interface{}) bool {
+	value := reflect.ValueOf(page)
+	return !value.IsValid() || value.Len() == 0
+}
+
+func pagePath(path string, page int) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%spage=%d", path, separator, page)
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"cf/net"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func fakePages(pages [][]string) (fetch pageFetcher, fetchedPaths *[]string) {
+	fetchedPaths = &[]string{}
+
+	fetch = func(path string) (page interface{}, nextPath string, apiResponse net.ApiResponse) {
+		*fetchedPaths = append(*fetchedPaths, path)
+
+		var index int
+		fmt.Sscanf(path, "/page/%d", &index)
+
+		page = pages[index]
+		if index+1 < len(pages) {
+			nextPath = fmt.Sprintf("/page/%d", index+1)
+		}
+		return
+	}
+	return
+}
+
+func assertNoGoroutinesLeaked(t *testing.T, before int) {
+	for i := 0; i < 100; i++ {
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, runtime.NumGoroutine(), before)
+}
+
+func TestPaginatedIteratorReturnsEveryPage(t *testing.T) {
+	fetch, fetchedPaths := fakePages([][]string{{"a", "b"}, {}, {"c"}})
+
+	items := []string{}
+	apiResponse := NewPaginatedIterator("/page/0", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a", "b", "c"})
+	assert.Equal(t, *fetchedPaths, []string{"/page/0", "/page/1", "/page/2"})
+}
+
+func TestPaginatedIteratorReturnsTheFetchError(t *testing.T) {
+	fetch := func(path string) (page interface{}, nextPath string, apiResponse net.ApiResponse) {
+		if path == "/page/0" {
+			return []string{"a"}, "/page/1", net.ApiResponse{}
+		}
+		apiResponse = net.NewApiResponseWithMessage("Server error")
+		return
+	}
+
+	items := []string{}
+	apiResponse := NewPaginatedIterator("/page/0", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.Message, "Server error")
+	assert.Equal(t, items, []string{"a"})
+}
+
+func TestPaginatedIteratorStopsWhenTheCallbackReturnsFalse(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fetch, fetchedPaths := fakePages([][]string{{"a"}, {"b"}, {"c"}, {"d"}})
+
+	items := []string{}
+	apiResponse := NewPaginatedIterator("/page/0", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return false
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a"})
+	assert.True(t, len(*fetchedPaths) <= 2)
+	assertNoGoroutinesLeaked(t, before)
+}
+
+func TestPaginatedIteratorCloseStopsFetching(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fetch, fetchedPaths := fakePages([][]string{{"a"}, {"b"}, {"c"}, {"d"}})
+
+	iterator := NewPaginatedIterator("/page/0", fetch)
+	page, ok := iterator.Next()
+	iterator.Close()
+	iterator.Close()
+
+	assert.True(t, ok)
+	assert.Equal(t, page, []string{"a"})
+	assert.True(t, len(*fetchedPaths) <= 2)
+	assertNoGoroutinesLeaked(t, before)
+
+	_, ok = iterator.Next()
+	assert.False(t, ok)
+	assert.True(t, iterator.Err().IsSuccessful())
+}
+
+func TestPaginatedIteratorPrefetchesTheNextPage(t *testing.T) {
+	fetch, fetchedPaths := fakePages([][]string{{"a"}, {"b"}, {"c"}})
+	fetched := make(chan string, 3)
+
+	iterator := NewPaginatedIterator("/page/0", func(path string) (interface{}, string, net.ApiResponse) {
+		defer func() { fetched <- path }()
+		return fetch(path)
+	})
+	defer iterator.Close()
+
+	_, ok := iterator.Next()
+	assert.True(t, ok)
+
+	<-fetched
+	select {
+	case path := <-fetched:
+		assert.Equal(t, path, "/page/1")
+	case <-time.After(time.Second):
+		t.Fatal("the next page was not fetched while the current one was being consumed")
+	}
+
+	assert.Equal(t, len(*fetchedPaths), 2)
+}
+
+func fakeCountedPages(pages [][]string, reportTotal bool) (fetch countedPageFetcher, fetchedPaths func() []string) {
+	mutex := new(sync.Mutex)
+	paths := []string{}
+
+	fetch = func(path string) (page interface{}, nextPath string, totalPages int, apiResponse net.ApiResponse) {
+		mutex.Lock()
+		paths = append(paths, path)
+		mutex.Unlock()
+
+		number := 1
+		fmt.Sscanf(path, "/things?page=%d", &number)
+
+		page = pages[number-1]
+		if number < len(pages) {
+			nextPath = fmt.Sprintf("/things?page=%d", number+1)
+		}
+		if reportTotal {
+			totalPages = len(pages)
+		}
+		return
+	}
+
+	fetchedPaths = func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, paths...)
+	}
+	return
+}
+
+func TestParallelPaginatedIteratorReturnsEveryPageInOrder(t *testing.T) {
+	fetch, fetchedPaths := fakeCountedPages([][]string{{"a"}, {"b"}, {}, {"c"}, {"d"}, {"e"}, {"f"}}, true)
+
+	items := []string{}
+	apiResponse := NewParallelPaginatedIterator("/things", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a", "b", "c", "d", "e", "f"})
+	assert.Equal(t, len(fetchedPaths()), 7)
+	assert.Equal(t, fetchedPaths()[0], "/things")
+}
+
+func TestParallelPaginatedIteratorFetchesABatchOfPagesConcurrently(t *testing.T) {
+	fetch, _ := fakeCountedPages([][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}}, true)
+
+	mutex := new(sync.Mutex)
+	inFlight, maxInFlight := 0, 0
+	allInFlight := make(chan bool)
+
+	iterator := NewParallelPaginatedIterator("/things", func(path string) (interface{}, string, int, net.ApiResponse) {
+		if path == "/things" {
+			return fetch(path)
+		}
+
+		mutex.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		if inFlight == 4 {
+			close(allInFlight)
+		}
+		mutex.Unlock()
+
+		select {
+		case <-allInFlight:
+		case <-time.After(time.Second):
+		}
+		return fetch(path)
+	})
+
+	items := []string{}
+	apiResponse := iterator.ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a", "b", "c", "d", "e"})
+	assert.Equal(t, maxInFlight, 4)
+}
+
+func TestParallelPaginatedIteratorFollowsNextUrlsWithoutATotal(t *testing.T) {
+	fetch, fetchedPaths := fakeCountedPages([][]string{{"a"}, {"b"}, {"c"}}, false)
+
+	items := []string{}
+	apiResponse := NewParallelPaginatedIterator("/things", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a", "b", "c"})
+	assert.Equal(t, fetchedPaths(), []string{"/things", "/things?page=2", "/things?page=3"})
+}
+
+func TestParallelPaginatedIteratorReturnsTheFetchError(t *testing.T) {
+	fetch, _ := fakeCountedPages([][]string{{"a"}, {"b"}, {"c"}}, true)
+
+	items := []string{}
+	apiResponse := NewParallelPaginatedIterator("/things", func(path string) (page interface{}, nextPath string, totalPages int, apiResponse net.ApiResponse) {
+		if path == "/things?page=3" {
+			apiResponse = net.NewApiResponseWithMessage("Server error")
+			return
+		}
+		return fetch(path)
+	}).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return true
+	})
+
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.Message, "Server error")
+	assert.Equal(t, items, []string{"a", "b"})
+}
+
+func TestParallelPaginatedIteratorStopsWhenTheCallbackReturnsFalse(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fetch, fetchedPaths := fakeCountedPages([][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}, {"f"}, {"g"}, {"h"}, {"i"}, {"j"}}, true)
+
+	items := []string{}
+	apiResponse := NewParallelPaginatedIterator("/things", fetch).ForEachPage(func(page interface{}) bool {
+		items = append(items, page.([]string)...)
+		return false
+	})
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, items, []string{"a"})
+	assert.True(t, len(fetchedPaths()) <= 5)
+	assertNoGoroutinesLeaked(t, before)
+}
@@ -43,7 +43,7 @@ func (repo CloudControllerPasswordRepository) GetScore(password string) (score s
 		"password": []string{password},
 	}
 
-	scoreRequest, apiResponse := repo.gateway.NewRequest("POST", scorePath, repo.config.AccessToken, strings.NewReader(scoreBody.Encode()))
+	scoreRequest, apiResponse := repo.gateway.NewRequest("POST", scorePath, repo.config.GetAccessToken(), strings.NewReader(scoreBody.Encode()))
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -68,7 +68,7 @@ func (repo CloudControllerPasswordRepository) UpdatePassword(old string, new str
 	path := fmt.Sprintf("%s/Users/%s/password", uaaEndpoint, repo.config.UserGuid())
 	body := fmt.Sprintf(`{"password":"%s","oldPassword":"%s"}`, new, old)
 
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func translateScoreResponse(response ScoreResponse) string {
@@ -49,7 +49,7 @@ func NewCloudControllerQuotaRepository(config *configuration.Configuration, gate
 func (repo CloudControllerQuotaRepository) findAllWithPath(path string) (quotas []cf.QuotaFields, apiResponse net.ApiResponse) {
 	resources := new(PaginatedQuotaResources)
 
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -85,5 +85,5 @@ func (repo CloudControllerQuotaRepository) FindByName(name string) (quota cf.Quo
 func (repo CloudControllerQuotaRepository) Update(orgGuid, quotaGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/organizations/%s", repo.config.Target, orgGuid)
 	data := fmt.Sprintf(`{"quota_definition_guid":"%s"}`, quotaGuid)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(data))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(data))
 }
@@ -32,6 +32,7 @@ type RepositoryLocator struct {
 	userProvidedServiceInstanceRepo CCUserProvidedServiceInstanceRepository
 	buildpackRepo                   CloudControllerBuildpackRepository
 	buildpackBitsRepo               CloudControllerBuildpackBitsRepository
+	curlRepo                        CloudControllerCurlRepository
 }
 
 func NewRepositoryLocator(config *configuration.Configuration, configRepo configuration.ConfigurationRepository, gatewaysByName map[string]net.Gateway) (loc RepositoryLocator) {
@@ -69,6 +70,7 @@ func NewRepositoryLocator(config *configuration.Configuration, configRepo config
 	loc.userRepo = NewCloudControllerUserRepository(config, uaaGateway, cloudControllerGateway, loc.endpointRepo)
 	loc.buildpackRepo = NewCloudControllerBuildpackRepository(config, cloudControllerGateway)
 	loc.buildpackBitsRepo = NewCloudControllerBuildpackBitsRepository(config, cloudControllerGateway, cf.ApplicationZipper{})
+	loc.curlRepo = NewCloudControllerCurlRepository(config, cloudControllerGateway)
 
 	return
 }
@@ -172,3 +174,7 @@ func (locator RepositoryLocator) GetBuildpackRepository() BuildpackRepository {
 func (locator RepositoryLocator) GetBuildpackBitsRepository() BuildpackBitsRepository {
 	return locator.buildpackBitsRepo
 }
+
+func (locator RepositoryLocator) GetCurlRepository() CurlRepository {
+	return locator.curlRepo
+}
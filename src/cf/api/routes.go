@@ -21,6 +21,7 @@ type RouteResource struct {
 func (resource RouteResource) ToFields() (fields cf.RouteFields) {
 	fields.Guid = resource.Metadata.Guid
 	fields.Host = resource.Entity.Host
+	fields.Path = resource.Entity.Path
 	return
 }
 func (resource RouteResource) ToModel() (route cf.Route) {
@@ -35,16 +36,19 @@ func (resource RouteResource) ToModel() (route cf.Route) {
 
 type RouteEntity struct {
 	Host   string
+	Path   string
 	Domain DomainResource
 	Space  SpaceResource
 	Apps   []ApplicationResource
 }
 
 type RouteRepository interface {
-	ListRoutes(stop chan bool) (routesChan chan []cf.Route, statusChan chan net.ApiResponse)
+	ListRoutes(cb func([]cf.Route) bool) (apiResponse net.ApiResponse)
 	FindByHost(host string) (route cf.Route, apiResponse net.ApiResponse)
 	FindByHostAndDomain(host, domain string) (route cf.Route, apiResponse net.ApiResponse)
+	FindByHostDomainAndPath(host, domain, path string) (route cf.Route, apiResponse net.ApiResponse)
 	Create(host, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse)
+	CreateWithPath(host, path, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse)
 	CreateInSpace(host, domainGuid, spaceGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse)
 	Bind(routeGuid, appGuid string) (apiResponse net.ApiResponse)
 	Unbind(routeGuid, appGuid string) (apiResponse net.ApiResponse)
@@ -64,42 +68,12 @@ func NewCloudControllerRouteRepository(config *configuration.Configuration, gate
 	return
 }
 
-func (repo CloudControllerRouteRepository) ListRoutes(stop chan bool) (routesChan chan []cf.Route, statusChan chan net.ApiResponse) {
-	routesChan = make(chan []cf.Route, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := fmt.Sprintf("/v2/routes?inline-relations-depth=1")
-
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					routes      []cf.Route
-					apiResponse net.ApiResponse
-				)
-				routes, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(routesChan)
-					close(statusChan)
-					return
-				}
-
-				if len(routes) > 0 {
-					routesChan <- routes
-				}
-			}
-		}
-		close(routesChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
-
-	return
+func (repo CloudControllerRouteRepository) ListRoutes(cb func([]cf.Route) bool) (apiResponse net.ApiResponse) {
+	return NewPaginatedIterator("/v2/routes?inline-relations-depth=1", func(path string) (interface{}, string, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Route))
+	})
 }
 
 func (repo CloudControllerRouteRepository) FindByHost(host string) (route cf.Route, apiResponse net.ApiResponse) {
@@ -108,18 +82,30 @@ func (repo CloudControllerRouteRepository) FindByHost(host string) (route cf.Rou
 }
 
 func (repo CloudControllerRouteRepository) FindByHostAndDomain(host, domainName string) (route cf.Route, apiResponse net.ApiResponse) {
+	return repo.FindByHostDomainAndPath(host, domainName, "")
+}
+
+func (repo CloudControllerRouteRepository) FindByHostDomainAndPath(host, domainName, routePath string) (route cf.Route, apiResponse net.ApiResponse) {
 	domain, apiResponse := repo.domainRepo.FindByName(domainName)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
 	path := fmt.Sprintf("/v2/routes?inline-relations-depth=1&q=host%%3A%s%%3Bdomain_guid%%3A%s", host, domain.Guid)
-	route, apiResponse = repo.findOneWithPath(path)
+	routes, _, apiResponse := repo.findNextWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	route.Domain = domain.DomainFields
+	for _, candidate := range routes {
+		if candidate.Path == routePath {
+			route = candidate
+			route.Domain = domain.DomainFields
+			return
+		}
+	}
+
+	apiResponse = net.NewNotFoundApiResponse("Route not found")
 	return
 }
 
@@ -140,7 +126,7 @@ func (repo CloudControllerRouteRepository) findOneWithPath(path string) (route c
 
 func (repo CloudControllerRouteRepository) findNextWithPath(path string) (routes []cf.Route, nextUrl string, apiResponse net.ApiResponse) {
 	routesResources := new(PaginatedRouteResources)
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, routesResources)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), routesResources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -157,12 +143,23 @@ func (repo CloudControllerRouteRepository) Create(host, domainGuid string) (crea
 	return repo.CreateInSpace(host, domainGuid, repo.config.SpaceFields.Guid)
 }
 
+func (repo CloudControllerRouteRepository) CreateWithPath(host, routePath, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
+	return repo.create(host, routePath, domainGuid, repo.config.SpaceFields.Guid)
+}
+
 func (repo CloudControllerRouteRepository) CreateInSpace(host, domainGuid, spaceGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
+	return repo.create(host, "", domainGuid, spaceGuid)
+}
+
+func (repo CloudControllerRouteRepository) create(host, routePath, domainGuid, spaceGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/routes?inline-relations-depth=1", repo.config.Target)
 	data := fmt.Sprintf(`{"host":"%s","domain_guid":"%s","space_guid":"%s"}`, host, domainGuid, spaceGuid)
+	if routePath != "" {
+		data = fmt.Sprintf(`{"host":"%s","path":"%s","domain_guid":"%s","space_guid":"%s"}`, host, routePath, domainGuid, spaceGuid)
+	}
 
 	resource := new(RouteResource)
-	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, strings.NewReader(data), resource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(data), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -173,15 +170,15 @@ func (repo CloudControllerRouteRepository) CreateInSpace(host, domainGuid, space
 
 func (repo CloudControllerRouteRepository) Bind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/routes/%s", repo.config.Target, appGuid, routeGuid)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, nil)
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), nil)
 }
 
 func (repo CloudControllerRouteRepository) Unbind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/apps/%s/routes/%s", repo.config.Target, appGuid, routeGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
 
 func (repo CloudControllerRouteRepository) Delete(routeGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/routes/%s", repo.config.Target, routeGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
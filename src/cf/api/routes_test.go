@@ -117,15 +117,11 @@ func TestRoutesListRoutes(t *testing.T) {
 	ts, handler, repo, _ := createRoutesRepo(t, firstRequest, secondRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	routesChan, statusChan := repo.ListRoutes(stopChan)
-
 	routes := []cf.Route{}
-	for chunk := range routesChan {
+	apiResponse := repo.ListRoutes(func(chunk []cf.Route) bool {
 		routes = append(routes, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, len(routes), 2)
 	assert.Equal(t, routes[0].Guid, "route-1-guid")
@@ -144,14 +140,13 @@ func TestRoutesListRoutesWithNoRoutes(t *testing.T) {
 	ts, handler, repo, _ := createRoutesRepo(t, emptyRoutesRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	routesChan, statusChan := repo.ListRoutes(stopChan)
-
-	_, ok := <-routesChan
-	apiResponse := <-statusChan
+	called := false
+	apiResponse := repo.ListRoutes(func(chunk []cf.Route) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.False(t, called)
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsSuccessful())
 }
@@ -247,6 +242,44 @@ func TestFindByHostAndDomainWhenRouteIsNotFound(t *testing.T) {
 	assert.True(t, apiResponse.IsNotFound())
 }
 
+func TestFindByHostDomainAndPath(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/routes?q=host%3Amy-cool-app%3Bdomain_guid%3Amy-domain-guid",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{ "resources": [
+  {
+    "metadata": { "guid": "my-route-guid" },
+    "entity": { "host": "my-cool-app" }
+  },
+  {
+    "metadata": { "guid": "my-path-route-guid" },
+    "entity": { "host": "my-cool-app", "path": "/api" }
+  }
+]}`},
+	})
+
+	ts, handler, repo, domainRepo := createRoutesRepo(t, request, request, request)
+	defer ts.Close()
+
+	domain := cf.Domain{}
+	domain.Guid = "my-domain-guid"
+	domainRepo.FindByNameDomain = domain
+
+	route, apiResponse := repo.FindByHostDomainAndPath("my-cool-app", "my-domain.com", "/api")
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, route.Guid, "my-path-route-guid")
+	assert.Equal(t, route.Path, "/api")
+	assert.Equal(t, route.Domain.Guid, domain.Guid)
+
+	route, apiResponse = repo.FindByHostAndDomain("my-cool-app", "my-domain.com")
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, route.Guid, "my-route-guid")
+
+	_, apiResponse = repo.FindByHostDomainAndPath("my-cool-app", "my-domain.com", "/other")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotFound())
+}
+
 func TestCreateInSpace(t *testing.T) {
 	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:  "POST",
@@ -291,6 +324,29 @@ func TestCreateRoute(t *testing.T) {
 	assert.Equal(t, createdRoute.Guid, "my-route-guid")
 }
 
+func TestCreateRouteWithPath(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:  "POST",
+		Path:    "/v2/routes?inline-relations-depth=1",
+		Matcher: testnet.RequestBodyMatcher(`{"host":"my-cool-app","path":"/api","domain_guid":"my-domain-guid","space_guid":"my-space-guid"}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated, Body: `
+{
+  "metadata": { "guid": "my-route-guid" },
+  "entity": { "host": "my-cool-app", "path": "/api" }
+}`},
+	})
+
+	ts, handler, repo, _ := createRoutesRepo(t, request)
+	defer ts.Close()
+
+	createdRoute, apiResponse := repo.CreateWithPath("my-cool-app", "/api", "my-domain-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.False(t, apiResponse.IsNotSuccessful())
+
+	assert.Equal(t, createdRoute.Guid, "my-route-guid")
+	assert.Equal(t, createdRoute.Path, "/api")
+}
+
 func TestBind(t *testing.T) {
 	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "PUT",
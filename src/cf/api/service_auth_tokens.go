@@ -65,7 +65,7 @@ func (repo CloudControllerServiceAuthTokenRepository) FindByLabelAndProvider(lab
 func (repo CloudControllerServiceAuthTokenRepository) findAllWithPath(path string) (authTokens []cf.ServiceAuthTokenFields, apiResponse net.ApiResponse) {
 	resources := new(PaginatedAuthTokenResources)
 
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -83,16 +83,16 @@ func (repo CloudControllerServiceAuthTokenRepository) findAllWithPath(path strin
 func (repo CloudControllerServiceAuthTokenRepository) Create(authToken cf.ServiceAuthTokenFields) (apiResponse net.ApiResponse) {
 	body := fmt.Sprintf(`{"label":"%s","provider":"%s","token":"%s"}`, authToken.Label, authToken.Provider, authToken.Token)
 	path := fmt.Sprintf("%s/v2/service_auth_tokens", repo.config.Target)
-	return repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceAuthTokenRepository) Delete(authToken cf.ServiceAuthTokenFields) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/service_auth_tokens/%s", repo.config.Target, authToken.Guid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
 
 func (repo CloudControllerServiceAuthTokenRepository) Update(authToken cf.ServiceAuthTokenFields) (apiResponse net.ApiResponse) {
 	body := fmt.Sprintf(`{"token":"%s"}`, authToken.Token)
 	path := fmt.Sprintf("%s/v2/service_auth_tokens/%s", repo.config.Target, authToken.Guid)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
@@ -30,7 +30,7 @@ func (repo CloudControllerServiceBindingRepository) Create(instanceGuid, appGuid
 		`{"app_guid":"%s","service_instance_guid":"%s"}`,
 		appGuid, instanceGuid,
 	)
-	return repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceBindingRepository) Delete(instance cf.ServiceInstance, appGuid string) (found bool, apiResponse net.ApiResponse) {
@@ -49,6 +49,6 @@ func (repo CloudControllerServiceBindingRepository) Delete(instance cf.ServiceIn
 		found = true
 	}
 
-	apiResponse = repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	apiResponse = repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 	return
 }
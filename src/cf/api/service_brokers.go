@@ -36,7 +36,7 @@ type ServiceBrokerEntity struct {
 }
 
 type ServiceBrokerRepository interface {
-	ListServiceBrokers(stop chan bool) (serviceBrokersChan chan []cf.ServiceBroker, statusChan chan net.ApiResponse)
+	ListServiceBrokers(cb func([]cf.ServiceBroker) bool) (apiResponse net.ApiResponse)
 	FindByName(name string) (serviceBroker cf.ServiceBroker, apiResponse net.ApiResponse)
 	Create(name, url, username, password string) (apiResponse net.ApiResponse)
 	Update(serviceBroker cf.ServiceBroker) (apiResponse net.ApiResponse)
@@ -55,42 +55,12 @@ func NewCloudControllerServiceBrokerRepository(config *configuration.Configurati
 	return
 }
 
-func (repo CloudControllerServiceBrokerRepository) ListServiceBrokers(stop chan bool) (serviceBrokersChan chan []cf.ServiceBroker, statusChan chan net.ApiResponse) {
-	serviceBrokersChan = make(chan []cf.ServiceBroker, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := "/v2/service_brokers"
-
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					serviceBrokers []cf.ServiceBroker
-					apiResponse    net.ApiResponse
-				)
-				serviceBrokers, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(serviceBrokersChan)
-					close(statusChan)
-					return
-				}
-
-				if len(serviceBrokers) > 0 {
-					serviceBrokersChan <- serviceBrokers
-				}
-			}
-		}
-		close(serviceBrokersChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
-
-	return
+func (repo CloudControllerServiceBrokerRepository) ListServiceBrokers(cb func([]cf.ServiceBroker) bool) (apiResponse net.ApiResponse) {
+	return NewPaginatedIterator("/v2/service_brokers", func(path string) (interface{}, string, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.ServiceBroker))
+	})
 }
 
 func (repo CloudControllerServiceBrokerRepository) FindByName(name string) (serviceBroker cf.ServiceBroker, apiResponse net.ApiResponse) {
@@ -112,7 +82,7 @@ func (repo CloudControllerServiceBrokerRepository) FindByName(name string) (serv
 func (repo CloudControllerServiceBrokerRepository) findNextWithPath(path string) (serviceBrokers []cf.ServiceBroker, nextUrl string, apiResponse net.ApiResponse) {
 	resources := new(PaginatedServiceBrokerResources)
 
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -130,7 +100,7 @@ func (repo CloudControllerServiceBrokerRepository) Create(name, url, username, p
 	body := fmt.Sprintf(
 		`{"name":"%s","broker_url":"%s","auth_username":"%s","auth_password":"%s"}`, name, url, username, password,
 	)
-	return repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceBrokerRepository) Update(serviceBroker cf.ServiceBroker) (apiResponse net.ApiResponse) {
@@ -139,16 +109,16 @@ func (repo CloudControllerServiceBrokerRepository) Update(serviceBroker cf.Servi
 		`{"broker_url":"%s","auth_username":"%s","auth_password":"%s"}`,
 		serviceBroker.Url, serviceBroker.Username, serviceBroker.Password,
 	)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceBrokerRepository) Rename(guid, name string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/service_brokers/%s", repo.config.Target, guid)
 	body := fmt.Sprintf(`{"name":"%s"}`, name)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceBrokerRepository) Delete(guid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/service_brokers/%s", repo.config.Target, guid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
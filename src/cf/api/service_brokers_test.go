@@ -63,15 +63,11 @@ func TestServiceBrokersListServiceBrokers(t *testing.T) {
 	ts, handler, repo := createServiceBrokerRepo(t, firstRequest, secondRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	serviceBrokersChan, statusChan := repo.ListServiceBrokers(stopChan)
-
 	serviceBrokers := []cf.ServiceBroker{}
-	for chunk := range serviceBrokersChan {
+	apiResponse := repo.ListServiceBrokers(func(chunk []cf.ServiceBroker) bool {
 		serviceBrokers = append(serviceBrokers, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, len(serviceBrokers), 2)
 	assert.Equal(t, serviceBrokers[0].Guid, "found-guid-1")
@@ -93,14 +89,13 @@ func TestServiceBrokersListServiceBrokersWithNoServiceBrokers(t *testing.T) {
 	ts, handler, repo := createServiceBrokerRepo(t, emptyServiceBrokersRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	serviceBrokersChan, statusChan := repo.ListServiceBrokers(stopChan)
-
-	_, ok := <-serviceBrokersChan
-	apiResponse := <-statusChan
+	called := false
+	apiResponse := repo.ListServiceBrokers(func(chunk []cf.ServiceBroker) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.False(t, called)
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsSuccessful())
 }
@@ -28,6 +28,7 @@ func (resource ServiceInstancesSummaries) ToModels() (instances []cf.ServiceInst
 		serviceOffering.Version = offeringSummary.Version
 
 		instance := cf.ServiceInstance{}
+		instance.Guid = instanceSummary.Guid
 		instance.Name = instanceSummary.Name
 		instance.ApplicationNames = applicationNames
 		instance.ServicePlan = servicePlan
@@ -57,6 +58,7 @@ type ServiceInstanceSummaryApp struct {
 }
 
 type ServiceInstanceSummary struct {
+	Guid        string
 	Name        string
 	ServicePlan ServicePlanSummary `json:"service_plan"`
 }
@@ -75,6 +77,7 @@ type ServiceOfferingSummary struct {
 
 type ServiceSummaryRepository interface {
 	GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse)
+	GetSummariesInSpace(spaceGuid string) (instances []cf.ServiceInstance, apiResponse net.ApiResponse)
 }
 
 type CloudControllerServiceSummaryRepository struct {
@@ -89,10 +92,14 @@ func NewCloudControllerServiceSummaryRepository(config *configuration.Configurat
 }
 
 func (repo CloudControllerServiceSummaryRepository) GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
-	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, repo.config.SpaceFields.Guid)
+	return repo.GetSummariesInSpace(repo.config.SpaceFields.Guid)
+}
+
+func (repo CloudControllerServiceSummaryRepository) GetSummariesInSpace(spaceGuid string) (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/spaces/%s/summary", repo.config.Target, spaceGuid)
 	resource := new(ServiceInstancesSummaries)
 
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resource)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -63,6 +63,7 @@ func TestServiceSummaryGetSummariesInCurrentSpace(t *testing.T) {
 	assert.Equal(t, 1, len(serviceInstances))
 
 	instance1 := serviceInstances[0]
+	assert.Equal(t, instance1.Guid, "my-service-instance-guid")
 	assert.Equal(t, instance1.Name, "my-service-instance")
 	assert.Equal(t, instance1.ServicePlan.Name, "spark")
 	assert.Equal(t, instance1.ServiceOffering.Label, "cleardb")
@@ -74,6 +75,23 @@ func TestServiceSummaryGetSummariesInCurrentSpace(t *testing.T) {
 	assert.Equal(t, instance1.ApplicationNames[1], "app2")
 }
 
+func TestServiceSummaryGetSummariesInSpace(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "GET",
+		Path:     "/v2/spaces/other-space-guid/summary",
+		Response: serviceInstanceSummariesResponse,
+	})
+
+	ts, handler, repo := createServiceSummaryRepo(t, req)
+	defer ts.Close()
+
+	serviceInstances, apiResponse := repo.GetSummariesInSpace("other-space-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, len(serviceInstances), 1)
+	assert.Equal(t, serviceInstances[0].Name, "my-service-instance")
+}
+
 func createServiceSummaryRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo ServiceSummaryRepository) {
 	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
 	space := cf.SpaceFields{}
@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/configuration"
 	"cf/net"
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -75,6 +76,9 @@ type ServiceInstanceResource struct {
 func (resource ServiceInstanceResource) ToFields() (fields cf.ServiceInstanceFields) {
 	fields.Guid = resource.Metadata.Guid
 	fields.Name = resource.Entity.Name
+	fields.LastOperation.Type = resource.Entity.LastOperation.Type
+	fields.LastOperation.State = resource.Entity.LastOperation.State
+	fields.LastOperation.Description = resource.Entity.LastOperation.Description
 	return
 }
 
@@ -94,6 +98,13 @@ type ServiceInstanceEntity struct {
 	Name            string
 	ServiceBindings []ServiceBindingResource `json:"service_bindings"`
 	ServicePlan     ServicePlanResource      `json:"service_plan"`
+	LastOperation   LastOperationEntity      `json:"last_operation"`
+}
+
+type LastOperationEntity struct {
+	Type        string
+	State       string
+	Description string
 }
 
 type ServiceBindingResource struct {
@@ -115,7 +126,7 @@ type ServiceBindingEntity struct {
 type ServiceRepository interface {
 	GetServiceOfferings() (offerings cf.ServiceOfferings, apiResponse net.ApiResponse)
 	FindInstanceByName(name string) (instance cf.ServiceInstance, apiResponse net.ApiResponse)
-	CreateServiceInstance(name, planGuid string) (identicalAlreadyExists bool, apiResponse net.ApiResponse)
+	CreateServiceInstance(name, planGuid string, params map[string]interface{}, acceptsIncomplete bool) (identicalAlreadyExists bool, apiResponse net.ApiResponse)
 	RenameService(instance cf.ServiceInstance, newName string) (apiResponse net.ApiResponse)
 	DeleteService(instance cf.ServiceInstance) (apiResponse net.ApiResponse)
 }
@@ -140,7 +151,7 @@ func (repo CloudControllerServiceRepository) GetServiceOfferings() (offerings cf
 	}
 
 	resources := new(PaginatedServiceOfferingResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -156,7 +167,7 @@ func (repo CloudControllerServiceRepository) FindInstanceByName(name string) (in
 	path := fmt.Sprintf("%s/v2/spaces/%s/service_instances?return_user_provided_service_instances=true&q=name%s&inline-relations-depth=2", repo.config.Target, repo.config.SpaceFields.Guid, "%3A"+name)
 
 	resources := new(PaginatedServiceInstanceResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -171,14 +182,27 @@ func (repo CloudControllerServiceRepository) FindInstanceByName(name string) (in
 	return
 }
 
-func (repo CloudControllerServiceRepository) CreateServiceInstance(name, planGuid string) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
+func (repo CloudControllerServiceRepository) CreateServiceInstance(name, planGuid string, params map[string]interface{}, acceptsIncomplete bool) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/service_instances", repo.config.Target)
+	if acceptsIncomplete {
+		path = path + "?accepts_incomplete=true"
+	}
 	data := fmt.Sprintf(
-		`{"name":"%s","service_plan_guid":"%s","space_guid":"%s"}`,
+		`{"name":"%s","service_plan_guid":"%s","space_guid":"%s"`,
 		name, planGuid, repo.config.SpaceFields.Guid,
 	)
 
-	apiResponse = repo.gateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(data))
+	if len(params) > 0 {
+		paramsJson, err := json.Marshal(params)
+		if err != nil {
+			apiResponse = net.NewApiResponseWithError("Error encoding service instance parameters", err)
+			return
+		}
+		data = data + fmt.Sprintf(`,"parameters":%s`, paramsJson)
+	}
+	data = data + "}"
+
+	apiResponse = repo.gateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(data))
 
 	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode == cf.SERVICE_INSTANCE_NAME_TAKEN {
 
@@ -201,7 +225,7 @@ func (repo CloudControllerServiceRepository) RenameService(instance cf.ServiceIn
 	if instance.IsUserProvided() {
 		path = fmt.Sprintf("%s/v2/user_provided_service_instances/%s", repo.config.Target, instance.Guid)
 	}
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerServiceRepository) DeleteService(instance cf.ServiceInstance) (apiResponse net.ApiResponse) {
@@ -209,5 +233,5 @@ func (repo CloudControllerServiceRepository) DeleteService(instance cf.ServiceIn
 		return net.NewApiResponseWithMessage("Cannot delete service instance, apps are still bound to it")
 	}
 	path := fmt.Sprintf("%s/v2/service_instances/%s", repo.config.Target, instance.Guid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
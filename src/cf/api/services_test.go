@@ -123,12 +123,45 @@ func TestCreateServiceInstance(t *testing.T) {
 	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
 	defer ts.Close()
 
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("instance-name", "plan-guid")
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("instance-name", "plan-guid", nil, false)
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsSuccessful())
 	assert.Equal(t, identicalAlreadyExists, false)
 }
 
+func TestCreateServiceInstanceWithParameters(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/v2/service_instances",
+		Matcher:  testnet.RequestBodyMatcher(`{"name":"instance-name","service_plan_guid":"plan-guid","space_guid":"my-space-guid","parameters":{"max_connections":10,"tags":["primary"]}}`),
+		Response: testnet.TestResponse{Status: http.StatusCreated},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	params := map[string]interface{}{"max_connections": 10, "tags": []interface{}{"primary"}}
+	_, apiResponse := repo.CreateServiceInstance("instance-name", "plan-guid", params, false)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
+func TestCreateServiceInstanceAcceptingIncompleteProvisioning(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "POST",
+		Path:     "/v2/service_instances?accepts_incomplete=true",
+		Matcher:  testnet.RequestBodyMatcher(`{"name":"instance-name","service_plan_guid":"plan-guid","space_guid":"my-space-guid"}`),
+		Response: testnet.TestResponse{Status: http.StatusAccepted},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	_, apiResponse := repo.CreateServiceInstance("instance-name", "plan-guid", nil, true)
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
 func TestCreateServiceInstanceWhenIdenticalServiceAlreadyExists(t *testing.T) {
 	errorReq := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:  "POST",
@@ -143,7 +176,7 @@ func TestCreateServiceInstanceWhenIdenticalServiceAlreadyExists(t *testing.T) {
 	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{errorReq, findServiceInstanceReq})
 	defer ts.Close()
 
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", "plan-guid")
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", "plan-guid", nil, false)
 
 	assert.True(t, handler.AllRequestsCalled())
 	assert.False(t, apiResponse.IsNotSuccessful())
@@ -164,7 +197,7 @@ func TestCreateServiceInstanceWhenDifferentServiceAlreadyExists(t *testing.T) {
 	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{errorReq, findServiceInstanceReq})
 	defer ts.Close()
 
-	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", "different-plan-guid")
+	identicalAlreadyExists, apiResponse := repo.CreateServiceInstance("my-service", "different-plan-guid", nil, false)
 
 	assert.True(t, handler.AllRequestsCalled())
 	assert.True(t, apiResponse.IsNotSuccessful())
@@ -245,6 +278,37 @@ func TestFindInstanceByName(t *testing.T) {
 	assert.Equal(t, binding.AppGuid, "app-1-guid")
 }
 
+func TestFindInstanceByNameWithLastOperation(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/spaces/my-space-guid/service_instances?return_user_provided_service_instances=true&q=name%3Amy-service",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"resources": [
+			{
+			  "metadata": {"guid": "my-service-instance-guid"},
+			  "entity": {
+				"name": "my-service",
+				"last_operation": {
+				  "type": "create",
+				  "state": "in progress",
+				  "description": "Provisioning"
+				}
+			  }
+			}
+		]}`},
+	})
+
+	ts, handler, repo := createServiceRepo(t, []testnet.TestRequest{req})
+	defer ts.Close()
+
+	instance, apiResponse := repo.FindInstanceByName("my-service")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, instance.LastOperation.Type, "create")
+	assert.Equal(t, instance.LastOperation.State, cf.LastOperationInProgress)
+	assert.Equal(t, instance.LastOperation.Description, "Provisioning")
+}
+
 func TestFindInstanceByNameForNonExistentService(t *testing.T) {
 	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "GET",
@@ -9,8 +9,9 @@ import (
 )
 
 type PaginatedSpaceResources struct {
-	Resources []SpaceResource
-	NextUrl   string `json:"next_url"`
+	Resources  []SpaceResource
+	NextUrl    string `json:"next_url"`
+	TotalPages int    `json:"total_pages"`
 }
 
 type SpaceResource struct {
@@ -51,7 +52,8 @@ type SpaceEntity struct {
 }
 
 type SpaceRepository interface {
-	ListSpaces(stop chan bool) (spacesChan chan []cf.Space, statusChan chan net.ApiResponse)
+	ListSpaces(cb func([]cf.Space) bool) (apiResponse net.ApiResponse)
+	ListSpacesInOrg(orgGuid string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse)
 	FindByName(name string) (space cf.Space, apiResponse net.ApiResponse)
 	FindByNameInOrg(name, orgGuid string) (space cf.Space, apiResponse net.ApiResponse)
 	Create(name string, orgGuid string) (space cf.Space, apiResponse net.ApiResponse)
@@ -70,42 +72,16 @@ func NewCloudControllerSpaceRepository(config *configuration.Configuration, gate
 	return
 }
 
-func (repo CloudControllerSpaceRepository) ListSpaces(stop chan bool) (spacesChan chan []cf.Space, statusChan chan net.ApiResponse) {
-	spacesChan = make(chan []cf.Space, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		path := fmt.Sprintf("/v2/organizations/%s/spaces", repo.config.OrganizationFields.Guid)
-
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					spaces      []cf.Space
-					apiResponse net.ApiResponse
-				)
-				spaces, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(spacesChan)
-					close(statusChan)
-					return
-				}
-
-				if len(spaces) > 0 {
-					spacesChan <- spaces
-				}
-			}
-		}
-		close(spacesChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
+func (repo CloudControllerSpaceRepository) ListSpaces(cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	return repo.ListSpacesInOrg(repo.config.OrganizationFields.Guid, cb)
+}
 
-	return
+func (repo CloudControllerSpaceRepository) ListSpacesInOrg(orgGuid string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	return NewParallelPaginatedIterator(fmt.Sprintf("/v2/organizations/%s/spaces", orgGuid), func(path string) (interface{}, string, int, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Space))
+	})
 }
 
 func (repo CloudControllerSpaceRepository) FindByName(name string) (space cf.Space, apiResponse net.ApiResponse) {
@@ -115,7 +91,7 @@ func (repo CloudControllerSpaceRepository) FindByName(name string) (space cf.Spa
 func (repo CloudControllerSpaceRepository) FindByNameInOrg(name, orgGuid string) (space cf.Space, apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("/v2/organizations/%s/spaces?q=name%%3A%s&inline-relations-depth=1", orgGuid, strings.ToLower(name))
 
-	spaces, _, apiResponse := repo.findNextWithPath(path)
+	spaces, _, _, apiResponse := repo.findNextWithPath(path)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -129,14 +105,15 @@ func (repo CloudControllerSpaceRepository) FindByNameInOrg(name, orgGuid string)
 	return
 }
 
-func (repo CloudControllerSpaceRepository) findNextWithPath(path string) (spaces []cf.Space, nextUrl string, apiResponse net.ApiResponse) {
+func (repo CloudControllerSpaceRepository) findNextWithPath(path string) (spaces []cf.Space, nextUrl string, totalPages int, apiResponse net.ApiResponse) {
 	resources := new(PaginatedSpaceResources)
-	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
 	nextUrl = resources.NextUrl
+	totalPages = resources.TotalPages
 
 	for _, r := range resources.Resources {
 		spaces = append(spaces, r.ToModel())
@@ -148,7 +125,7 @@ func (repo CloudControllerSpaceRepository) Create(name string, orgGuid string) (
 	path := fmt.Sprintf("%s/v2/spaces?inline-relations-depth=1", repo.config.Target)
 	body := fmt.Sprintf(`{"name":"%s","organization_guid":"%s"}`, name, orgGuid)
 	resource := new(SpaceResource)
-	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.AccessToken, strings.NewReader(body), resource)
+	apiResponse = repo.gateway.CreateResourceForResponse(path, repo.config.GetAccessToken(), strings.NewReader(body), resource)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -159,10 +136,10 @@ func (repo CloudControllerSpaceRepository) Create(name string, orgGuid string) (
 func (repo CloudControllerSpaceRepository) Rename(spaceGuid, newName string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/spaces/%s", repo.config.Target, spaceGuid)
 	body := fmt.Sprintf(`{"name":"%s"}`, newName)
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerSpaceRepository) Delete(spaceGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/spaces/%s?recursive=true", repo.config.Target, spaceGuid)
-	return repo.gateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.gateway.DeleteResource(path, repo.config.GetAccessToken())
 }
@@ -20,6 +20,7 @@ func TestSpacesListSpaces(t *testing.T) {
 		Response: testnet.TestResponse{
 			Status: http.StatusOK,
 			Body: `{
+			"total_pages": 2,
 			"next_url": "/v2/organizations/some-org-guid/spaces?page=2",
 			"resources": [
 				{
@@ -54,15 +55,11 @@ func TestSpacesListSpaces(t *testing.T) {
 	ts, handler, repo := createSpacesRepo(t, firstPageSpacesRequest, secondPageSpacesRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	spacesChan, statusChan := repo.ListSpaces(stopChan)
-
 	spaces := []cf.Space{}
-	for chunk := range spacesChan {
+	apiResponse := repo.ListSpaces(func(chunk []cf.Space) bool {
 		spaces = append(spaces, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.Equal(t, spaces[0].Guid, "acceptance-space-guid")
 	assert.Equal(t, spaces[1].Guid, "staging-space-guid")
@@ -70,6 +67,37 @@ func TestSpacesListSpaces(t *testing.T) {
 	assert.True(t, handler.AllRequestsCalled())
 }
 
+func TestSpacesListSpacesInOrg(t *testing.T) {
+	request := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/organizations/other-org-guid/spaces",
+		Response: testnet.TestResponse{
+			Status: http.StatusOK,
+			Body: `{
+			"resources": [
+				{
+					"metadata": { "guid": "production-space-guid" },
+					"entity": { "name": "production" }
+				}
+			]
+		}`}})
+
+	ts, handler, repo := createSpacesRepo(t, request)
+	defer ts.Close()
+
+	spaces := []cf.Space{}
+	apiResponse := repo.ListSpacesInOrg("other-org-guid", func(chunk []cf.Space) bool {
+		spaces = append(spaces, chunk...)
+		return true
+	})
+
+	assert.Equal(t, len(spaces), 1)
+	assert.Equal(t, spaces[0].Guid, "production-space-guid")
+	assert.Equal(t, spaces[0].Name, "production")
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.True(t, handler.AllRequestsCalled())
+}
+
 func TestSpacesListSpacesWithNoSpaces(t *testing.T) {
 	emptySpacesRequest := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method: "GET",
@@ -83,14 +111,13 @@ func TestSpacesListSpacesWithNoSpaces(t *testing.T) {
 	ts, handler, repo := createSpacesRepo(t, emptySpacesRequest)
 	defer ts.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	spacesChan, statusChan := repo.ListSpaces(stopChan)
-
-	_, ok := <-spacesChan
-	apiResponse := <-statusChan
+	called := false
+	apiResponse := repo.ListSpaces(func(chunk []cf.Space) bool {
+		called = true
+		return true
+	})
 
-	assert.False(t, ok)
+	assert.False(t, called)
 	assert.True(t, apiResponse.IsSuccessful())
 	assert.True(t, handler.AllRequestsCalled())
 }
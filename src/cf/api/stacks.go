@@ -67,7 +67,7 @@ func (repo CloudControllerStackRepository) FindAll() (stacks []cf.Stack, apiResp
 
 func (repo CloudControllerStackRepository) findAllWithPath(path string) (stacks []cf.Stack, apiResponse net.ApiResponse) {
 	resources := new(PaginatedStackResources)
-	apiResponse = repo.gateway.GetResource(path, repo.config.AccessToken, resources)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
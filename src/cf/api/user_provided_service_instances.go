@@ -10,8 +10,9 @@ import (
 )
 
 type UserProvidedServiceInstanceRepository interface {
-	Create(name, drainUrl string, params map[string]string) (apiResponse net.ApiResponse)
+	Create(name, drainUrl string, params map[string]interface{}) (apiResponse net.ApiResponse)
 	Update(serviceInstanceFields cf.ServiceInstanceFields) (apiResponse net.ApiResponse)
+	FindByGuid(guid string) (serviceInstanceFields cf.ServiceInstanceFields, apiResponse net.ApiResponse)
 }
 
 type CCUserProvidedServiceInstanceRepository struct {
@@ -25,14 +26,14 @@ func NewCCUserProvidedServiceInstanceRepository(config *configuration.Configurat
 	return
 }
 
-func (repo CCUserProvidedServiceInstanceRepository) Create(name, drainUrl string, params map[string]string) (apiResponse net.ApiResponse) {
+func (repo CCUserProvidedServiceInstanceRepository) Create(name, drainUrl string, params map[string]interface{}) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/user_provided_service_instances", repo.config.Target)
 
 	type RequestBody struct {
-		Name           string            `json:"name"`
-		Credentials    map[string]string `json:"credentials"`
-		SpaceGuid      string            `json:"space_guid"`
-		SysLogDrainUrl string            `json:"syslog_drain_url"`
+		Name           string                 `json:"name"`
+		Credentials    map[string]interface{} `json:"credentials"`
+		SpaceGuid      string                 `json:"space_guid"`
+		SysLogDrainUrl string                 `json:"syslog_drain_url"`
 	}
 
 	jsonBytes, err := json.Marshal(RequestBody{
@@ -47,15 +48,15 @@ func (repo CCUserProvidedServiceInstanceRepository) Create(name, drainUrl string
 		return
 	}
 
-	return repo.gateway.CreateResource(path, repo.config.AccessToken, bytes.NewReader(jsonBytes))
+	return repo.gateway.CreateResource(path, repo.config.GetAccessToken(), bytes.NewReader(jsonBytes))
 }
 
 func (repo CCUserProvidedServiceInstanceRepository) Update(serviceInstanceFields cf.ServiceInstanceFields) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/user_provided_service_instances/%s", repo.config.Target, serviceInstanceFields.Guid)
 
 	type RequestBody struct {
-		Credentials    map[string]string `json:"credentials,omitempty"`
-		SysLogDrainUrl string            `json:"syslog_drain_url,omitempty"`
+		Credentials    map[string]interface{} `json:"credentials,omitempty"`
+		SysLogDrainUrl string                 `json:"syslog_drain_url,omitempty"`
 	}
 
 	reqBody := RequestBody{serviceInstanceFields.Params, serviceInstanceFields.SysLogDrainUrl}
@@ -65,5 +66,30 @@ func (repo CCUserProvidedServiceInstanceRepository) Update(serviceInstanceFields
 		return
 	}
 
-	return repo.gateway.UpdateResource(path, repo.config.AccessToken, bytes.NewReader(jsonBytes))
+	return repo.gateway.UpdateResource(path, repo.config.GetAccessToken(), bytes.NewReader(jsonBytes))
+}
+
+func (repo CCUserProvidedServiceInstanceRepository) FindByGuid(guid string) (serviceInstanceFields cf.ServiceInstanceFields, apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/user_provided_service_instances/%s", repo.config.Target, guid)
+
+	type ResponseBody struct {
+		Metadata Metadata
+		Entity   struct {
+			Name           string
+			Credentials    map[string]interface{}
+			SysLogDrainUrl string `json:"syslog_drain_url"`
+		}
+	}
+
+	resource := new(ResponseBody)
+	apiResponse = repo.gateway.GetResource(path, repo.config.GetAccessToken(), resource)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	serviceInstanceFields.Guid = resource.Metadata.Guid
+	serviceInstanceFields.Name = resource.Entity.Name
+	serviceInstanceFields.Params = resource.Entity.Credentials
+	serviceInstanceFields.SysLogDrainUrl = resource.Entity.SysLogDrainUrl
+	return
 }
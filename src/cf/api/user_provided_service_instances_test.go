@@ -23,7 +23,7 @@ func TestCreateUserProvidedServiceInstance(t *testing.T) {
 	ts, handler, repo := createUserProvidedServiceInstanceRepo(t, req)
 	defer ts.Close()
 
-	apiResponse := repo.Create("my-custom-service", "", map[string]string{
+	apiResponse := repo.Create("my-custom-service", "", map[string]interface{}{
 		"host":     "example.com",
 		"user":     "me",
 		"password": "secret",
@@ -43,7 +43,7 @@ func TestCreateUserProvidedServiceInstanceWithSyslogDrain(t *testing.T) {
 	ts, handler, repo := createUserProvidedServiceInstanceRepo(t, req)
 	defer ts.Close()
 
-	apiResponse := repo.Create("my-custom-service", "syslog://example.com", map[string]string{
+	apiResponse := repo.Create("my-custom-service", "syslog://example.com", map[string]interface{}{
 		"host":     "example.com",
 		"user":     "me",
 		"password": "secret",
@@ -63,7 +63,7 @@ func TestUpdateUserProvidedServiceInstance(t *testing.T) {
 	ts, handler, repo := createUserProvidedServiceInstanceRepo(t, req)
 	defer ts.Close()
 
-	params := map[string]string{
+	params := map[string]interface{}{
 		"host":     "example.com",
 		"user":     "me",
 		"password": "secret",
@@ -89,7 +89,7 @@ func TestUpdateUserProvidedServiceInstanceWithOnlyParams(t *testing.T) {
 	ts, handler, repo := createUserProvidedServiceInstanceRepo(t, req)
 	defer ts.Close()
 
-	params := map[string]string{
+	params := map[string]interface{}{
 		"host":     "example.com",
 		"user":     "me",
 		"password": "secret",
@@ -120,6 +120,38 @@ func TestUpdateUserProvidedServiceInstanceWithOnlySysLogDrainUrl(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
+func TestFindUserProvidedServiceInstanceByGuid(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/user_provided_service_instances/my-instance-guid",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `
+{
+  "metadata": {"guid": "my-instance-guid"},
+  "entity": {
+    "name": "my-instance",
+    "credentials": {"user": "me", "port": 5432, "hosts": ["db-1", "db-2"]},
+    "syslog_drain_url": "syslog://example.com"
+  }
+}`},
+	})
+
+	ts, handler, repo := createUserProvidedServiceInstanceRepo(t, req)
+	defer ts.Close()
+
+	serviceInstance, apiResponse := repo.FindByGuid("my-instance-guid")
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+
+	assert.Equal(t, serviceInstance.Guid, "my-instance-guid")
+	assert.Equal(t, serviceInstance.Name, "my-instance")
+	assert.Equal(t, serviceInstance.Params, map[string]interface{}{
+		"user":  "me",
+		"port":  float64(5432),
+		"hosts": []interface{}{"db-1", "db-2"},
+	})
+	assert.Equal(t, serviceInstance.SysLogDrainUrl, "syslog://example.com")
+}
+
 func createUserProvidedServiceInstanceRepo(t *testing.T, req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo UserProvidedServiceInstanceRepository) {
 	ts, handler = testnet.NewTLSServer(t, []testnet.TestRequest{req})
 	space := cf.SpaceFields{}
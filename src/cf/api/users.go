@@ -5,6 +5,7 @@ import (
 	"cf/configuration"
 	"cf/net"
 	"fmt"
+	"net/http"
 	neturl "net/url"
 	"strings"
 )
@@ -36,16 +37,32 @@ var spaceRoleToPathMap = map[string]string{
 	cf.SPACE_AUDITOR:   "auditors",
 }
 
+var orgRoleToUserPathMap = map[string]string{
+	cf.ORG_MANAGER:     "managed_organizations",
+	cf.BILLING_MANAGER: "billing_managed_organizations",
+	cf.ORG_AUDITOR:     "audited_organizations",
+}
+
+// a user's plain "spaces" are the ones they are a developer of
+var spaceRoleToUserPathMap = map[string]string{
+	cf.SPACE_MANAGER:   "managed_spaces",
+	cf.SPACE_DEVELOPER: "spaces",
+	cf.SPACE_AUDITOR:   "audited_spaces",
+}
+
 type UserRepository interface {
 	FindByUsername(username string) (user cf.UserFields, apiResponse net.ApiResponse)
-	ListUsersInOrgForRole(orgGuid string, role string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse)
-	ListUsersInSpaceForRole(spaceGuid string, role string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse)
+	ListUsersInOrgForRole(orgGuid string, role string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse)
+	ListUsersInSpaceForRole(spaceGuid string, role string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse)
+	ListOrgsForUserRole(userGuid string, role string, cb func([]cf.OrganizationFields) bool) (apiResponse net.ApiResponse)
+	ListSpacesForUserRole(userGuid string, role string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse)
 	Create(username, password string) (apiResponse net.ApiResponse)
 	Delete(userGuid string) (apiResponse net.ApiResponse)
 	SetOrgRole(userGuid, orgGuid, role string) (apiResponse net.ApiResponse)
 	UnsetOrgRole(userGuid, orgGuid, role string) (apiResponse net.ApiResponse)
 	SetSpaceRole(userGuid, spaceGuid, orgGuid, role string) (apiResponse net.ApiResponse)
 	UnsetSpaceRole(userGuid, spaceGuid, role string) (apiResponse net.ApiResponse)
+	RemoveFromOrg(userGuid, orgGuid string) (apiResponse net.ApiResponse)
 }
 
 type CloudControllerUserRepository struct {
@@ -82,57 +99,62 @@ func (repo CloudControllerUserRepository) FindByUsername(username string) (user
 	return
 }
 
-func (repo CloudControllerUserRepository) ListUsersInOrgForRole(orgGuid string, roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse) {
+func (repo CloudControllerUserRepository) ListUsersInOrgForRole(orgGuid string, roleName string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("/v2/organizations/%s/%s", orgGuid, orgRoleToPathMap[roleName])
-	return repo.listUsersForRole(path, roleName, stop)
+	return repo.listUsersWithPath(path, cb)
 }
 
-func (repo CloudControllerUserRepository) ListUsersInSpaceForRole(spaceGuid string, roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse) {
+func (repo CloudControllerUserRepository) ListUsersInSpaceForRole(spaceGuid string, roleName string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("/v2/spaces/%s/%s", spaceGuid, spaceRoleToPathMap[roleName])
-	return repo.listUsersForRole(path, roleName, stop)
+	return repo.listUsersWithPath(path, cb)
 }
 
-func (repo CloudControllerUserRepository) listUsersForRole(path string, roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse) {
-	usersChan = make(chan []cf.UserFields, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-	loop:
-		for path != "" {
-			select {
-			case <-stop:
-				break loop
-			default:
-				var (
-					users       []cf.UserFields
-					apiResponse net.ApiResponse
-				)
-
-				users, path, apiResponse = repo.findNextWithPath(path)
-				if apiResponse.IsNotSuccessful() {
-					statusChan <- apiResponse
-					close(usersChan)
-					close(statusChan)
-					return
-				}
-
-				if len(users) > 0 {
-					usersChan <- users
-				}
-			}
+func (repo CloudControllerUserRepository) ListOrgsForUserRole(userGuid string, roleName string, cb func([]cf.OrganizationFields) bool) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/users/%s/%s", userGuid, orgRoleToUserPathMap[roleName])
+	return NewPaginatedIterator(path, func(path string) (interface{}, string, net.ApiResponse) {
+		resources := new(PaginatedOrganizationResources)
+		apiResponse := repo.ccGateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), resources)
+
+		orgs := []cf.OrganizationFields{}
+		for _, r := range resources.Resources {
+			orgs = append(orgs, r.ToFields())
 		}
-		close(usersChan)
-		close(statusChan)
-		cf.WaitForClose(stop)
-	}()
+		return orgs, resources.NextUrl, apiResponse
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.OrganizationFields))
+	})
+}
 
-	return
+// ListSpacesForUserRole lists the spaces in which the user has the role. The
+// org of each space is inlined so callers can group spaces by org.
+func (repo CloudControllerUserRepository) ListSpacesForUserRole(userGuid string, roleName string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("/v2/users/%s/%s?inline-relations-depth=1", userGuid, spaceRoleToUserPathMap[roleName])
+	return NewPaginatedIterator(path, func(path string) (interface{}, string, net.ApiResponse) {
+		resources := new(PaginatedSpaceResources)
+		apiResponse := repo.ccGateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), resources)
+
+		spaces := []cf.Space{}
+		for _, r := range resources.Resources {
+			spaces = append(spaces, r.ToModel())
+		}
+		return spaces, resources.NextUrl, apiResponse
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.Space))
+	})
+}
+
+func (repo CloudControllerUserRepository) listUsersWithPath(path string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
+	return NewPaginatedIterator(path, func(path string) (interface{}, string, net.ApiResponse) {
+		return repo.findNextWithPath(path)
+	}).ForEachPage(func(page interface{}) bool {
+		return cb(page.([]cf.UserFields))
+	})
 }
 
 func (repo CloudControllerUserRepository) findNextWithPath(path string) (users []cf.UserFields, nextUrl string, apiResponse net.ApiResponse) {
 	paginatedResources := new(PaginatedUserResources)
 
-	apiResponse = repo.ccGateway.GetResource(repo.config.Target+path, repo.config.AccessToken, paginatedResources)
+	apiResponse = repo.ccGateway.GetResource(repo.config.Target+path, repo.config.GetAccessToken(), paginatedResources)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -170,7 +192,7 @@ func (repo CloudControllerUserRepository) updateOrFindUsersWithUAAPath(ccUsers [
 	}
 
 	uaaResponse := new(uaaUserResources)
-	apiResponse = repo.uaaGateway.GetResource(path, repo.config.AccessToken, uaaResponse)
+	apiResponse = repo.uaaGateway.GetResource(path, repo.config.GetAccessToken(), uaaResponse)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -213,7 +235,7 @@ func (repo CloudControllerUserRepository) Create(username, password string) (api
 		username,
 		username,
 	)
-	request, apiResponse := repo.uaaGateway.NewRequest("POST", path, repo.config.AccessToken, strings.NewReader(body))
+	request, apiResponse := repo.uaaGateway.NewRequest("POST", path, repo.config.GetAccessToken(), strings.NewReader(body))
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -224,19 +246,23 @@ func (repo CloudControllerUserRepository) Create(username, password string) (api
 	createUserResponse := &uaaUserFields{}
 
 	_, apiResponse = repo.uaaGateway.PerformRequestForJSONResponse(request, createUserResponse)
+	if apiResponse.StatusCode == http.StatusConflict {
+		apiResponse = net.NewApiResponse(apiResponse.Message, cf.USER_EXISTS, apiResponse.StatusCode)
+		return
+	}
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
 	path = fmt.Sprintf("%s/v2/users", repo.config.Target)
 	body = fmt.Sprintf(`{"guid":"%s"}`, createUserResponse.Id)
-	return repo.ccGateway.CreateResource(path, repo.config.AccessToken, strings.NewReader(body))
+	return repo.ccGateway.CreateResource(path, repo.config.GetAccessToken(), strings.NewReader(body))
 }
 
 func (repo CloudControllerUserRepository) Delete(userGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/users/%s", repo.config.Target, userGuid)
 
-	apiResponse = repo.ccGateway.DeleteResource(path, repo.config.AccessToken)
+	apiResponse = repo.ccGateway.DeleteResource(path, repo.config.GetAccessToken())
 	if apiResponse.IsNotSuccessful() && apiResponse.ErrorCode != cf.USER_NOT_FOUND {
 		return
 	}
@@ -247,7 +273,7 @@ func (repo CloudControllerUserRepository) Delete(userGuid string) (apiResponse n
 	}
 
 	path = fmt.Sprintf("%s/Users/%s", uaaEndpoint, userGuid)
-	return repo.uaaGateway.DeleteResource(path, repo.config.AccessToken)
+	return repo.uaaGateway.DeleteResource(path, repo.config.GetAccessToken())
 }
 
 func (repo CloudControllerUserRepository) SetOrgRole(userGuid string, orgGuid string, role string) (apiResponse net.ApiResponse) {
@@ -272,7 +298,7 @@ func (repo CloudControllerUserRepository) setOrUnsetOrgRole(verb, userGuid, orgG
 
 	path := fmt.Sprintf("%s/v2/organizations/%s/%s/%s", repo.config.Target, orgGuid, rolePath, userGuid)
 
-	request, apiResponse := repo.ccGateway.NewRequest(verb, path, repo.config.AccessToken, nil)
+	request, apiResponse := repo.ccGateway.NewRequest(verb, path, repo.config.GetAccessToken(), nil)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
@@ -295,7 +321,7 @@ func (repo CloudControllerUserRepository) SetSpaceRole(userGuid, spaceGuid, orgG
 		return
 	}
 
-	return repo.ccGateway.UpdateResource(rolePath, repo.config.AccessToken, nil)
+	return repo.ccGateway.UpdateResource(rolePath, repo.config.GetAccessToken(), nil)
 }
 
 func (repo CloudControllerUserRepository) UnsetSpaceRole(userGuid, spaceGuid, role string) (apiResponse net.ApiResponse) {
@@ -303,7 +329,7 @@ func (repo CloudControllerUserRepository) UnsetSpaceRole(userGuid, spaceGuid, ro
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
-	return repo.ccGateway.DeleteResource(rolePath, repo.config.AccessToken)
+	return repo.ccGateway.DeleteResource(rolePath, repo.config.GetAccessToken())
 }
 
 func (repo CloudControllerUserRepository) checkSpaceRole(userGuid, spaceGuid, role string) (fullPath string, apiResponse net.ApiResponse) {
@@ -317,7 +343,12 @@ func (repo CloudControllerUserRepository) checkSpaceRole(userGuid, spaceGuid, ro
 	return
 }
 
+func (repo CloudControllerUserRepository) RemoveFromOrg(userGuid, orgGuid string) (apiResponse net.ApiResponse) {
+	path := fmt.Sprintf("%s/v2/organizations/%s/users/%s", repo.config.Target, orgGuid, userGuid)
+	return repo.ccGateway.DeleteResource(path, repo.config.GetAccessToken())
+}
+
 func (repo CloudControllerUserRepository) addOrgUserRole(userGuid, orgGuid string) (apiResponse net.ApiResponse) {
 	path := fmt.Sprintf("%s/v2/organizations/%s/users/%s", repo.config.Target, orgGuid, userGuid)
-	return repo.ccGateway.UpdateResource(path, repo.config.AccessToken, nil)
+	return repo.ccGateway.UpdateResource(path, repo.config.GetAccessToken(), nil)
 }
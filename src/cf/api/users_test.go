@@ -76,15 +76,11 @@ func TestListUsersInOrgForRole(t *testing.T) {
 	defer cc.Close()
 	defer uaa.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	usersChan, statusChan := repo.ListUsersInOrgForRole("my-org-guid", cf.ORG_MANAGER, stopChan)
-
 	users := []cf.UserFields{}
-	for chunk := range usersChan {
+	apiResponse := repo.ListUsersInOrgForRole("my-org-guid", cf.ORG_MANAGER, func(chunk []cf.UserFields) bool {
 		users = append(users, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.True(t, ccHandler.AllRequestsCalled())
 	assert.True(t, uaaHandler.AllRequestsCalled())
@@ -104,15 +100,11 @@ func TestListUsersInSpaceForRole(t *testing.T) {
 	defer cc.Close()
 	defer uaa.Close()
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-	usersChan, statusChan := repo.ListUsersInSpaceForRole("my-space-guid", cf.SPACE_MANAGER, stopChan)
-
 	users := []cf.UserFields{}
-	for chunk := range usersChan {
+	apiResponse := repo.ListUsersInSpaceForRole("my-space-guid", cf.SPACE_MANAGER, func(chunk []cf.UserFields) bool {
 		users = append(users, chunk...)
-	}
-	apiResponse := <-statusChan
+		return true
+	})
 
 	assert.True(t, ccHandler.AllRequestsCalled())
 	assert.True(t, uaaHandler.AllRequestsCalled())
@@ -125,6 +117,76 @@ func TestListUsersInSpaceForRole(t *testing.T) {
 	assert.Equal(t, users[1].Username, "Super user 2")
 }
 
+func TestListOrgsForUserRole(t *testing.T) {
+	firstReq := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/users/my-user-guid/billing_managed_organizations",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+			"next_url": "/v2/users/my-user-guid/billing_managed_organizations?page=2",
+			"resources": [ {"metadata": {"guid": "org-1-guid"}, "entity": {"name": "org-1"}} ]
+		}`},
+	})
+	secondReq := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/users/my-user-guid/billing_managed_organizations?page=2",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+			"resources": [ {"metadata": {"guid": "org-2-guid"}, "entity": {"name": "org-2"}} ]
+		}`},
+	})
+
+	cc, ccHandler, repo := createUsersRepoWithoutUAAEndpoints(t, []testnet.TestRequest{firstReq, secondReq})
+	defer cc.Close()
+
+	orgs := []cf.OrganizationFields{}
+	apiResponse := repo.ListOrgsForUserRole("my-user-guid", cf.BILLING_MANAGER, func(chunk []cf.OrganizationFields) bool {
+		orgs = append(orgs, chunk...)
+		return true
+	})
+
+	assert.True(t, ccHandler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+
+	assert.Equal(t, len(orgs), 2)
+	assert.Equal(t, orgs[0].Guid, "org-1-guid")
+	assert.Equal(t, orgs[0].Name, "org-1")
+	assert.Equal(t, orgs[1].Guid, "org-2-guid")
+	assert.Equal(t, orgs[1].Name, "org-2")
+}
+
+func TestListSpacesForUserRole(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "GET",
+		Path:   "/v2/users/my-user-guid/spaces?inline-relations-depth=1",
+		Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+			"resources": [ {
+				"metadata": {"guid": "space-1-guid"},
+				"entity": {
+					"name": "space-1",
+					"organization": {"metadata": {"guid": "org-1-guid"}, "entity": {"name": "org-1"}}
+				}
+			} ]
+		}`},
+	})
+
+	cc, ccHandler, repo := createUsersRepoWithoutUAAEndpoints(t, []testnet.TestRequest{req})
+	defer cc.Close()
+
+	spaces := []cf.Space{}
+	apiResponse := repo.ListSpacesForUserRole("my-user-guid", cf.SPACE_DEVELOPER, func(chunk []cf.Space) bool {
+		spaces = append(spaces, chunk...)
+		return true
+	})
+
+	assert.True(t, ccHandler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+
+	assert.Equal(t, len(spaces), 1)
+	assert.Equal(t, spaces[0].Guid, "space-1-guid")
+	assert.Equal(t, spaces[0].Name, "space-1")
+	assert.Equal(t, spaces[0].Organization.Guid, "org-1-guid")
+	assert.Equal(t, spaces[0].Organization.Name, "org-1")
+}
+
 func TestFindByUsername(t *testing.T) {
 	usersResponse := `{ "resources": [
         { "id": "my-guid", "userName": "my-full-username" }
@@ -200,6 +262,25 @@ func TestCreateUser(t *testing.T) {
 	assert.False(t, apiResponse.IsNotSuccessful())
 }
 
+func TestCreateUserWhenTheUserAlreadyExists(t *testing.T) {
+	uaaReq := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method: "POST",
+		Path:   "/Users",
+		Response: testnet.TestResponse{
+			Status: http.StatusConflict,
+			Body:   `{"error":"scim_resource_already_exists","error_description":"Username already in use: my-user"}`,
+		},
+	})
+
+	uaa, uaaHandler, repo := createUsersRepoWithoutCCEndpoints(t, []testnet.TestRequest{uaaReq})
+	defer uaa.Close()
+
+	apiResponse := repo.Create("my-user", "my-password")
+	assert.True(t, uaaHandler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsNotSuccessful())
+	assert.Equal(t, apiResponse.ErrorCode, cf.USER_EXISTS)
+}
+
 func TestDeleteUser(t *testing.T) {
 	ccReq := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
 		Method:   "DELETE",
@@ -327,6 +408,22 @@ func testUnsetOrgRoleWithValidRole(t *testing.T, role string, path string) {
 	assert.True(t, apiResponse.IsSuccessful())
 }
 
+func TestRemoveFromOrg(t *testing.T) {
+	req := testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+		Method:   "DELETE",
+		Path:     "/v2/organizations/my-org-guid/users/my-user-guid",
+		Response: testnet.TestResponse{Status: http.StatusNoContent},
+	})
+
+	cc, handler, repo := createUsersRepoWithoutUAAEndpoints(t, []testnet.TestRequest{req})
+	defer cc.Close()
+
+	apiResponse := repo.RemoveFromOrg("my-user-guid", "my-org-guid")
+
+	assert.True(t, handler.AllRequestsCalled())
+	assert.True(t, apiResponse.IsSuccessful())
+}
+
 func TestSetSpaceRoleToSpaceManager(t *testing.T) {
 	testSetSpaceRoleWithValidRole(t, "SpaceManager", "/v2/spaces/my-space-guid/managers/my-user-guid")
 }
@@ -33,7 +33,10 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "api",
 			Description: "Set or view target api url",
-			Usage:       fmt.Sprintf("%s api [URL]", cf.Name()),
+			Usage:       fmt.Sprintf("%s api [URL] [--allow-insecure-http]", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "allow-insecure-http", Usage: "Allow an explicit http:// API URL, which is remembered for that target"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("api", c)
 			},
@@ -41,11 +44,48 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "app",
 			Description: "Display health and status for app",
-			Usage:       fmt.Sprintf("%s app APP", cf.Name()),
+			Usage: fmt.Sprintf("%s app APP [--stats] [--sample INTERVAL] [--count NUM] [--csv FILE]\n\n", cf.Name()) +
+				"   With --sample, --count or --csv, instances are polled every INTERVAL (10s by default),\n" +
+				"   NUM times or until interrupted, and a min/avg/max summary is shown at the end.\n\n" +
+				"   An instance is marked (replaced) when it started in the last 10 minutes, more than\n" +
+				"   a minute after an instance with a higher index. This is a guess from start times:\n" +
+				"   instances added by scaling up are not marked, nor is a replaced last instance.",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "stats", Usage: "Show the host, port and uptime of each instance"},
+				NewStringFlag("sample", "Interval between samples (e.g. 10s, 1m)"),
+				NewIntFlag("count", "Number of samples to take"),
+				NewStringFlag("csv", "Write every sample to a CSV file"),
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("app", c)
 			},
 		},
+		{
+			Name:        "apply-org-config",
+			Description: "Bring orgs, spaces, quotas, private domains and user roles in line with a config file",
+			Usage: fmt.Sprintf("%s apply-org-config FILE [--prune] [-f]\n\n", cf.Name()) +
+				"   Orgs, spaces and domains are created when missing and never deleted. With --prune, roles\n" +
+				"   not listed in the file are revoked in each listed org and in the spaces listed under it.\n\n" +
+				"EXAMPLE:\n" +
+				"   orgs:\n" +
+				"   - name: my-org\n" +
+				"     quota: paid\n" +
+				"     domains: [example.com]\n" +
+				"     users:\n" +
+				"       alice: [OrgManager]\n" +
+				"     spaces:\n" +
+				"     - name: development\n" +
+				"       users:\n" +
+				"         alice: [SpaceManager]\n" +
+				"         bob: [SpaceDeveloper, SpaceAuditor]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "prune", Usage: "Revoke roles that are not listed in the file"},
+				cli.BoolFlag{Name: "f", Usage: "Apply the changes without confirmation"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("apply-org-config", c)
+			},
+		},
 		{
 			Name:        "apps",
 			ShortName:   "a",
@@ -58,11 +98,16 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		{
 			Name:        "auth",
 			Description: "Authenticate user non-interactively",
-			Usage: fmt.Sprintf("%s auth USERNAME PASSWORD\n\n", cf.Name()) +
+			Usage: fmt.Sprintf("%s auth USERNAME PASSWORD\n", cf.Name()) +
+				fmt.Sprintf("   %s auth --client-credentials CLIENT_ID CLIENT_SECRET\n\n", cf.Name()) +
 				terminal.WarningColor("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\n") +
 				"EXAMPLE:\n" +
 				fmt.Sprintf("   %s auth name@example.com \"my password\" (use quotes for passwords with a space)\n", cf.Name()) +
-				fmt.Sprintf("   %s auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)", cf.Name()),
+				fmt.Sprintf("   %s auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)\n", cf.Name()) +
+				fmt.Sprintf("   %s auth --client-credentials my-ci-client my-client-secret (authenticate as a UAA client)", cf.Name()),
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "client-credentials", Usage: "Authenticate with a client id and secret instead of a username and password"},
+			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("auth", c)
 			},
@@ -76,6 +121,37 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("bind-service", c)
 			},
 		},
+		{
+			Name:        "autoscale",
+			Description: "Scale an app between instance limits based on its cpu and memory use",
+			Usage: fmt.Sprintf("%s autoscale APP [--min NUM] [--max NUM] [--cpu PERCENT] [--mem PERCENT]\n", cf.Name()) +
+				"               [--interval INTERVAL] [--cooldown INTERVAL] [--policy FILE] [--simulate FILE] [--count NUM]\n\n" +
+				"   Runs in the foreground until interrupted. Flags override the values of a policy file.\n" +
+				"   With --simulate, samples saved with 'app --csv' are replayed and no scaling is done.\n\n" +
+				"EXAMPLE POLICY FILE:\n" +
+				"   min: 2\n" +
+				"   max: 10\n" +
+				"   cpu: 70\n" +
+				"   mem: 80\n" +
+				"   scale_down_margin: 20\n" +
+				"   step: 1\n" +
+				"   interval: 30s\n" +
+				"   cooldown: 5m",
+			Flags: []cli.Flag{
+				NewIntFlag("min", "Minimum number of instances"),
+				NewIntFlag("max", "Maximum number of instances"),
+				NewIntFlag("cpu", "Average cpu percentage above which to scale up"),
+				NewIntFlag("mem", "Average memory percentage above which to scale up"),
+				NewStringFlag("interval", "Time between checks (e.g. 30s, 1m)"),
+				NewStringFlag("cooldown", "Time to wait after scaling before scaling again (e.g. 5m)"),
+				NewStringFlag("policy", "YAML file with the autoscaling policy"),
+				NewStringFlag("simulate", "CSV file of samples to replay instead of scaling the app"),
+				NewIntFlag("count", "Number of checks before exiting"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("autoscale", c)
+			},
+		},
 		{
 			Name:        "buildpacks",
 			Description: "List all buildpacks",
@@ -84,6 +160,21 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("buildpacks", c)
 			},
 		},
+		{
+			Name:        "copy-source",
+			Description: "Copy the bits of an app to another app, restaging and restarting the target",
+			Usage: fmt.Sprintf("%s copy-source SOURCE_APP TARGET_APP [-o ORG] [-s SPACE] [--no-restart]\n\n", cf.Name()) +
+				"   The target app is looked up in the given space, or the targeted one, and is created\n" +
+				"   with the memory, instances and environment of the source app when it does not exist.",
+			Flags: []cli.Flag{
+				NewStringFlag("o", "Org of the target app (requires -s)"),
+				NewStringFlag("s", "Space of the target app"),
+				cli.BoolFlag{Name: "no-restart", Usage: "Do not restart the target app after copying"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("copy-source", c)
+			},
+		},
 		{
 			Name:        "create-buildpack",
 			Description: "Create a buildpack",
@@ -189,6 +280,28 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("create-user-provided-service", c)
 			},
 		},
+		{
+			Name:        "curl",
+			Description: "Executes a raw request, content-type set to application/json by default",
+			Usage: fmt.Sprintf("%s curl PATH [-X METHOD] [-H HEADER]... [-d DATA] [-i] [--all-pages]\n\n", cf.Name()) +
+				"   By default '" + cf.Name() + " curl' will perform a GET to the specified PATH. If data\n" +
+				"   is provided via -d, a POST will be performed instead. Use -d @FILE to read the\n" +
+				"   request body from a file. Pass -H once for every header. The response is shown\n" +
+				"   whatever its status code.\n\n" +
+				"EXAMPLE:\n" +
+				fmt.Sprintf("   %s curl /v2/apps -X GET -H \"Content-Type: application/x-www-form-urlencoded\" -d 'q=name:myapp'\n", cf.Name()) +
+				fmt.Sprintf("   %s curl /v2/organizations --all-pages", cf.Name()),
+			Flags: []cli.Flag{
+				NewStringFlag("X", "HTTP method (GET,POST,PUT,DELETE,etc)"),
+				NewStringSliceFlag("H", "Custom header to include in the request, can be repeated"),
+				NewStringFlag("d", "HTTP data to include in the request body, or @FILE to read it from a file"),
+				cli.BoolFlag{Name: "i", Usage: "Include response headers in the output"},
+				cli.BoolFlag{Name: "all-pages", Usage: "Follow next_url and combine all pages of resources"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("curl", c)
+			},
+		},
 		{
 			Name:        "delete",
 			ShortName:   "d",
@@ -310,6 +423,23 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("domains", c)
 			},
 		},
+		{
+			Name:        "download-bits",
+			Description: "Download the source bits last uploaded for an app",
+			Usage:       fmt.Sprintf("%s download-bits APP PATH", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("download-bits", c)
+			},
+		},
+		{
+			Name:        "download-droplet",
+			Description: "Download the staged droplet of an app",
+			Usage: fmt.Sprintf("%s download-droplet APP PATH\n\n", cf.Name()) +
+				"   The droplet can be uploaded to an app again with upload-droplet.",
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("download-droplet", c)
+			},
+		},
 		{
 			Name:        "env",
 			ShortName:   "e",
@@ -327,6 +457,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("events", c)
 			},
 		},
+		{
+			Name:        "export-space",
+			Description: "Write the apps, routes and services of a space to a bundle file",
+			Usage: fmt.Sprintf("%s export-space SPACE [-o FILE]\n\n", cf.Name()) +
+				"   The bundle can be loaded into another space with import-space. Without -o it is\n" +
+				"   printed to the terminal. User-provided service credentials are included.",
+			Flags: []cli.Flag{
+				NewStringFlag("o", "File to write the bundle to"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("export-space", c)
+			},
+		},
 		{
 			Name:        "files",
 			ShortName:   "f",
@@ -336,17 +479,68 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("files", c)
 			},
 		},
+		{
+			Name:        "import-space",
+			Description: "Recreate the services, routes and apps of a bundle in the targeted space",
+			Usage: fmt.Sprintf("%s import-space FILE [--skip-existing]\n\n", cf.Name()) +
+				"   Existing apps and user-provided services are updated to match the bundle. Apps with a\n" +
+				"   path (relative to the bundle file) have their bits uploaded; the rest are left without bits.",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "skip-existing", Usage: "Leave apps and services that already exist untouched"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("import-space", c)
+			},
+		},
+		{
+			Name:        "import-users",
+			Description: "Create users and assign org and space roles from a CSV or YAML file",
+			Usage: fmt.Sprintf("%s import-users FILE [--dry-run]\n\n", cf.Name()) +
+				"   Users that already exist are left as they are, so a file can be imported again safely.\n\n" +
+				"EXAMPLE:\n" +
+				"   CSV (one row per user and org or space, roles separated by spaces):\n" +
+				"      username,password,org,space,roles\n" +
+				"      alice,pa55woRD,my-org,,OrgManager\n" +
+				"      alice,,my-org,development,SpaceDeveloper SpaceAuditor\n\n" +
+				"   YAML:\n" +
+				"      users:\n" +
+				"      - username: alice\n" +
+				"        password: pa55woRD\n" +
+				"        orgs:\n" +
+				"        - name: my-org\n" +
+				"          roles: [OrgManager]\n" +
+				"          spaces:\n" +
+				"          - name: development\n" +
+				"            roles: [SpaceDeveloper, SpaceAuditor]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "dry-run", Usage: "Show what would be created and assigned without changing anything"},
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("import-users", c)
+			},
+		},
+		{
+			Name:        "info",
+			Description: "Show the endpoints advertised by the targeted API and CLI version compatibility",
+			Usage: fmt.Sprintf("%s info\n\n", cf.Name()) +
+				"   Advertised endpoints can be overridden with the CF_UAA_ENDPOINT, CF_LOGGREGATOR_ENDPOINT,\n" +
+				"   CF_DOPPLER_ENDPOINT and CF_ROUTING_API_ENDPOINT environment variables.",
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("info", c)
+			},
+		},
 		{
 			Name:        "login",
 			ShortName:   "l",
 			Description: "Log user in",
-			Usage: fmt.Sprintf("%s login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE]\n\n", cf.Name()) +
+			Usage: fmt.Sprintf("%s login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso] [--allow-insecure-http]\n\n", cf.Name()) +
 				terminal.WarningColor("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\n") +
 				"EXAMPLE:\n" +
 				fmt.Sprintf("   %s login (omit username and password to login interactively -- %s will prompt for both)\n", cf.Name(), cf.Name()) +
 				fmt.Sprintf("   %s login -u name@example.com -p pa55woRD (specify username and password as arguments)\n", cf.Name()) +
 				fmt.Sprintf("   %s login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n", cf.Name()) +
-				fmt.Sprintf("   %s login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)", cf.Name()),
+				fmt.Sprintf("   %s login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n", cf.Name()) +
+				fmt.Sprintf("   %s login --sso (log in with a one-time passcode from your identity provider)", cf.Name()),
 			Flags: []cli.Flag{
 				StringFlagWithNoDefault{cli.StringFlag{
 					Name: "a", Usage: "API endpoint (e.g. https://api.example.com)",
@@ -355,6 +549,8 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				NewStringFlag("p", "Password"),
 				NewStringFlag("o", "Org"),
 				NewStringFlag("s", "Space"),
+				cli.BoolFlag{Name: "sso", Usage: "Use a one-time passcode to login"},
+				cli.BoolFlag{Name: "allow-insecure-http", Usage: "Allow an explicit http:// API URL, which is remembered for that target"},
 			},
 			Action: func(c *cli.Context) {
 				cmdRunner.RunCmdByName("login", c)
@@ -408,6 +604,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("map-route", c)
 			},
 		},
+		{
+			Name:        "oauth-token",
+			Description: "Retrieve and display the OAuth token for the current session",
+			Usage:       fmt.Sprintf("%s oauth-token", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("oauth-token", c)
+			},
+		},
 		{
 			Name:        "org",
 			Description: "Show org info",
@@ -416,6 +620,20 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("org", c)
 			},
 		},
+		{
+			Name:        "org-access",
+			Description: "Show every user in an org with their org and space roles",
+			Usage: fmt.Sprintf("%s org-access ORG [--format FORMAT]\n   %s org-access --all-orgs [--format FORMAT]\n\n", cf.Name(), cf.Name()) +
+				"FORMAT:\n" +
+				"   table (default), csv or json",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "all-orgs", Usage: "Report on every org (admin only)"},
+				NewStringFlag("format", "Output format: table, csv or json"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("org-access", c)
+			},
+		},
 		{
 			Name:        "org-users",
 			Description: "Show org users by role",
@@ -447,18 +665,28 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 			ShortName:   "p",
 			Description: "Push a new app or sync changes to an existing app",
 			Usage: fmt.Sprintf("%s push APP [-b URL] [-c COMMAND] [-d DOMAIN] [-i NUM_INSTANCES]\n", cf.Name()) +
-				"               [-m MEMORY] [-n HOST] [-p PATH] [-s STACK]\n" +
-				"               [--no-hostname] [--no-route] [--no-start]",
+				"               [-m MEMORY] [-k DISK] [-n HOST] [-p PATH | --docker-image IMAGE] [-s STACK]\n" +
+				"               [--health-check-type TYPE] [--health-check-http-endpoint PATH]\n" +
+				"               [--route URL[,URL...]] [--random-route] [--prune-routes]\n" +
+				"               [--max-in-flight NUM] [--no-hostname] [--no-route] [--no-start]",
 			Flags: []cli.Flag{
 				NewStringFlag("b", "Custom buildpack URL (e.g. https://github.com/heroku/heroku-buildpack-play.git)"),
 				NewStringFlag("c", "Startup command, set to null to reset to default start command"),
 				NewStringFlag("d", "Domain (e.g. example.com)"),
 				NewStringFlag("i", "Number of instances"),
+				NewStringFlag("k", "Disk limit (e.g. 256M, 1024M, 1G)"),
 				NewStringFlag("m", "Memory limit (e.g. 256M, 1024M, 1G)"),
 				NewStringFlag("n", "Hostname (e.g. my-subdomain)"),
 				NewStringFlag("p", "Path of app directory or zip file"),
 				NewStringFlag("s", "Stack to use"),
 				NewStringFlag("t", "Start timeout in seconds"),
+				NewStringFlag("docker-image", "Docker image to run instead of uploading app files (e.g. repo/name:tag)"),
+				NewStringFlag("health-check-type", "Health check type: port, http or none"),
+				NewStringFlag("health-check-http-endpoint", "Path checked by the http health check (e.g. /health)"),
+				NewStringFlag("route", "Comma separated routes to bind, each a host and domain with an optional path (e.g. www.example.com/blog)"),
+				cli.BoolFlag{Name: "random-route", Usage: "Bind the app to a hostname made of the app name and random words"},
+				cli.BoolFlag{Name: "prune-routes", Usage: "Unbind routes of the app that are not in the manifest or flags"},
+				NewIntFlagWithValue("max-in-flight", "Number of manifest apps pushed at the same time", 1),
 				cli.BoolFlag{Name: "no-hostname", Usage: "Map the root domain to this app"},
 				cli.BoolFlag{Name: "no-route", Usage: "Do not map a route to this app"},
 				cli.BoolFlag{Name: "no-start", Usage: "Do not start an app after pushing"},
@@ -475,6 +703,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("quotas", c)
 			},
 		},
+		{
+			Name:        "remove-user-from-org",
+			Description: "Remove every org and space role of a user in an org, and the org membership itself",
+			Usage:       fmt.Sprintf("%s remove-user-from-org USERNAME ORG", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("remove-user-from-org", c)
+			},
+		},
 		{
 			Name:        "rename",
 			Description: "Rename an app",
@@ -515,6 +751,19 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("rename-space", c)
 			},
 		},
+		{
+			Name:        "restage",
+			ShortName:   "rg",
+			Description: "Restage an app",
+			Usage:       fmt.Sprintf("%s restage APP [--staging-timeout MINUTES] [--startup-timeout MINUTES]", cf.Name()),
+			Flags: []cli.Flag{
+				NewIntFlag("staging-timeout", "Max wait time for buildpack staging, in minutes"),
+				NewIntFlag("startup-timeout", "Max wait time for app instance startup, in minutes"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("restage", c)
+			},
+		},
 		{
 			Name:        "restart",
 			ShortName:   "rs",
@@ -524,6 +773,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("restart", c)
 			},
 		},
+		{
+			Name:        "restart-app-instance",
+			Description: "Terminate a single instance of an app and wait for its replacement to run",
+			Usage:       fmt.Sprintf("%s restart-app-instance APP INDEX", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("restart-app-instance", c)
+			},
+		},
 		{
 			Name:        "routes",
 			ShortName:   "r",
@@ -535,10 +792,11 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 		},
 		{
 			Name:        "scale",
-			Description: "Change the instance count and memory limit for an app",
-			Usage:       fmt.Sprintf("%s scale APP -i INSTANCES -m MEMORY", cf.Name()),
+			Description: "Change the instance count, memory and disk limits for an app",
+			Usage:       fmt.Sprintf("%s scale APP [-i INSTANCES] [-m MEMORY] [-k DISK]", cf.Name()),
 			Flags: []cli.Flag{
 				NewIntFlagWithValue("i", "number of instances", -1),
+				NewStringFlag("k", "disk limit (e.g. 256M, 1024M, 1G)"),
 				NewStringFlag("m", "memory limit (e.g. 256M, 1024M, 1G)"),
 			},
 			Action: func(c *cli.Context) {
@@ -692,6 +950,14 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("target", c)
 			},
 		},
+		{
+			Name:        "token-info",
+			Description: "Show the user, client, scopes and expiry of the current access token",
+			Usage:       fmt.Sprintf("%s token-info", cf.Name()),
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("token-info", c)
+			},
+		},
 		{
 			Name:        "unbind-service",
 			ShortName:   "us",
@@ -788,6 +1054,28 @@ func NewApp(cmdRunner commands.Runner) (app *cli.App, err error) {
 				cmdRunner.RunCmdByName("update-user-provided-service", c)
 			},
 		},
+		{
+			Name:        "upload-droplet",
+			Description: "Replace the droplet of an app with one saved by download-droplet",
+			Usage: fmt.Sprintf("%s upload-droplet APP PATH\n\n", cf.Name()) +
+				"   A started app is restarted so it runs the uploaded droplet, which makes this a quick rollback.",
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("upload-droplet", c)
+			},
+		},
+		{
+			Name:        "user-roles",
+			Description: "Show the org and space roles of a user",
+			Usage: fmt.Sprintf("%s user-roles USERNAME [--format FORMAT]\n\n", cf.Name()) +
+				"FORMAT:\n" +
+				"   table (default), csv or json",
+			Flags: []cli.Flag{
+				NewStringFlag("format", "Output format: table, csv or json"),
+			},
+			Action: func(c *cli.Context) {
+				cmdRunner.RunCmdByName("user-roles", c)
+			},
+		},
 	}
 	return
 }
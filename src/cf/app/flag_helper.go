@@ -18,6 +18,11 @@ func NewStringFlag(name, usage string) StringFlagWithNoDefault {
 	return StringFlagWithNoDefault{cli.StringFlag{Name: name, Usage: usage}}
 }
 
+// NewStringSliceFlag collects every value of a flag that is passed several times.
+func NewStringSliceFlag(name, usage string) cli.StringSliceFlag {
+	return cli.StringSliceFlag{Name: name, Value: &cli.StringSlice{}, Usage: usage}
+}
+
 type IntFlagWithNoDefault struct {
 	cli.IntFlag
 }
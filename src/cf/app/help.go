@@ -27,8 +27,14 @@ var appHelpTemplate = `{{.Title "NAME:"}}
 {{.Title "ENVIRONMENT VARIABLES:"}}
    CF_STAGING_TIMEOUT=15 max wait time for buildpack staging, in minutes
    CF_STARTUP_TIMEOUT=5 max wait time for app instance startup, in minutes
+   CF_CREDENTIALS_KEY=passphrase - encrypt stored tokens with a passphrase
+   CF_CREDENTIALS_KEY_FILE=path/to/key - encrypt stored tokens with the contents of a key file
+   CF_DOPPLER_ENDPOINT=wss://doppler.example.com:4443 - override the advertised doppler endpoint
+   CF_LOGGREGATOR_ENDPOINT=wss://loggregator.example.com:4443 - override the advertised loggregator endpoint
+   CF_ROUTING_API_ENDPOINT=https://api.example.com/routing - override the advertised routing API endpoint
    CF_TRACE=true - print API request diagnostics to stdout
    CF_TRACE=path/to/trace.log - append API request diagnostics to a log file
+   CF_UAA_ENDPOINT=https://uaa.example.com - override the advertised UAA endpoint
    HTTP_PROXY=http://proxy.example.com:8080 - enable http proxying for API requests
 `
 
@@ -106,6 +112,10 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				}, {
 					newCmdPresenter(app, maxNameLen, "api"),
 					newCmdPresenter(app, maxNameLen, "auth"),
+					newCmdPresenter(app, maxNameLen, "info"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "oauth-token"),
+					newCmdPresenter(app, maxNameLen, "token-info"),
 				},
 			},
 		}, {
@@ -117,16 +127,24 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				}, {
 					newCmdPresenter(app, maxNameLen, "push"),
 					newCmdPresenter(app, maxNameLen, "scale"),
+					newCmdPresenter(app, maxNameLen, "autoscale"),
 					newCmdPresenter(app, maxNameLen, "delete"),
 					newCmdPresenter(app, maxNameLen, "rename"),
+					newCmdPresenter(app, maxNameLen, "copy-source"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "start"),
 					newCmdPresenter(app, maxNameLen, "stop"),
 					newCmdPresenter(app, maxNameLen, "restart"),
+					newCmdPresenter(app, maxNameLen, "restage"),
+					newCmdPresenter(app, maxNameLen, "restart-app-instance"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "events"),
 					newCmdPresenter(app, maxNameLen, "files"),
 					newCmdPresenter(app, maxNameLen, "logs"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "download-bits"),
+					newCmdPresenter(app, maxNameLen, "download-droplet"),
+					newCmdPresenter(app, maxNameLen, "upload-droplet"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "env"),
 					newCmdPresenter(app, maxNameLen, "set-env"),
@@ -176,6 +194,9 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 					newCmdPresenter(app, maxNameLen, "create-space"),
 					newCmdPresenter(app, maxNameLen, "delete-space"),
 					newCmdPresenter(app, maxNameLen, "rename-space"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "export-space"),
+					newCmdPresenter(app, maxNameLen, "import-space"),
 				},
 			},
 		}, {
@@ -217,14 +238,19 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				{
 					newCmdPresenter(app, maxNameLen, "create-user"),
 					newCmdPresenter(app, maxNameLen, "delete-user"),
+					newCmdPresenter(app, maxNameLen, "import-users"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "org-users"),
 					newCmdPresenter(app, maxNameLen, "set-org-role"),
 					newCmdPresenter(app, maxNameLen, "unset-org-role"),
+					newCmdPresenter(app, maxNameLen, "remove-user-from-org"),
 				}, {
 					newCmdPresenter(app, maxNameLen, "space-users"),
 					newCmdPresenter(app, maxNameLen, "set-space-role"),
 					newCmdPresenter(app, maxNameLen, "unset-space-role"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "org-access"),
+					newCmdPresenter(app, maxNameLen, "user-roles"),
 				},
 			},
 		}, {
@@ -233,6 +259,8 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				{
 					newCmdPresenter(app, maxNameLen, "quotas"),
 					newCmdPresenter(app, maxNameLen, "set-quota"),
+				}, {
+					newCmdPresenter(app, maxNameLen, "apply-org-config"),
 				},
 			},
 		}, {
@@ -252,6 +280,14 @@ func newAppPresenter(app *cli.App) (presenter appPresenter) {
 				},
 			},
 		},
+		{
+			Name: "ADVANCED",
+			CommandSubGroups: [][]cmdPresenter{
+				{
+					newCmdPresenter(app, maxNameLen, "curl"),
+				},
+			},
+		},
 	}
 	return
 }
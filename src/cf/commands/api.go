@@ -16,7 +16,7 @@ type Api struct {
 }
 
 type ApiEndpointSetter interface {
-	SetApiEndpoint(endpoint string)
+	SetApiEndpoint(endpoint string, allowInsecureHttp bool)
 }
 
 func NewApi(ui terminal.UI, config *configuration.Configuration, endpointRepo api.EndpointRepository) (cmd Api) {
@@ -40,17 +40,17 @@ func (cmd Api) Run(c *cli.Context) {
 		return
 	}
 
-	cmd.SetApiEndpoint(c.Args()[0])
+	cmd.SetApiEndpoint(c.Args()[0], c.Bool("allow-insecure-http"))
 }
 
-func (cmd Api) SetApiEndpoint(endpoint string) {
+func (cmd Api) SetApiEndpoint(endpoint string, allowInsecureHttp bool) {
 	if strings.HasSuffix(endpoint, "/") {
 		endpoint = strings.TrimSuffix(endpoint, "/")
 	}
 
 	cmd.ui.Say("Setting api endpoint to %s...", terminal.EntityNameColor(endpoint))
 
-	endpoint, apiResponse := cmd.endpointRepo.UpdateEndpoint(endpoint)
+	endpoint, apiResponse := cmd.endpointRepo.UpdateEndpoint(endpoint, allowInsecureHttp)
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
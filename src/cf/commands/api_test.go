@@ -53,6 +53,29 @@ func TestApiWithTrailingSlash(t *testing.T) {
 	})
 }
 
+func TestApiWithAllowInsecureHttp(t *testing.T) {
+	endpointRepo := &testapi.FakeEndpointRepo{}
+	config := &configuration.Configuration{}
+
+	callApi([]string{"--allow-insecure-http", "http://example.com"}, config, endpointRepo)
+	assert.True(t, endpointRepo.UpdateEndpointAllowInsecureHttp)
+
+	callApi([]string{"https://example.com"}, config, endpointRepo)
+	assert.False(t, endpointRepo.UpdateEndpointAllowInsecureHttp)
+}
+
+func TestApiWhenTheEndpointCannotBeSet(t *testing.T) {
+	endpointRepo := &testapi.FakeEndpointRepo{UpdateEndpointError: true}
+	config := &configuration.Configuration{}
+
+	ui := callApi([]string{"example.com"}, config, endpointRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Server error"},
+	})
+}
+
 func callApi(args []string, config *configuration.Configuration, endpointRepo *testapi.FakeEndpointRepo) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 
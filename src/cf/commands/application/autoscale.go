@@ -0,0 +1,291 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"os"
+	"os/signal"
+	"time"
+)
+
+type Autoscale struct {
+	ui               terminal.UI
+	config           *configuration.Configuration
+	appRepo          api.ApplicationRepository
+	appInstancesRepo api.AppInstancesRepository
+	orgRepo          api.OrganizationRepository
+	appReq           requirements.ApplicationRequirement
+}
+
+func NewAutoscale(ui terminal.UI, config *configuration.Configuration, appRepo api.ApplicationRepository, appInstancesRepo api.AppInstancesRepository, orgRepo api.OrganizationRepository) (cmd *Autoscale) {
+	cmd = new(Autoscale)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appRepo = appRepo
+	cmd.appInstancesRepo = appInstancesRepo
+	cmd.orgRepo = orgRepo
+	return
+}
+
+func (cmd *Autoscale) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 || c.Int("count") < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "autoscale")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *Autoscale) Run(c *cli.Context) {
+	app := cmd.appReq.GetApplication()
+
+	policy, err := cmd.policyFromContext(c)
+	if err != nil {
+		cmd.ui.Failed("Invalid autoscaling policy\n%s", err.Error())
+		return
+	}
+
+	var source instanceStatsSource = cmd.appInstancesRepo
+	count := c.Int("count")
+	simulating := c.String("simulate") != ""
+
+	if simulating {
+		simulation, err := newSimulatedStatsSource(c.String("simulate"))
+		if err != nil {
+			cmd.ui.Failed("Error reading samples from %s\n%s", c.String("simulate"), err.Error())
+			return
+		}
+		if simulation.remaining() == 0 {
+			cmd.ui.Failed("No samples found in %s", c.String("simulate"))
+			return
+		}
+		if count == 0 || count > simulation.remaining() {
+			count = simulation.remaining()
+		}
+		source = simulation
+
+		cmd.ui.Say("Simulating autoscaling of app %s with samples from %s in org %s / space %s as %s...",
+			terminal.EntityNameColor(app.Name),
+			terminal.EntityNameColor(c.String("simulate")),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+	} else {
+		cmd.ui.Say("Autoscaling app %s in org %s / space %s as %s...",
+			terminal.EntityNameColor(app.Name),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+	}
+
+	cmd.ui.Say("%s %d to %d instances, %s, checked every %s with a %s cooldown\n",
+		terminal.HeaderColor("policy:"),
+		policy.Min, policy.Max, describeAutoscaleThresholds(policy), policy.Interval, policy.Cooldown)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	scaler := &autoscaler{policy: policy}
+	current := app.InstanceCount
+	scalings := 0
+	started := time.Now()
+
+	for poll := 1; count == 0 || poll <= count; poll++ {
+		// simulated samples are spaced by the policy interval so the cooldown
+		// plays out as it would have
+		now := time.Now()
+		if simulating {
+			now = started.Add(time.Duration(poll-1) * policy.Interval)
+		}
+
+		// the instance count can change outside of autoscale, so it is read
+		// again before every live decision
+		sampled := true
+		if !simulating {
+			latest, apiResponse := cmd.appRepo.Read(app.Name)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Warn("[%s] could not get app: %s", now.Format("03:04:05 PM"), apiResponse.Message)
+				sampled = false
+			} else {
+				app = latest
+				current = app.InstanceCount
+			}
+		}
+
+		var instances []cf.AppInstanceFields
+		if sampled {
+			var apiResponse net.ApiResponse
+			instances, apiResponse = source.GetInstances(app.Guid)
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Warn("[%s] could not get instances: %s", now.Format("03:04:05 PM"), apiResponse.Message)
+				sampled = false
+			}
+		}
+
+		if sampled {
+			decision := scaler.decide(now, current, app.Memory, instances)
+			if decision.Desired > decision.Current && !simulating {
+				decision = cmd.limitToOrgQuota(app, decision)
+			}
+
+			cmd.logDecision(now, decision)
+
+			if decision.scales() && cmd.scale(app, decision, simulating) {
+				current = decision.Desired
+				scaler.scaled(now)
+				scalings++
+			}
+		}
+
+		if poll == count {
+			break
+		}
+		if simulating {
+			continue
+		}
+		if cmd.waitForNextPoll(interrupt, policy.Interval) {
+			break
+		}
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Ok()
+	cmd.ui.Say("Scaled %d times, app %s has %d instances", scalings, terminal.EntityNameColor(app.Name), current)
+}
+
+// waitForNextPoll waits for the interval and reports whether autoscaling was
+// interrupted in the meantime.
+func (cmd *Autoscale) waitForNextPoll(interrupt chan os.Signal, interval time.Duration) (interrupted bool) {
+	select {
+	case <-interrupt:
+		interrupted = true
+	case <-time.After(interval):
+	}
+	return
+}
+
+func (cmd *Autoscale) policyFromContext(c *cli.Context) (policy autoscalePolicy, err error) {
+	policy = newAutoscalePolicy()
+	if c.String("policy") != "" {
+		policy, err = parseAutoscalePolicyFile(c.String("policy"))
+		if err != nil {
+			return
+		}
+	}
+
+	if c.Int("min") > 0 {
+		policy.Min = c.Int("min")
+	}
+	if c.Int("max") > 0 {
+		policy.Max = c.Int("max")
+	}
+	if c.Int("cpu") > 0 {
+		policy.Cpu = float64(c.Int("cpu"))
+	}
+	if c.Int("mem") > 0 {
+		policy.Mem = float64(c.Int("mem"))
+	}
+	if c.String("interval") != "" {
+		policy.Interval, err = time.ParseDuration(c.String("interval"))
+		if err != nil {
+			err = fmt.Errorf("Invalid interval %s", c.String("interval"))
+			return
+		}
+	}
+	if c.String("cooldown") != "" {
+		policy.Cooldown, err = time.ParseDuration(c.String("cooldown"))
+		if err != nil {
+			err = fmt.Errorf("Invalid cooldown %s", c.String("cooldown"))
+			return
+		}
+	}
+
+	err = policy.validate()
+	return
+}
+
+// limitToOrgQuota lowers a scale up to the instances that still fit in the
+// memory left in the org quota.
+func (cmd *Autoscale) limitToOrgQuota(app cf.Application, decision autoscaleDecision) autoscaleDecision {
+	if app.Memory == 0 {
+		return decision
+	}
+
+	available, limited := orgMemoryAvailable(cmd.orgRepo, cmd.config)
+	if !limited {
+		return decision
+	}
+
+	fits := decision.Current + int(available/app.Memory)
+	if fits >= decision.Desired {
+		return decision
+	}
+
+	if fits <= decision.Current {
+		decision.Reason = decision.Reason + ", but the org quota is used up"
+	} else {
+		decision.Reason = fmt.Sprintf("%s, limited to %d by the org quota", decision.Reason, fits)
+	}
+	decision.Desired = maxInt(fits, decision.Current)
+	return decision
+}
+
+func (cmd *Autoscale) logDecision(now time.Time, decision autoscaleDecision) {
+	action := "keeping"
+	if decision.Desired > decision.Current {
+		action = fmt.Sprintf("scaling up to %d", decision.Desired)
+	} else if decision.Desired < decision.Current {
+		action = fmt.Sprintf("scaling down to %d", decision.Desired)
+	}
+
+	cmd.ui.Say("[%s] %d instances, cpu %.1f%%, memory %.1f%%: %s (%s)",
+		now.Format("03:04:05 PM"), decision.Current, decision.Cpu, decision.Mem, action, decision.Reason)
+}
+
+func (cmd *Autoscale) scale(app cf.Application, decision autoscaleDecision, simulating bool) bool {
+	if simulating {
+		return true
+	}
+
+	params := cf.NewEmptyAppParams()
+	params.Set("instances", decision.Desired)
+
+	_, apiResponse := cmd.appRepo.Update(app.Guid, params)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Warn("Error scaling app %s\n%s", app.Name, apiResponse.Message)
+		return false
+	}
+	return true
+}
+
+func describeAutoscaleThresholds(policy autoscalePolicy) string {
+	thresholds := ""
+	if policy.Cpu > 0 {
+		thresholds = fmt.Sprintf("cpu %.0f%%", policy.Cpu)
+	}
+	if policy.Mem > 0 {
+		if thresholds != "" {
+			thresholds = thresholds + " / "
+		}
+		thresholds = thresholds + fmt.Sprintf("memory %.0f%%", policy.Mem)
+	}
+	return fmt.Sprintf("scale up above %s, down %.0f points below", thresholds, policy.ScaleDownMargin)
+}
@@ -0,0 +1,265 @@
+package application
+
+import (
+	"cf"
+	"cf/formatters"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"launchpad.net/goyaml"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAutoscaleInterval        = 30 * time.Second
+	DefaultAutoscaleCooldown        = 5 * time.Minute
+	DefaultAutoscaleScaleDownMargin = 20
+)
+
+// autoscalePolicy scales an app up by Step instances when its average cpu or
+// memory use goes above Cpu or Mem percent, and down by Step once both are
+// ScaleDownMargin points below those thresholds. A threshold of 0 is ignored.
+// No decision but returning to the Min/Max bounds is taken within Cooldown
+// of the last scaling.
+type autoscalePolicy struct {
+	Min             int
+	Max             int
+	Cpu             float64
+	Mem             float64
+	ScaleDownMargin float64
+	Step            int
+	Interval        time.Duration
+	Cooldown        time.Duration
+}
+
+// autoscalePolicyDocument is the YAML form of autoscalePolicy, with durations
+// written like 30s or 5m.
+type autoscalePolicyDocument struct {
+	Min             int     `yaml:"min"`
+	Max             int     `yaml:"max"`
+	Cpu             float64 `yaml:"cpu"`
+	Mem             float64 `yaml:"mem"`
+	ScaleDownMargin float64 `yaml:"scale_down_margin"`
+	Step            int     `yaml:"step"`
+	Interval        string  `yaml:"interval"`
+	Cooldown        string  `yaml:"cooldown"`
+}
+
+func newAutoscalePolicy() autoscalePolicy {
+	return autoscalePolicy{
+		ScaleDownMargin: DefaultAutoscaleScaleDownMargin,
+		Step:            1,
+		Interval:        DefaultAutoscaleInterval,
+		Cooldown:        DefaultAutoscaleCooldown,
+	}
+}
+
+func parseAutoscalePolicyFile(path string) (policy autoscalePolicy, err error) {
+	policy = newAutoscalePolicy()
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	document := autoscalePolicyDocument{}
+	err = goyaml.Unmarshal(contents, &document)
+	if err != nil {
+		return
+	}
+
+	policy.Min = document.Min
+	policy.Max = document.Max
+	policy.Cpu = document.Cpu
+	policy.Mem = document.Mem
+	if document.ScaleDownMargin != 0 {
+		policy.ScaleDownMargin = document.ScaleDownMargin
+	}
+	if document.Step != 0 {
+		policy.Step = document.Step
+	}
+	if document.Interval != "" {
+		policy.Interval, err = time.ParseDuration(document.Interval)
+		if err != nil {
+			err = fmt.Errorf("Invalid interval %s", document.Interval)
+			return
+		}
+	}
+	if document.Cooldown != "" {
+		policy.Cooldown, err = time.ParseDuration(document.Cooldown)
+		if err != nil {
+			err = fmt.Errorf("Invalid cooldown %s", document.Cooldown)
+			return
+		}
+	}
+	return
+}
+
+func (policy autoscalePolicy) validate() (err error) {
+	problems := []string{}
+	addProblem := func(message string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(message, args...))
+	}
+
+	if policy.Min < 1 {
+		addProblem("Minimum instances must be at least 1")
+	}
+	if policy.Max < policy.Min {
+		addProblem("Maximum instances must not be below the minimum")
+	}
+	if policy.Cpu <= 0 && policy.Mem <= 0 {
+		addProblem("A cpu or memory threshold is required")
+	}
+	if policy.Cpu < 0 || policy.Cpu > 100 || policy.Mem < 0 || policy.Mem > 100 {
+		addProblem("Thresholds must be percentages between 0 and 100")
+	}
+	if policy.ScaleDownMargin < 0 {
+		addProblem("Scale down margin must not be negative")
+	}
+	if policy.Step < 1 {
+		addProblem("Step must be at least 1")
+	}
+	if policy.Interval <= 0 {
+		addProblem("Interval must be positive")
+	}
+	if policy.Cooldown < 0 {
+		addProblem("Cooldown must not be negative")
+	}
+
+	if len(problems) > 0 {
+		err = errors.New(strings.Join(problems, "\n"))
+	}
+	return
+}
+
+type autoscaleDecision struct {
+	Current int
+	Desired int
+	Cpu     float64
+	Mem     float64
+	Reason  string
+}
+
+func (decision autoscaleDecision) scales() bool {
+	return decision.Desired != decision.Current
+}
+
+// autoscaler applies a policy to successive instance samples, remembering
+// when it last scaled for the cooldown.
+type autoscaler struct {
+	policy     autoscalePolicy
+	lastScaled time.Time
+}
+
+// decide picks an instance count for an app currently running current
+// instances. Memory use is relative to each instance's quota, or to
+// memoryLimit megabytes when an instance reports none.
+func (scaler *autoscaler) decide(now time.Time, current int, memoryLimit uint64, instances []cf.AppInstanceFields) (decision autoscaleDecision) {
+	policy := scaler.policy
+	decision.Current = current
+	decision.Desired = current
+
+	var cpuSum, memSum float64
+	running := 0
+	for _, instance := range instances {
+		if instance.State != cf.InstanceRunning {
+			continue
+		}
+
+		memQuota := instance.MemQuota
+		if memQuota == 0 {
+			memQuota = memoryLimit * formatters.MEGABYTE
+		}
+
+		running++
+		cpuSum += instance.CpuUsage * 100
+		if memQuota > 0 {
+			memSum += float64(instance.MemUsage) / float64(memQuota) * 100
+		}
+	}
+
+	if running > 0 {
+		decision.Cpu = cpuSum / float64(running)
+		decision.Mem = memSum / float64(running)
+	}
+
+	switch {
+	case current < policy.Min:
+		decision.Desired = policy.Min
+		decision.Reason = "below minimum"
+	case current > policy.Max:
+		decision.Desired = policy.Max
+		decision.Reason = "above maximum"
+	case running == 0:
+		decision.Reason = "no running instances"
+	case !scaler.lastScaled.IsZero() && now.Sub(scaler.lastScaled) < policy.Cooldown:
+		decision.Reason = fmt.Sprintf("cooling down until %s", scaler.lastScaled.Add(policy.Cooldown).Format("03:04:05 PM"))
+	case scaler.aboveThresholds(decision):
+		decision.Desired = minInt(current+policy.Step, policy.Max)
+		decision.Reason = scaler.describeThresholds(decision, "above")
+		if !decision.scales() {
+			decision.Reason = decision.Reason + ", at maximum"
+		}
+	case scaler.belowThresholds(decision):
+		decision.Desired = maxInt(current-policy.Step, policy.Min)
+		decision.Reason = scaler.describeThresholds(decision, "below")
+		if !decision.scales() {
+			decision.Reason = decision.Reason + ", at minimum"
+		}
+	default:
+		decision.Reason = "within thresholds"
+	}
+	return
+}
+
+func (scaler *autoscaler) scaled(now time.Time) {
+	scaler.lastScaled = now
+}
+
+func (scaler *autoscaler) aboveThresholds(decision autoscaleDecision) bool {
+	policy := scaler.policy
+	return (policy.Cpu > 0 && decision.Cpu > policy.Cpu) ||
+		(policy.Mem > 0 && decision.Mem > policy.Mem)
+}
+
+func (scaler *autoscaler) belowThresholds(decision autoscaleDecision) bool {
+	policy := scaler.policy
+	return (policy.Cpu <= 0 || decision.Cpu < policy.Cpu-policy.ScaleDownMargin) &&
+		(policy.Mem <= 0 || decision.Mem < policy.Mem-policy.ScaleDownMargin)
+}
+
+func (scaler *autoscaler) describeThresholds(decision autoscaleDecision, direction string) string {
+	policy := scaler.policy
+	reasons := []string{}
+	if direction == "above" {
+		if policy.Cpu > 0 && decision.Cpu > policy.Cpu {
+			reasons = append(reasons, fmt.Sprintf("cpu above %.0f%%", policy.Cpu))
+		}
+		if policy.Mem > 0 && decision.Mem > policy.Mem {
+			reasons = append(reasons, fmt.Sprintf("memory above %.0f%%", policy.Mem))
+		}
+	} else {
+		if policy.Cpu > 0 {
+			reasons = append(reasons, fmt.Sprintf("cpu below %.0f%%", policy.Cpu-policy.ScaleDownMargin))
+		}
+		if policy.Mem > 0 {
+			reasons = append(reasons, fmt.Sprintf("memory below %.0f%%", policy.Mem-policy.ScaleDownMargin))
+		}
+	}
+	return strings.Join(reasons, " and ")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"cf"
+	"cf/formatters"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func autoscaleInstances(cpu float64, memPercent uint64, count int) (instances []cf.AppInstanceFields) {
+	for i := 0; i < count; i++ {
+		instance := cf.AppInstanceFields{}
+		instance.State = cf.InstanceRunning
+		instance.CpuUsage = cpu / 100
+		instance.MemQuota = 100 * formatters.MEGABYTE
+		instance.MemUsage = memPercent * formatters.MEGABYTE
+		instances = append(instances, instance)
+	}
+	return
+}
+
+func testAutoscalePolicy() autoscalePolicy {
+	policy := newAutoscalePolicy()
+	policy.Min = 2
+	policy.Max = 4
+	policy.Cpu = 70
+	policy.Mem = 80
+	return policy
+}
+
+func TestAutoscalerScalesUpAboveAThreshold(t *testing.T) {
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+
+	decision := scaler.decide(time.Now(), 2, 0, autoscaleInstances(85, 40, 2))
+	assert.Equal(t, decision.Desired, 3)
+	assert.Equal(t, decision.Cpu, 85.0)
+	assert.Equal(t, decision.Mem, 40.0)
+	assert.Equal(t, decision.Reason, "cpu above 70%")
+
+	decision = scaler.decide(time.Now(), 2, 0, autoscaleInstances(10, 90, 2))
+	assert.Equal(t, decision.Desired, 3)
+	assert.Equal(t, decision.Reason, "memory above 80%")
+
+	decision = scaler.decide(time.Now(), 4, 0, autoscaleInstances(85, 40, 4))
+	assert.Equal(t, decision.Desired, 4)
+	assert.Equal(t, decision.Reason, "cpu above 70%, at maximum")
+}
+
+func TestAutoscalerOnlyScalesDownWellBelowTheThresholds(t *testing.T) {
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+
+	decision := scaler.decide(time.Now(), 3, 0, autoscaleInstances(60, 40, 3))
+	assert.Equal(t, decision.Desired, 3)
+	assert.Equal(t, decision.Reason, "within thresholds")
+
+	decision = scaler.decide(time.Now(), 3, 0, autoscaleInstances(40, 70, 3))
+	assert.Equal(t, decision.Desired, 3)
+	assert.Equal(t, decision.Reason, "within thresholds")
+
+	decision = scaler.decide(time.Now(), 3, 0, autoscaleInstances(40, 50, 3))
+	assert.Equal(t, decision.Desired, 2)
+	assert.Equal(t, decision.Reason, "cpu below 50% and memory below 60%")
+
+	decision = scaler.decide(time.Now(), 2, 0, autoscaleInstances(40, 50, 2))
+	assert.Equal(t, decision.Desired, 2)
+	assert.Equal(t, decision.Reason, "cpu below 50% and memory below 60%, at minimum")
+}
+
+func TestAutoscalerWaitsForTheCooldown(t *testing.T) {
+	now := time.Now()
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+	scaler.scaled(now)
+
+	decision := scaler.decide(now.Add(time.Minute), 3, 0, autoscaleInstances(85, 40, 3))
+	assert.Equal(t, decision.Desired, 3)
+	assert.Contains(t, decision.Reason, "cooling down until")
+
+	decision = scaler.decide(now.Add(6*time.Minute), 3, 0, autoscaleInstances(85, 40, 3))
+	assert.Equal(t, decision.Desired, 4)
+}
+
+func TestAutoscalerKeepsToTheLimitsDuringTheCooldown(t *testing.T) {
+	now := time.Now()
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+	scaler.scaled(now)
+
+	decision := scaler.decide(now, 1, 0, autoscaleInstances(10, 10, 1))
+	assert.Equal(t, decision.Desired, 2)
+	assert.Equal(t, decision.Reason, "below minimum")
+
+	decision = scaler.decide(now, 6, 0, autoscaleInstances(90, 90, 6))
+	assert.Equal(t, decision.Desired, 4)
+	assert.Equal(t, decision.Reason, "above maximum")
+}
+
+func TestAutoscalerIgnoresInstancesThatAreNotRunning(t *testing.T) {
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+
+	instances := autoscaleInstances(90, 90, 2)
+	instances[0].State = cf.InstanceDown
+	instances[1].State = cf.InstanceStarting
+
+	decision := scaler.decide(time.Now(), 2, 0, instances)
+	assert.Equal(t, decision.Desired, 2)
+	assert.Equal(t, decision.Reason, "no running instances")
+}
+
+func TestAutoscalerUsesTheAppMemoryWhenInstancesReportNoQuota(t *testing.T) {
+	scaler := &autoscaler{policy: testAutoscalePolicy()}
+
+	instances := autoscaleInstances(10, 0, 2)
+	for index := range instances {
+		instances[index].MemQuota = 0
+		instances[index].MemUsage = 230 * formatters.MEGABYTE
+	}
+
+	decision := scaler.decide(time.Now(), 2, 256, instances)
+	assert.Equal(t, decision.Desired, 3)
+	assert.Equal(t, decision.Reason, "memory above 80%")
+}
+
+func TestParseAutoscalePolicyFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "policy")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	file.WriteString(`
+min: 2
+max: 10
+cpu: 70
+scale_down_margin: 15
+interval: 1m
+cooldown: 10m
+`)
+	file.Close()
+
+	policy, err := parseAutoscalePolicyFile(file.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, policy.Min, 2)
+	assert.Equal(t, policy.Max, 10)
+	assert.Equal(t, policy.Cpu, 70.0)
+	assert.Equal(t, policy.Mem, 0.0)
+	assert.Equal(t, policy.ScaleDownMargin, 15.0)
+	assert.Equal(t, policy.Step, 1)
+	assert.Equal(t, policy.Interval, time.Minute)
+	assert.Equal(t, policy.Cooldown, 10*time.Minute)
+	assert.NoError(t, policy.validate())
+}
+
+func TestAutoscalePolicyValidation(t *testing.T) {
+	policy := newAutoscalePolicy()
+	policy.Min = 3
+	policy.Max = 2
+	policy.Cpu = 120
+
+	err := policy.validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Maximum instances must not be below the minimum")
+	assert.Contains(t, err.Error(), "Thresholds must be percentages between 0 and 100")
+
+	policy = newAutoscalePolicy()
+	policy.Min = 1
+	policy.Max = 2
+	err = policy.validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "A cpu or memory threshold is required")
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"cf"
+	"cf/net"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type instanceStatsSource interface {
+	GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse)
+}
+
+// simulatedStatsSource replays samples recorded with app --csv, one sample
+// per call to GetInstances, so a policy can be tried against past traffic.
+type simulatedStatsSource struct {
+	samples [][]cf.AppInstanceFields
+}
+
+func newSimulatedStatsSource(path string) (source *simulatedStatsSource, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return
+	}
+
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(instanceSampleCsvHeader, ",") {
+		err = fmt.Errorf("Expected a CSV file starting with %s", strings.Join(instanceSampleCsvHeader, ","))
+		return
+	}
+
+	source = new(simulatedStatsSource)
+	lastTaken := ""
+	for line, record := range records[1:] {
+		if record[0] != lastTaken {
+			lastTaken = record[0]
+			source.samples = append(source.samples, []cf.AppInstanceFields{})
+		}
+
+		var index int
+		var instance cf.AppInstanceFields
+		index, instance, err = parseInstanceSampleRecord(record)
+		if err != nil {
+			err = fmt.Errorf("Line %d: %s", line+2, err.Error())
+			return
+		}
+
+		sample := source.samples[len(source.samples)-1]
+		for len(sample) <= index {
+			sample = append(sample, cf.AppInstanceFields{State: cf.InstanceDown})
+		}
+		sample[index] = instance
+		source.samples[len(source.samples)-1] = sample
+	}
+	return
+}
+
+func parseInstanceSampleRecord(record []string) (index int, instance cf.AppInstanceFields, err error) {
+	index, err = strconv.Atoi(record[1])
+	if err != nil || index < 0 {
+		err = fmt.Errorf("invalid instance %s", record[1])
+		return
+	}
+
+	instance.State = cf.InstanceState(record[2])
+
+	cpu, err := strconv.ParseFloat(record[3], 64)
+	if err != nil {
+		err = fmt.Errorf("invalid cpu %s", record[3])
+		return
+	}
+	instance.CpuUsage = cpu / 100
+
+	instance.MemUsage, err = strconv.ParseUint(record[4], 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid memory %s", record[4])
+		return
+	}
+
+	instance.DiskUsage, err = strconv.ParseUint(record[5], 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid disk %s", record[5])
+	}
+	return
+}
+
+func (source *simulatedStatsSource) remaining() int {
+	return len(source.samples)
+}
+
+func (source *simulatedStatsSource) GetInstances(appGuid string) (instances []cf.AppInstanceFields, apiResponse net.ApiResponse) {
+	if len(source.samples) == 0 {
+		apiResponse = net.NewApiResponseWithMessage("No samples left")
+		return
+	}
+
+	instances = source.samples[0]
+	source.samples = source.samples[1:]
+	return
+}
@@ -0,0 +1,285 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"cf/formatters"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type autoscaleDeps struct {
+	reqFactory       *testreq.FakeReqFactory
+	appRepo          *testapi.FakeApplicationRepository
+	appInstancesRepo *testapi.FakeAppInstancesRepo
+	orgRepo          *testapi.FakeOrgRepository
+}
+
+func TestAutoscaleFailsWithUsage(t *testing.T) {
+	deps := getAutoscaleDeps()
+
+	ui := callAutoscale(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAutoscale(t, []string{"--count", "-1", "my-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callAutoscale(t, []string{"--min", "1", "--max", "2", "--cpu", "70", "--count", "1", "my-app"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestAutoscaleRequirements(t *testing.T) {
+	deps := getAutoscaleDeps()
+	args := []string{"--min", "1", "--max", "2", "--cpu", "70", "--count", "1", "my-app"}
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callAutoscale(t, args, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callAutoscale(t, args, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callAutoscale(t, args, deps)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, deps.reqFactory.ApplicationName, "my-app")
+}
+
+func TestAutoscaleWithAnInvalidPolicy(t *testing.T) {
+	deps := getAutoscaleDeps()
+
+	ui := callAutoscale(t, []string{"--min", "3", "--max", "2", "--count", "1", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Invalid autoscaling policy"},
+	})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Maximum instances must not be below the minimum"},
+		{"A cpu or memory threshold is required"},
+	})
+	assert.Equal(t, deps.appInstancesRepo.GetInstancesAppGuid, "")
+}
+
+func TestAutoscaleScalesUpAndWaitsForTheCooldown(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(90, 2),
+		autoscaleSample(90, 3),
+	}
+	deps.appRepo.ReadAppResponses = []cf.Application{autoscaleApp(2), autoscaleApp(3)}
+
+	ui := callAutoscale(t, []string{"--min", "2", "--max", "5", "--cpu", "70", "--interval", "1ms", "--cooldown", "1h", "--count", "2", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Autoscaling app", "my-app", "my-org", "my-space", "my-user"},
+		{"policy", "2 to 5 instances", "cpu 70%", "1ms", "1h0m0s"},
+		{"2 instances", "cpu 90.0%", "scaling up to 3", "cpu above 70%"},
+		{"3 instances", "cpu 90.0%", "keeping", "cooling down until"},
+		{"OK"},
+		{"Scaled 1 times", "my-app", "3 instances"},
+	})
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "my-app-guid")
+	assert.Equal(t, deps.appRepo.UpdateParams.Get("instances"), 3)
+}
+
+func TestAutoscaleReadsTheInstanceCountOnEveryPoll(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(60, 2),
+		autoscaleSample(60, 4),
+	}
+	deps.appRepo.ReadAppResponses = []cf.Application{autoscaleApp(2), autoscaleApp(4)}
+
+	ui := callAutoscale(t, []string{"--min", "1", "--max", "5", "--cpu", "70", "--interval", "1ms", "--count", "2", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"2 instances", "keeping"},
+		{"4 instances", "keeping"},
+		{"Scaled 0 times", "my-app", "4 instances"},
+	})
+	assert.Equal(t, deps.appRepo.ReadName, "my-app")
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+}
+
+func TestAutoscaleSkipsThePollWhenTheAppCannotBeRead(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appRepo.ReadErr = true
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(90, 2),
+	}
+
+	ui := callAutoscale(t, []string{"--min", "2", "--max", "5", "--cpu", "70", "--count", "1", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"could not get app", "Error finding app by name"},
+		{"OK"},
+	})
+	assert.Equal(t, deps.appInstancesRepo.GetInstancesAppGuid, "")
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+}
+
+func TestAutoscaleScalesDownWhenIdle(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(5, 2),
+	}
+
+	ui := callAutoscale(t, []string{"--min", "1", "--max", "5", "--cpu", "70", "--count", "1", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"2 instances", "scaling down to 1", "cpu below 50%"},
+	})
+	assert.Equal(t, deps.appRepo.UpdateParams.Get("instances"), 1)
+}
+
+func TestAutoscaleStaysWithinTheOrgQuota(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(90, 2),
+	}
+	deps.orgRepo.MemoryUsage = 1800
+
+	ui := callAutoscale(t, []string{"--min", "2", "--max", "5", "--cpu", "70", "--count", "1", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"keeping", "cpu above 70%, but the org quota is used up"},
+	})
+	assert.Equal(t, deps.orgRepo.FindByNameName, "my-org")
+	assert.Equal(t, deps.orgRepo.MemoryUsageOrgGuid, "my-org-guid")
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+}
+
+func TestAutoscaleWithAPolicyFile(t *testing.T) {
+	deps := getAutoscaleDeps()
+	deps.appInstancesRepo.GetInstancesResponses = [][]cf.AppInstanceFields{
+		autoscaleSample(90, 2),
+	}
+
+	policy := writeAutoscaleFile(t, "min: 2\nmax: 6\ncpu: 95\nstep: 2\ninterval: 1ms\n")
+	defer os.Remove(policy)
+
+	ui := callAutoscale(t, []string{"--policy", policy, "--cpu", "80", "--count", "1", "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"policy", "2 to 6 instances", "cpu 80%"},
+		{"scaling up to 4", "cpu above 80%"},
+	})
+	assert.Equal(t, deps.appRepo.UpdateParams.Get("instances"), 4)
+}
+
+func TestAutoscaleSimulation(t *testing.T) {
+	deps := getAutoscaleDeps()
+
+	samples := writeAutoscaleFile(t, "time,instance,state,cpu,memory,disk\n"+
+		"2014-01-01T10:00:00Z,0,running,90.00,1048576,1048576\n"+
+		"2014-01-01T10:00:00Z,1,running,80.00,1048576,1048576\n"+
+		"2014-01-01T10:00:30Z,0,running,90.00,1048576,1048576\n"+
+		"2014-01-01T10:00:30Z,1,running,90.00,1048576,1048576\n"+
+		"2014-01-01T10:01:00Z,0,running,10.00,1048576,1048576\n"+
+		"2014-01-01T10:01:00Z,1,running,10.00,1048576,1048576\n")
+	defer os.Remove(samples)
+
+	ui := callAutoscale(t, []string{"--min", "2", "--max", "5", "--cpu", "70", "--cooldown", "45s", "--simulate", samples, "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Simulating autoscaling of app", "my-app", samples},
+		{"2 instances", "cpu 85.0%", "scaling up to 3"},
+		{"3 instances", "cpu 90.0%", "keeping", "cooling down"},
+		{"3 instances", "cpu 10.0%", "scaling down to 2"},
+		{"OK"},
+		{"Scaled 2 times", "2 instances"},
+	})
+	assert.Equal(t, deps.appRepo.UpdateAppGuid, "")
+	assert.Equal(t, deps.appInstancesRepo.GetInstancesAppGuid, "")
+}
+
+func TestAutoscaleSimulationWithAnInvalidFile(t *testing.T) {
+	deps := getAutoscaleDeps()
+
+	samples := writeAutoscaleFile(t, "some,other,file\n")
+	defer os.Remove(samples)
+
+	ui := callAutoscale(t, []string{"--min", "2", "--max", "5", "--cpu", "70", "--simulate", samples, "my-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading samples", samples},
+	})
+}
+
+func autoscaleSample(cpu float64, count int) (instances []cf.AppInstanceFields) {
+	for i := 0; i < count; i++ {
+		instance := cf.AppInstanceFields{}
+		instance.State = cf.InstanceRunning
+		instance.CpuUsage = cpu / 100
+		instance.MemQuota = 256 * formatters.MEGABYTE
+		instance.MemUsage = 64 * formatters.MEGABYTE
+		instances = append(instances, instance)
+	}
+	return
+}
+
+func writeAutoscaleFile(t *testing.T, contents string) string {
+	file, err := ioutil.TempFile("", "autoscale")
+	assert.NoError(t, err)
+	file.WriteString(contents)
+	file.Close()
+	return file.Name()
+}
+
+func autoscaleApp(instanceCount int) (app cf.Application) {
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.InstanceCount = instanceCount
+	app.Memory = 256
+	return
+}
+
+func getAutoscaleDeps() (deps autoscaleDeps) {
+	app := autoscaleApp(2)
+
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+	org.QuotaDefinition.MemoryLimit = 2048
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	deps.appRepo = &testapi.FakeApplicationRepository{ReadApp: app}
+	deps.appInstancesRepo = &testapi.FakeAppInstancesRepo{}
+	deps.orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	return
+}
+
+func callAutoscale(t *testing.T, args []string, deps autoscaleDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("autoscale", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewAutoscale(ui, config, deps.appRepo, deps.appInstancesRepo, deps.orgRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
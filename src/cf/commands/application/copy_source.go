@@ -0,0 +1,157 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type CopySource struct {
+	ui          terminal.UI
+	config      *configuration.Configuration
+	starter     ApplicationStarter
+	stopper     ApplicationStopper
+	appRepo     api.ApplicationRepository
+	appBitsRepo api.ApplicationBitsRepository
+	orgRepo     api.OrganizationRepository
+	spaceRepo   api.SpaceRepository
+	appReq      requirements.ApplicationRequirement
+}
+
+func NewCopySource(ui terminal.UI, config *configuration.Configuration, starter ApplicationStarter, stopper ApplicationStopper, appRepo api.ApplicationRepository, appBitsRepo api.ApplicationBitsRepository, orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository) (cmd *CopySource) {
+	cmd = new(CopySource)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.starter = starter
+	cmd.stopper = stopper
+	cmd.appRepo = appRepo
+	cmd.appBitsRepo = appBitsRepo
+	cmd.orgRepo = orgRepo
+	cmd.spaceRepo = spaceRepo
+	return
+}
+
+func (cmd *CopySource) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "copy-source")
+		return
+	}
+
+	if c.String("o") != "" && c.String("s") == "" {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "copy-source")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *CopySource) Run(c *cli.Context) {
+	sourceApp := cmd.appReq.GetApplication()
+	targetAppName := c.Args()[1]
+
+	orgName, space, apiResponse := cmd.findTargetSpace(c.String("o"), c.String("s"))
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Say("Copying source from app %s to target app %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(sourceApp.Name),
+		terminal.EntityNameColor(targetAppName),
+		terminal.EntityNameColor(orgName),
+		terminal.EntityNameColor(space.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	targetApp, apiResponse := cmd.appRepo.ReadFromSpace(targetAppName, space.Guid)
+	if apiResponse.IsError() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	if apiResponse.IsNotFound() {
+		cmd.ui.Say("Creating app %s from %s...", terminal.EntityNameColor(targetAppName), terminal.EntityNameColor(sourceApp.Name))
+		targetApp, apiResponse = cmd.appRepo.Create(targetAppParams(sourceApp, targetAppName, space.Guid))
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+	}
+
+	apiResponse = cmd.appBitsRepo.CopyBits(sourceApp.Guid, targetApp.Guid)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Error copying source\n%s", apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if c.Bool("no-restart") {
+		return
+	}
+
+	if targetApp.State == "started" {
+		var err error
+		targetApp, err = cmd.stopper.ApplicationStop(targetApp)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+		cmd.ui.Say("")
+	}
+
+	_, err := cmd.starter.ApplicationStart(targetApp)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+}
+
+func (cmd *CopySource) findTargetSpace(orgName, spaceName string) (targetOrgName string, space cf.SpaceFields, apiResponse net.ApiResponse) {
+	targetOrgName = cmd.config.OrganizationFields.Name
+
+	if spaceName == "" {
+		space = cmd.config.SpaceFields
+		return
+	}
+
+	var foundSpace cf.Space
+	if orgName == "" {
+		foundSpace, apiResponse = cmd.spaceRepo.FindByName(spaceName)
+	} else {
+		var org cf.Organization
+		org, apiResponse = cmd.orgRepo.FindByName(orgName)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+		targetOrgName = org.Name
+		foundSpace, apiResponse = cmd.spaceRepo.FindByNameInOrg(spaceName, org.Guid)
+	}
+
+	space = foundSpace.SpaceFields
+	return
+}
+
+func targetAppParams(sourceApp cf.Application, name, spaceGuid string) (params cf.AppParams) {
+	params = sourceApp.ToParams()
+	params.Delete("guid")
+	params.Delete("state")
+	params.Set("name", name)
+	params.Set("space_guid", spaceGuid)
+	return
+}
@@ -0,0 +1,194 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"generic"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type copySourceDeps struct {
+	reqFactory  *testreq.FakeReqFactory
+	starter     *testcmd.FakeAppStarter
+	stopper     *testcmd.FakeAppStopper
+	appRepo     *testapi.FakeApplicationRepository
+	appBitsRepo *testapi.FakeApplicationBitsRepository
+	orgRepo     *testapi.FakeOrgRepository
+	spaceRepo   *testapi.FakeSpaceRepository
+}
+
+func TestCopySourceFailsWithUsage(t *testing.T) {
+	deps := getCopySourceDeps()
+
+	ui := callCopySource(t, []string{}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopySource(t, []string{"source-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopySource(t, []string{"-o", "other-org", "source-app", "target-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCopySource(t, []string{"source-app", "target-app"}, deps)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestCopySourceRequirements(t *testing.T) {
+	deps := getCopySourceDeps()
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callCopySource(t, []string{"source-app", "target-app"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callCopySource(t, []string{"source-app", "target-app"}, deps)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callCopySource(t, []string{"source-app", "target-app"}, deps)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, deps.reqFactory.ApplicationName, "source-app")
+}
+
+func TestCopySourceToAnExistingAppInTheTargetedSpace(t *testing.T) {
+	deps := getCopySourceDeps()
+	targetApp := cf.Application{}
+	targetApp.Name = "target-app"
+	targetApp.Guid = "target-app-guid"
+	targetApp.State = "started"
+	deps.appRepo.ReadApp = targetApp
+
+	ui := callCopySource(t, []string{"source-app", "target-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Copying source from app", "source-app", "target-app", "my-org", "my-space", "my-user"},
+		{"OK"},
+	})
+	assert.Equal(t, deps.appRepo.ReadName, "target-app")
+	assert.Equal(t, deps.appRepo.ReadFromSpaceGuid, "my-space-guid")
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+	assert.Equal(t, deps.appBitsRepo.CopyBitsSourceAppGuid, "source-app-guid")
+	assert.Equal(t, deps.appBitsRepo.CopyBitsTargetAppGuid, "target-app-guid")
+	assert.Equal(t, deps.stopper.AppToStop, targetApp)
+	assert.Equal(t, deps.starter.AppToStart, targetApp)
+}
+
+func TestCopySourceCreatesTheTargetAppInAnotherOrgAndSpace(t *testing.T) {
+	deps := getCopySourceDeps()
+	deps.appRepo.ReadNotFound = true
+
+	ui := callCopySource(t, []string{"-o", "other-org", "-s", "other-space", "source-app", "target-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Copying source from app", "source-app", "target-app", "other-org", "other-space"},
+		{"Creating app", "target-app", "source-app"},
+		{"OK"},
+	})
+	assert.Equal(t, deps.orgRepo.FindByNameName, "other-org")
+	assert.Equal(t, deps.spaceRepo.FindByNameInOrgName, "other-space")
+	assert.Equal(t, deps.spaceRepo.FindByNameInOrgOrgGuid, "other-org-guid")
+	assert.Equal(t, deps.appRepo.ReadFromSpaceGuid, "other-space-guid")
+
+	params := deps.appRepo.CreatedAppParams()
+	assert.Equal(t, params.Get("name"), "target-app")
+	assert.Equal(t, params.Get("space_guid"), "other-space-guid")
+	assert.Equal(t, params.Get("memory"), uint64(512))
+	assert.Equal(t, params.Get("instances"), 3)
+	assert.Equal(t, params.Get("env").(generic.Map).Get("RAILS_ENV"), "staging")
+	assert.False(t, params.Has("guid"))
+	assert.False(t, params.Has("state"))
+
+	assert.Equal(t, deps.appBitsRepo.CopyBitsTargetAppGuid, "target-app-guid")
+	assert.Equal(t, deps.stopper.AppToStop.Guid, "")
+	assert.Equal(t, deps.starter.AppToStart.Guid, "target-app-guid")
+}
+
+func TestCopySourceWithNoRestart(t *testing.T) {
+	deps := getCopySourceDeps()
+	targetApp := cf.Application{}
+	targetApp.Guid = "target-app-guid"
+	targetApp.State = "started"
+	deps.appRepo.ReadApp = targetApp
+
+	callCopySource(t, []string{"--no-restart", "source-app", "target-app"}, deps)
+
+	assert.Equal(t, deps.appBitsRepo.CopyBitsTargetAppGuid, "target-app-guid")
+	assert.Equal(t, deps.stopper.AppToStop.Guid, "")
+	assert.Equal(t, deps.starter.AppToStart.Guid, "")
+}
+
+func TestCopySourceWhenCopyingFails(t *testing.T) {
+	deps := getCopySourceDeps()
+	deps.appBitsRepo.CopyBitsErr = true
+
+	ui := callCopySource(t, []string{"source-app", "target-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error copying source"},
+	})
+	assert.Equal(t, deps.starter.AppToStart.Guid, "")
+}
+
+func getCopySourceDeps() (deps copySourceDeps) {
+	sourceApp := cf.Application{}
+	sourceApp.Name = "source-app"
+	sourceApp.Guid = "source-app-guid"
+	sourceApp.Memory = 512
+	sourceApp.InstanceCount = 3
+	sourceApp.State = "started"
+	sourceApp.EnvironmentVars = map[string]interface{}{"RAILS_ENV": "staging"}
+
+	org := cf.Organization{}
+	org.Name = "other-org"
+	org.Guid = "other-org-guid"
+
+	space := cf.Space{}
+	space.Name = "other-space"
+	space.Guid = "other-space-guid"
+
+	targetApp := cf.Application{}
+	targetApp.Name = "target-app"
+	targetApp.Guid = "target-app-guid"
+
+	deps.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: sourceApp}
+	deps.starter = &testcmd.FakeAppStarter{}
+	deps.stopper = &testcmd.FakeAppStopper{}
+	deps.appRepo = &testapi.FakeApplicationRepository{ReadApp: targetApp}
+	deps.appBitsRepo = &testapi.FakeApplicationBitsRepository{}
+	deps.orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	deps.spaceRepo = &testapi.FakeSpaceRepository{FindByNameInOrgSpace: space}
+	return
+}
+
+func callCopySource(t *testing.T, args []string, deps copySourceDeps) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("copy-source", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	space.Guid = "my-space-guid"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewCopySource(ui, config, deps.starter, deps.stopper, deps.appRepo, deps.appBitsRepo, deps.orgRepo, deps.spaceRepo)
+	testcmd.RunCommand(cmd, ctxt, deps.reqFactory)
+	return
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/formatters"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type DownloadBits struct {
+	ui          terminal.UI
+	config      *configuration.Configuration
+	appBitsRepo api.ApplicationBitsRepository
+	appReq      requirements.ApplicationRequirement
+	droplet     bool
+}
+
+func NewDownloadBits(ui terminal.UI, config *configuration.Configuration, appBitsRepo api.ApplicationBitsRepository, droplet bool) (cmd *DownloadBits) {
+	cmd = new(DownloadBits)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appBitsRepo = appBitsRepo
+	cmd.droplet = droplet
+	return
+}
+
+func (cmd *DownloadBits) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		if cmd.droplet {
+			cmd.ui.FailWithUsage(c, "download-droplet")
+		} else {
+			cmd.ui.FailWithUsage(c, "download-bits")
+		}
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *DownloadBits) Run(c *cli.Context) {
+	app := cmd.appReq.GetApplication()
+	path := c.Args()[1]
+
+	what := "source bits"
+	if cmd.droplet {
+		what = "droplet"
+	}
+
+	cmd.ui.Say("Downloading %s of app %s in org %s / space %s as %s...",
+		what,
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	// download next to the destination so a failed or corrupt download never
+	// replaces an existing file
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		cmd.ui.Failed("Error creating %s\n%s", path, err.Error())
+		return
+	}
+	defer os.Remove(file.Name())
+
+	var (
+		sha1Sum     string
+		apiResponse net.ApiResponse
+	)
+	progress := newDownloadProgress(cmd.ui)
+	if cmd.droplet {
+		sha1Sum, apiResponse = cmd.appBitsRepo.DownloadDroplet(app.Guid, file, progress)
+	} else {
+		sha1Sum, apiResponse = cmd.appBitsRepo.DownloadBits(app.Guid, file, progress)
+	}
+	file.Close()
+
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		cmd.ui.Failed("Error writing %s\n%s", path, err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Saved %s to %s (sha1 %s)", what, terminal.EntityNameColor(path), sha1Sum)
+}
+
+// newDownloadProgress reports every further tenth of a download, or every
+// further megabyte when its size is unknown.
+func newDownloadProgress(ui terminal.UI) func(downloaded, total int64) {
+	var reported int64
+	return func(downloaded, total int64) {
+		if total <= 0 {
+			if downloaded-reported >= formatters.MEGABYTE {
+				reported = downloaded
+				ui.Say("  %s downloaded", formatters.ByteSize(uint64(downloaded)))
+			}
+			return
+		}
+
+		step := downloaded * 10 / total
+		if step > reported {
+			reported = step
+			ui.Say("  %d%% (%s of %s)", step*10, formatters.ByteSize(uint64(downloaded)), formatters.ByteSize(uint64(total)))
+		}
+	}
+}
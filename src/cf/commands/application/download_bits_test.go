@@ -0,0 +1,151 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestDownloadBitsFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{}
+
+	ui := callDownloadBits(t, []string{}, reqFactory, appBitsRepo, true)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callDownloadBits(t, []string{"my-app"}, reqFactory, appBitsRepo, false)
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestDownloadBitsRequirements(t *testing.T) {
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callDownloadBits(t, []string{"my-app", "droplet.tgz"}, reqFactory, appBitsRepo, true)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callDownloadBits(t, []string{"my-app", "droplet.tgz"}, reqFactory, appBitsRepo, true)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callDownloadBits(t, []string{"my-app", "droplet.tgz"}, reqFactory, appBitsRepo, true)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.ApplicationName, "my-app")
+}
+
+func TestDownloadDroplet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "download-droplet")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "droplet.tgz")
+
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{DownloadContent: "droplet contents", DownloadSha1: "abc123"}
+
+	ui := callDownloadBits(t, []string{"my-app", path}, reqFactory, appBitsRepo, true)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Downloading droplet of app", "my-app", "my-org", "my-space", "my-user"},
+		{"50%"},
+		{"100%"},
+		{"OK"},
+		{"Saved droplet", path, "abc123"},
+	})
+	assert.Equal(t, appBitsRepo.DownloadedDropletAppGuid, "my-app-guid")
+	assert.Equal(t, appBitsRepo.DownloadedBitsAppGuid, "")
+
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(contents), "droplet contents")
+}
+
+func TestDownloadSourceBits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "download-bits")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.zip")
+
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{DownloadContent: "zip contents"}
+
+	ui := callDownloadBits(t, []string{"my-app", path}, reqFactory, appBitsRepo, false)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Downloading source bits of app", "my-app"},
+		{"OK"},
+	})
+	assert.Equal(t, appBitsRepo.DownloadedBitsAppGuid, "my-app-guid")
+	assert.Equal(t, appBitsRepo.DownloadedDropletAppGuid, "")
+}
+
+func TestDownloadDropletLeavesExistingFileWhenDownloadFails(t *testing.T) {
+	dir, err := ioutil.TempDir("", "download-droplet")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "droplet.tgz")
+	err = ioutil.WriteFile(path, []byte("previous droplet"), 0600)
+	assert.NoError(t, err)
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{DownloadErr: true}
+
+	ui := callDownloadBits(t, []string{"my-app", path}, reqFactory, appBitsRepo, true)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error downloading"},
+	})
+
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(contents), "previous droplet")
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, len(files), 1)
+}
+
+func callDownloadBits(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appBitsRepo *testapi.FakeApplicationBitsRepository, droplet bool) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	commandName := "download-bits"
+	if droplet {
+		commandName = "download-droplet"
+	}
+	ctxt := testcmd.NewContext(commandName, args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewDownloadBits(ui, config, appBitsRepo, droplet)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
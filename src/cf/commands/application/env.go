@@ -4,7 +4,9 @@ import (
 	"cf/configuration"
 	"cf/requirements"
 	"cf/terminal"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/codegangsta/cli"
 )
 
@@ -56,6 +58,19 @@ func (cmd *Env) Run(c *cli.Context) {
 		return
 	}
 	for key, value := range envVars {
-		cmd.ui.Say("%s: %s", key, terminal.EntityNameColor(value))
+		cmd.ui.Say("%s: %s", key, terminal.EntityNameColor(formatEnvValue(value)))
 	}
 }
+
+// formatEnvValue shows strings as they are and structured values as json.
+func formatEnvValue(value interface{}) string {
+	if value, ok := value.(string); ok {
+		return value
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
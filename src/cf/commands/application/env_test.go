@@ -36,7 +36,7 @@ func TestEnvFailsWithUsage(t *testing.T) {
 
 func TestEnvListsEnvironmentVariables(t *testing.T) {
 	reqFactory := getEnvDependencies()
-	reqFactory.Application.EnvironmentVars = map[string]string{
+	reqFactory.Application.EnvironmentVars = map[string]interface{}{
 		"my-key":  "my-value",
 		"my-key2": "my-value2",
 	}
@@ -50,9 +50,22 @@ func TestEnvListsEnvironmentVariables(t *testing.T) {
 	})
 }
 
+func TestEnvShowsStructuredValuesAsJson(t *testing.T) {
+	reqFactory := getEnvDependencies()
+	reqFactory.Application.EnvironmentVars = map[string]interface{}{
+		"CACHE": map[string]interface{}{"host": "localhost", "port": float64(11211)},
+	}
+
+	ui := callEnv(t, []string{"my-app"}, reqFactory)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"CACHE", `{"host":"localhost","port":11211}`},
+	})
+}
+
 func TestEnvShowsEmptyMessage(t *testing.T) {
 	reqFactory := getEnvDependencies()
-	reqFactory.Application.EnvironmentVars = map[string]string{}
+	reqFactory.Application.EnvironmentVars = map[string]interface{}{}
 
 	ui := callEnv(t, []string{"my-app"}, reqFactory)
 
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -52,11 +53,10 @@ func (cmd *Events) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	eventChan, statusChan := cmd.eventsRepo.ListEvents(app.Guid)
 	table := cmd.ui.Table([]string{"time", "instance", "description", "exit status"})
 	noEvents := true
 
-	for events := range eventChan {
+	apiStatus := cmd.eventsRepo.ListEvents(app.Guid, func(events []cf.EventFields) bool {
 		rows := [][]string{}
 		for i := len(events) - 1; i >= 0; i-- {
 			event := events[i]
@@ -69,9 +69,9 @@ func (cmd *Events) Run(c *cli.Context) {
 		}
 		table.Print(rows)
 		noEvents = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching events.\n%s", apiStatus.Message)
 		return
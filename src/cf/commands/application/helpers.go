@@ -2,6 +2,8 @@ package application
 
 import (
 	"cf"
+	"cf/api"
+	"cf/configuration"
 	"cf/terminal"
 	"code.google.com/p/gogoprotobuf/proto"
 	"fmt"
@@ -15,6 +17,11 @@ const (
 	TIMESTAMP_FORMAT = "2006-01-02T15:04:05.00-0700"
 )
 
+const (
+	recentlyReplacedWindow = 10 * time.Minute
+	replacedInstanceGap    = time.Minute
+)
+
 func NewLogMessage(msgText, appGuid, sourceName string, timestamp time.Time) (msg *logmessage.Message) {
 	messageType := logmessage.LogMessage_ERR
 
@@ -158,3 +165,44 @@ func coloredInstanceState(instance cf.AppInstanceFields) (colored string) {
 
 	return
 }
+
+// recentlyReplaced guesses, from start times alone, whether the instance at
+// index was restarted or crashed and replaced in the last few minutes. The API
+// does not report restarts, so an instance counts as replaced when it came up
+// well after an instance at a higher index. Instances added by scaling up take
+// the highest indexes and are never marked, which also means a replaced
+// instance at the highest index goes unmarked.
+func recentlyReplaced(instances []cf.AppInstanceFields, index int, now time.Time) bool {
+	instance := instances[index]
+	if instance.Since.IsZero() || now.Sub(instance.Since) > recentlyReplacedWindow {
+		return false
+	}
+
+	for _, sibling := range instances[index+1:] {
+		if !sibling.Since.IsZero() && instance.Since.Sub(sibling.Since) > replacedInstanceGap {
+			return true
+		}
+	}
+	return false
+}
+
+// orgMemoryAvailable returns the memory in megabytes left in the quota of the
+// targeted org. limited is false when the org has no memory limit or its
+// quota or usage cannot be read.
+func orgMemoryAvailable(orgRepo api.OrganizationRepository, config *configuration.Configuration) (available uint64, limited bool) {
+	org, apiResponse := orgRepo.FindByName(config.OrganizationFields.Name)
+	if apiResponse.IsNotSuccessful() || org.QuotaDefinition.MemoryLimit == 0 {
+		return
+	}
+
+	usage, apiResponse := orgRepo.GetMemoryUsage(org.Guid)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	limited = true
+	if usage < org.QuotaDefinition.MemoryLimit {
+		available = org.QuotaDefinition.MemoryLimit - usage
+	}
+	return
+}
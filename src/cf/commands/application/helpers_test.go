@@ -1,11 +1,14 @@
 package application
 
 import (
+	"cf"
+	"cf/configuration"
 	"cf/terminal"
 	"code.google.com/p/gogoprotobuf/proto"
 	"fmt"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
 	"testing"
 	"time"
 )
@@ -14,6 +17,71 @@ func TestTimestampFormat(t *testing.T) {
 	assert.Equal(t, TIMESTAMP_FORMAT, "2006-01-02T15:04:05.00-0700")
 }
 
+func TestRecentlyReplaced(t *testing.T) {
+	now := time.Now()
+
+	instances := []cf.AppInstanceFields{
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Hour)},
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Minute)},
+		cf.AppInstanceFields{Since: now.Add(-30 * time.Minute)},
+		cf.AppInstanceFields{},
+	}
+
+	assert.False(t, recentlyReplaced(instances, 0, now))
+	assert.True(t, recentlyReplaced(instances, 1, now))
+	assert.False(t, recentlyReplaced(instances, 2, now))
+	assert.False(t, recentlyReplaced(instances, 3, now))
+
+	startedTogether := []cf.AppInstanceFields{
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Minute)},
+		cf.AppInstanceFields{Since: now.Add(-2*time.Minute + 10*time.Second)},
+	}
+
+	assert.False(t, recentlyReplaced(startedTogether, 0, now))
+	assert.False(t, recentlyReplaced(startedTogether, 1, now))
+
+	scaledUp := []cf.AppInstanceFields{
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Hour)},
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Hour)},
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Minute)},
+		cf.AppInstanceFields{Since: now.Add(-2 * time.Minute)},
+	}
+
+	assert.False(t, recentlyReplaced(scaledUp, 2, now))
+	assert.False(t, recentlyReplaced(scaledUp, 3, now))
+}
+
+func TestOrgMemoryAvailable(t *testing.T) {
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+	org.QuotaDefinition.MemoryLimit = 2048
+	orgRepo := &testapi.FakeOrgRepository{FindByNameOrganization: org, MemoryUsage: 1536}
+
+	config := &configuration.Configuration{}
+	config.OrganizationFields.Name = "my-org"
+
+	available, limited := orgMemoryAvailable(orgRepo, config)
+	assert.True(t, limited)
+	assert.Equal(t, available, uint64(512))
+	assert.Equal(t, orgRepo.FindByNameName, "my-org")
+	assert.Equal(t, orgRepo.MemoryUsageOrgGuid, "my-org-guid")
+
+	orgRepo.MemoryUsage = 4096
+	available, limited = orgMemoryAvailable(orgRepo, config)
+	assert.True(t, limited)
+	assert.Equal(t, available, uint64(0))
+
+	orgRepo.MemoryUsageErr = true
+	_, limited = orgMemoryAvailable(orgRepo, config)
+	assert.False(t, limited)
+
+	org.QuotaDefinition.MemoryLimit = 0
+	orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	_, limited = orgMemoryAvailable(orgRepo, config)
+	assert.False(t, limited)
+}
+
 func TestLogMessageOutput(t *testing.T) {
 	cloud_controller := "API"
 	router := "RTR"
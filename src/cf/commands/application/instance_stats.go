@@ -0,0 +1,130 @@
+package application
+
+import (
+	"cf"
+	"cf/formatters"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const DefaultSampleInterval = 10 * time.Second
+
+var instanceSampleCsvHeader = []string{"time", "instance", "state", "cpu", "memory", "disk"}
+
+type statSeries struct {
+	min   float64
+	max   float64
+	sum   float64
+	count int
+}
+
+func (series *statSeries) add(value float64) {
+	if series.count == 0 || value < series.min {
+		series.min = value
+	}
+	if series.count == 0 || value > series.max {
+		series.max = value
+	}
+	series.sum += value
+	series.count++
+}
+
+func (series statSeries) avg() float64 {
+	if series.count == 0 {
+		return 0
+	}
+	return series.sum / float64(series.count)
+}
+
+type instanceStatSeries struct {
+	cpu  statSeries
+	mem  statSeries
+	disk statSeries
+}
+
+// instanceStatsRecorder keeps per instance min/avg/max of every sample it is
+// given and, when it has a writer, a CSV row per instance and sample.
+type instanceStatsRecorder struct {
+	series []*instanceStatSeries
+	csv    *csv.Writer
+}
+
+func newInstanceStatsRecorder(csvOut io.Writer) (recorder *instanceStatsRecorder, err error) {
+	recorder = new(instanceStatsRecorder)
+	if csvOut == nil {
+		return
+	}
+
+	recorder.csv = csv.NewWriter(csvOut)
+	err = recorder.csv.Write(instanceSampleCsvHeader)
+	if err != nil {
+		return
+	}
+	recorder.csv.Flush()
+	err = recorder.csv.Error()
+	return
+}
+
+func (recorder *instanceStatsRecorder) record(taken time.Time, instances []cf.AppInstanceFields) (err error) {
+	for index, instance := range instances {
+		for len(recorder.series) <= index {
+			recorder.series = append(recorder.series, new(instanceStatSeries))
+		}
+
+		series := recorder.series[index]
+		series.cpu.add(instance.CpuUsage * 100)
+		series.mem.add(float64(instance.MemUsage))
+		series.disk.add(float64(instance.DiskUsage))
+
+		if recorder.csv == nil {
+			continue
+		}
+
+		err = recorder.csv.Write([]string{
+			taken.Format(time.RFC3339),
+			strconv.Itoa(index),
+			string(instance.State),
+			strconv.FormatFloat(instance.CpuUsage*100, 'f', 2, 64),
+			strconv.FormatUint(instance.MemUsage, 10),
+			strconv.FormatUint(instance.DiskUsage, 10),
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	if recorder.csv != nil {
+		// flushed after every sample so an interrupted run keeps what it saw
+		recorder.csv.Flush()
+		err = recorder.csv.Error()
+	}
+	return
+}
+
+func (recorder *instanceStatsRecorder) summaryTable() (table [][]string) {
+	table = [][]string{
+		[]string{"", "samples", "cpu min/avg/max", "memory min/avg/max", "disk min/avg/max"},
+	}
+
+	for index, series := range recorder.series {
+		table = append(table, []string{
+			fmt.Sprintf("#%d", index),
+			strconv.Itoa(series.cpu.count),
+			fmt.Sprintf("%.1f%% / %.1f%% / %.1f%%", series.cpu.min, series.cpu.avg(), series.cpu.max),
+			byteSizeSeries(series.mem),
+			byteSizeSeries(series.disk),
+		})
+	}
+	return
+}
+
+func byteSizeSeries(series statSeries) string {
+	return fmt.Sprintf("%s / %s / %s",
+		formatters.ByteSize(uint64(series.min)),
+		formatters.ByteSize(uint64(series.avg())),
+		formatters.ByteSize(uint64(series.max)),
+	)
+}
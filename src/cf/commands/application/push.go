@@ -10,10 +10,14 @@ import (
 	"cf/requirements"
 	"cf/terminal"
 	"errors"
+	"fmt"
 	"generic"
 	"github.com/codegangsta/cli"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
 type Push struct {
@@ -31,6 +35,11 @@ type Push struct {
 	stackRepo      api.StackRepository
 	appBitsRepo    api.ApplicationBitsRepository
 	globalServices cf.ServiceInstanceSet
+
+	declaredServices []cf.ServiceInstanceDeclaration
+
+	ServiceTimeout time.Duration
+	PingerThrottle time.Duration
 }
 
 func NewPush(ui terminal.UI, config *configuration.Configuration, manifestRepo manifest.ManifestRepository,
@@ -50,6 +59,9 @@ func NewPush(ui terminal.UI, config *configuration.Configuration, manifestRepo m
 	cmd.serviceRepo = serviceRepo
 	cmd.stackRepo = stackRepo
 	cmd.appBitsRepo = appBitsRepo
+
+	cmd.ServiceTimeout = DefaultServiceTimeout
+	cmd.PingerThrottle = DefaultPingerThrottle
 	return
 }
 
@@ -84,10 +96,19 @@ func createAppSetFromContextAndManifest(
 
 			path := contextPath
 			if manifestAppParams.Has("path") {
+				if appFields.Has("docker_image") {
+					err = errors.New(fmt.Sprintf("App %s cannot have both a path and a docker image", appFields.Get("name")))
+					return
+				}
 				path = filepath.Join(contextPath, manifestAppParams.Get("path").(string))
 			}
 			appFields.Set("path", path)
 
+			err = cf.ValidateHealthCheck(appFields)
+			if err != nil {
+				return
+			}
+
 			appSet = append(appSet, appFields)
 		}
 	}
@@ -102,6 +123,12 @@ func createAppSetFromContextAndManifest(
 }
 
 func (cmd *Push) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if c.String("p") != "" && c.String("docker-image") != "" {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "push")
+		return
+	}
+
 	contextPath, err := appPathFromContext(c)
 
 	if err != nil {
@@ -123,12 +150,36 @@ func (cmd *Push) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 		return
 	}
 
-	cmd.appSet, err = createAppSetFromContextAndManifest(contextParams, contextPath, manifest)
+	if c.Int("max-in-flight") < 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "push")
+		return
+	}
+
+	appSet, err := createAppSetFromContextAndManifest(contextParams, contextPath, manifest)
 	if err != nil {
 		cmd.ui.Failed("Error: %s", err)
 		return
 	}
 
+	if c.String("p") != "" {
+		for _, appParams := range appSet {
+			if appParams.Has("docker_image") {
+				err = errors.New(fmt.Sprintf("App %s cannot have both a path and a docker image", appParams.Get("name")))
+				cmd.ui.Failed("Error: %s", err)
+				return
+			}
+		}
+	}
+
+	cmd.appSet, err = orderAppsByDependencies(appSet)
+	if err != nil {
+		cmd.ui.Failed("Error: %s", err)
+		return
+	}
+
+	cmd.declaredServices = manifest.DeclaredServices
+
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
 		reqFactory.NewTargetedSpaceRequirement(),
@@ -137,16 +188,45 @@ func (cmd *Push) GetRequirements(reqFactory requirements.Factory, c *cli.Context
 }
 
 func (cmd *Push) Run(c *cli.Context) {
+	if !cmd.createDeclaredServices() {
+		return
+	}
+
+	if len(cmd.appSet) > 1 && c.Int("max-in-flight") > 1 {
+		cmd.pushInParallel(c)
+		return
+	}
+
 	for _, appParams := range cmd.appSet {
+		app, ok := cmd.prepareApp(appParams, c)
+		if !ok {
+			return
+		}
+
+		cmd.restart(app, appParams, c)
+	}
+}
+
+// prepareApp creates or updates the app, binds its routes, uploads its bits
+// and binds its services, leaving only the restart to the caller.
+func (cmd *Push) prepareApp(appParams cf.AppParams, c *cli.Context) (app cf.Application, ok bool) {
+	dockerImage := appParams.Has("docker_image")
+	if dockerImage {
+		cmd.ignoreBuildpackAndStack(appParams)
+	} else {
 		cmd.fetchStackGuid(&appParams)
+	}
 
-		app, didCreate := cmd.app(appParams)
-		if !didCreate {
-			app = cmd.updateApp(app, appParams)
-		}
+	app, didCreate := cmd.app(appParams)
+	if !didCreate {
+		app = cmd.updateApp(app, appParams)
+	}
 
-		cmd.bindAppToRoute(app, appParams, didCreate, c)
+	cmd.bindAppToRoutes(app, appParams, didCreate, c)
 
+	if dockerImage {
+		cmd.ui.Say("Using docker image %s for %s", terminal.EntityNameColor(appParams.Get("docker_image").(string)), terminal.EntityNameColor(app.Name))
+	} else {
 		cmd.ui.Say("Uploading %s...", terminal.EntityNameColor(app.Name))
 
 		apiResponse := cmd.appBitsRepo.UploadApp(app.Guid, appParams.Get("path").(string))
@@ -155,30 +235,123 @@ func (cmd *Push) Run(c *cli.Context) {
 			return
 		}
 		cmd.ui.Ok()
+	}
 
-		if appParams.Has("services") {
-			services := appParams.Get("services").([]string)
+	if appParams.Has("services") {
+		services := appParams.Get("services").([]string)
 
-			for _, serviceName := range services {
-				serviceInstance, response := cmd.serviceRepo.FindInstanceByName(serviceName)
+		for _, serviceName := range services {
+			serviceInstance, response := cmd.serviceRepo.FindInstanceByName(serviceName)
 
-				if response.IsNotSuccessful() {
-					cmd.ui.Failed("Could not find service %s to bind to %s", serviceName, appParams.Get("name").(string))
-					return
-				}
+			if response.IsNotSuccessful() {
+				cmd.ui.Failed("Could not find service %s to bind to %s", serviceName, appParams.Get("name").(string))
+				return
+			}
 
-				cmd.ui.Say("Binding service %s to %s in org %s / space %s as %s", serviceName, appParams.Get("name").(string), cmd.config.OrganizationFields.Name, cmd.config.SpaceFields.Name, cmd.config.Username())
-				bindResponse := cmd.binder.BindApplication(app, serviceInstance)
-				cmd.ui.Ok()
+			cmd.ui.Say("Binding service %s to %s in org %s / space %s as %s", serviceName, appParams.Get("name").(string), cmd.config.OrganizationFields.Name, cmd.config.SpaceFields.Name, cmd.config.Username())
+			bindResponse := cmd.binder.BindApplication(app, serviceInstance)
+			cmd.ui.Ok()
 
-				if bindResponse.IsNotSuccessful() && bindResponse.ErrorCode != service.AppAlreadyBoundErrorCode {
-					cmd.ui.Failed("Could not find to service %s\nError: %s", serviceName, bindResponse.Message)
-					return
+			if bindResponse.IsNotSuccessful() && bindResponse.ErrorCode != service.AppAlreadyBoundErrorCode {
+				cmd.ui.Failed("Could not find to service %s\nError: %s", serviceName, bindResponse.Message)
+				return
+			}
+		}
+	}
+
+	ok = true
+	return
+}
+
+// pushInParallel pushes up to max-in-flight apps at once. An app is only
+// started once the apps it depends on are pushed, and is not started at all
+// when one of them failed.
+func (cmd *Push) pushInParallel(c *cli.Context) {
+	appCount := len(cmd.appSet)
+	appIndexes := map[string]int{}
+	finished := make([]chan bool, appCount)
+	failures := make([]string, appCount)
+	outputMutex := new(sync.Mutex)
+
+	for index, appParams := range cmd.appSet {
+		appIndexes[appParams.Get("name").(string)] = index
+		finished[index] = make(chan bool)
+	}
+
+	cf.RunInParallel(appCount, c.Int("max-in-flight"), func(index int) {
+		defer close(finished[index])
+
+		appParams := cmd.appSet[index]
+		appName := appParams.Get("name").(string)
+		appUI := newPushUI(cmd.ui, appName, outputMutex)
+		push := cmd.withUI(appUI)
+
+		failures[index] = appUI.run(func() {
+			app, ok := push.prepareApp(appParams, c)
+			if !ok {
+				return
+			}
+
+			for _, dependencyName := range appDependencies(appParams) {
+				dependencyIndex := appIndexes[dependencyName]
+				<-finished[dependencyIndex]
+
+				if failures[dependencyIndex] != "" {
+					appUI.Failed("Not starting %s, %s failed to push", appName, dependencyName)
 				}
 			}
+
+			push.restart(app, appParams, c)
+		})
+	})
+
+	cmd.showPushSummary(failures)
+}
+
+func (cmd *Push) withUI(ui terminal.UI) *Push {
+	push := *cmd
+	push.ui = ui
+	push.starter = cmd.starter.WithUI(ui)
+	push.stopper = cmd.stopper.WithUI(ui)
+	return &push
+}
+
+func (cmd *Push) showPushSummary(failures []string) {
+	cmd.ui.Say("")
+
+	failedCount := 0
+	rows := [][]string{}
+	for index, appParams := range cmd.appSet {
+		status, details := "pushed", ""
+		if failures[index] != "" {
+			failedCount++
+			status = "failed"
+			details = strings.Split(failures[index], "\n")[0]
 		}
 
-		cmd.restart(app, appParams, c)
+		rows = append(rows, []string{appParams.Get("name").(string), status, details})
+	}
+
+	table := cmd.ui.Table([]string{"app", "status", "details"})
+	table.Print(rows)
+	cmd.ui.Say("")
+
+	if failedCount > 0 {
+		cmd.ui.Failed("%d of %d apps failed to push", failedCount, len(cmd.appSet))
+		return
+	}
+
+	cmd.ui.Ok()
+}
+
+// ignoreBuildpackAndStack drops the buildpack and stack of an app pushed as a
+// docker image, since the image is run as it is.
+func (cmd *Push) ignoreBuildpackAndStack(appParams cf.AppParams) {
+	for _, key := range []string{"buildpack", "stack"} {
+		if appParams.NotNil(key) {
+			cmd.ui.Warn("Ignoring %s %s, app %s is pushed as a docker image", key, appParams.Get(key), appParams.Get("name"))
+		}
+		appParams.Delete(key)
 	}
 }
 
@@ -200,21 +373,42 @@ func (cmd *Push) fetchStackGuid(appParams *cf.AppParams) {
 	appParams.Set("stack_guid", stack.Guid)
 }
 
-func (cmd *Push) bindAppToRoute(app cf.Application, params cf.AppParams, didCreateApp bool, c *cli.Context) {
+func (cmd *Push) bindAppToRoutes(app cf.Application, params cf.AppParams, didCreateApp bool, c *cli.Context) {
 	if c.Bool("no-route") {
 		return
 	}
 
-	routeFlagsPresent := c.String("n") != "" || c.String("d") != "" || c.Bool("no-hostname")
+	routesListed := params.Has("routes")
+	routeFlagsPresent := c.String("n") != "" || c.String("d") != "" || c.Bool("no-hostname") || c.Bool("prune-routes") || routesListed
 	if len(app.Routes) > 0 && !routeFlagsPresent {
 		return
 	}
 
-	if len(app.Routes) == 0 && didCreateApp == false && !routeFlagsPresent {
+	if len(app.Routes) == 0 && didCreateApp == false && !routeFlagsPresent && !c.Bool("random-route") {
 		cmd.ui.Say("App %s currently exists as a worker, skipping route creation", terminal.EntityNameColor(app.Name))
 		return
 	}
 
+	boundRoutes := []cf.Route{}
+	if routesListed {
+		for _, spec := range params.Get("routes").([]cf.RouteSpec) {
+			hostName, path, domain := cmd.resolveRouteSpec(c, spec)
+			if domain.Guid == "" {
+				return
+			}
+
+			boundRoutes = append(boundRoutes, cmd.bindRoute(app, hostName, path, domain))
+		}
+	} else {
+		boundRoutes = append(boundRoutes, cmd.bindDefaultRoute(app, params, c))
+	}
+
+	if c.Bool("prune-routes") {
+		cmd.pruneRoutes(app, boundRoutes)
+	}
+}
+
+func (cmd *Push) bindDefaultRoute(app cf.Application, params cf.AppParams, c *cli.Context) (route cf.Route) {
 	var defaultHostname string
 	if params.Has("host") {
 		defaultHostname = params.Get("host").(string)
@@ -231,7 +425,19 @@ func (cmd *Push) bindAppToRoute(app cf.Application, params cf.AppParams, didCrea
 
 	hostName := cmd.hostname(c, defaultHostname)
 	domain := cmd.domain(c, domainName)
-	route := cmd.route(hostName, domain.DomainFields)
+
+	if c.Bool("random-route") && c.String("n") == "" && !params.Has("host") && !c.Bool("no-hostname") {
+		hostName = cmd.randomHostname(app.Name, domain.DomainFields)
+		if hostName == "" {
+			return
+		}
+	}
+
+	return cmd.bindRoute(app, hostName, "", domain)
+}
+
+func (cmd *Push) bindRoute(app cf.Application, hostName, path string, domain cf.Domain) (route cf.Route) {
+	route = cmd.route(hostName, path, domain.DomainFields)
 
 	for _, boundRoute := range app.Routes {
 		if boundRoute.Guid == route.Guid {
@@ -239,7 +445,7 @@ func (cmd *Push) bindAppToRoute(app cf.Application, params cf.AppParams, didCrea
 		}
 	}
 
-	cmd.ui.Say("Binding %s to %s...", terminal.EntityNameColor(domain.UrlForHost(hostName)), terminal.EntityNameColor(app.Name))
+	cmd.ui.Say("Binding %s to %s...", terminal.EntityNameColor(domain.UrlForHostAndPath(hostName, path)), terminal.EntityNameColor(app.Name))
 
 	apiResponse := cmd.routeRepo.Bind(route.Guid, app.Guid)
 	if apiResponse.IsNotSuccessful() {
@@ -249,6 +455,73 @@ func (cmd *Push) bindAppToRoute(app cf.Application, params cf.AppParams, didCrea
 
 	cmd.ui.Ok()
 	cmd.ui.Say("")
+	return
+}
+
+// pruneRoutes unbinds the routes of the app that were not just bound to it.
+func (cmd *Push) pruneRoutes(app cf.Application, boundRoutes []cf.Route) {
+	for _, appRoute := range app.Routes {
+		stillBound := false
+		for _, boundRoute := range boundRoutes {
+			if boundRoute.Guid == appRoute.Guid {
+				stillBound = true
+				break
+			}
+		}
+		if stillBound {
+			continue
+		}
+
+		cmd.ui.Say("Unbinding %s from %s...", terminal.EntityNameColor(appRoute.URL()), terminal.EntityNameColor(app.Name))
+
+		apiResponse := cmd.routeRepo.Unbind(appRoute.Guid, app.Guid)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+}
+
+// resolveRouteSpec finds the domain of a listed route. A route given as a url
+// is matched against the domains from the longest candidate down, so
+// www.example.com is the domain itself when it exists, and host www on
+// example.com otherwise.
+func (cmd *Push) resolveRouteSpec(c *cli.Context, spec cf.RouteSpec) (hostName, path string, domain cf.Domain) {
+	if spec.Url == "" {
+		return spec.Host, spec.Path, cmd.domain(c, spec.Domain)
+	}
+
+	domainName := spec.Url
+	if index := strings.Index(domainName, "/"); index >= 0 {
+		path = domainName[index:]
+		domainName = domainName[:index]
+	}
+
+	hostLabels := []string{}
+	for {
+		var apiResponse net.ApiResponse
+		domain, apiResponse = cmd.domainRepo.FindByNameInCurrentSpace(domainName)
+		if apiResponse.IsSuccessful() {
+			hostName = strings.Join(hostLabels, ".")
+			return
+		}
+		if apiResponse.IsError() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		index := strings.Index(domainName, ".")
+		if index < 0 {
+			domain = cf.Domain{}
+			cmd.ui.Failed("No domain found for route %s", spec.Url)
+			return
+		}
+		hostLabels = append(hostLabels, domainName[:index])
+		domainName = domainName[index+1:]
+	}
 }
 
 func (cmd *Push) restart(app cf.Application, params cf.AppParams, c *cli.Context) {
@@ -271,12 +544,16 @@ func (cmd *Push) restart(app cf.Application, params cf.AppParams, c *cli.Context
 	cmd.starter.ApplicationStart(app)
 }
 
-func (cmd *Push) route(hostName string, domain cf.DomainFields) (route cf.Route) {
-	route, apiResponse := cmd.routeRepo.FindByHostAndDomain(hostName, domain.Name)
+func (cmd *Push) route(hostName, path string, domain cf.DomainFields) (route cf.Route) {
+	route, apiResponse := cmd.routeRepo.FindByHostDomainAndPath(hostName, domain.Name, path)
 	if apiResponse.IsNotSuccessful() {
-		cmd.ui.Say("Creating route %s...", terminal.EntityNameColor(domain.UrlForHost(hostName)))
+		cmd.ui.Say("Creating route %s...", terminal.EntityNameColor(domain.UrlForHostAndPath(hostName, path)))
 
-		route, apiResponse = cmd.routeRepo.Create(hostName, domain.Guid)
+		if path == "" {
+			route, apiResponse = cmd.routeRepo.Create(hostName, domain.Guid)
+		} else {
+			route, apiResponse = cmd.routeRepo.CreateWithPath(hostName, path, domain.Guid)
+		}
 		if apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed(apiResponse.Message)
 			return
@@ -300,21 +577,17 @@ func (cmd *Push) domain(c *cli.Context, domainName string) (domain cf.Domain) {
 			cmd.ui.Failed(apiResponse.Message)
 		}
 	} else {
-		stopChan := make(chan bool, 1)
-		domainsChan, statusChan := cmd.domainRepo.ListDomainsForOrg(cmd.config.OrganizationFields.Guid, stopChan)
-
-		for domainsChunk := range domainsChan {
-			for _, d := range domainsChunk {
+		apiResponse = cmd.domainRepo.ListDomainsForOrg(cmd.config.OrganizationFields.Guid, func(domains []cf.Domain) bool {
+			for _, d := range domains {
 				if d.Shared {
 					domain = d
-					stopChan <- true
-					break
+					return false
 				}
 			}
-		}
+			return true
+		})
 
-		apiResponse, ok := <-statusChan
-		if (domain.Guid == "") && ok && apiResponse.IsNotSuccessful() {
+		if domain.Guid == "" && apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed(apiResponse.Message)
 		} else if domain.Guid == "" {
 			cmd.ui.Failed("No default domain exists")
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"cf"
+	"fmt"
+	"strings"
+)
+
+// orderAppsByDependencies sorts the apps so that every app comes after the apps
+// it depends on. Apps without dependencies keep their manifest order.
+func orderAppsByDependencies(appSet cf.AppSet) (ordered cf.AppSet, err error) {
+	appsByName := map[string]cf.AppParams{}
+	for _, appParams := range appSet {
+		if appParams.Has("name") {
+			appsByName[appParams.Get("name").(string)] = appParams
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	states := map[string]int{}
+	path := []string{}
+
+	var visit func(appParams cf.AppParams) error
+	visit = func(appParams cf.AppParams) error {
+		name := appParams.Get("name").(string)
+
+		switch states[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("Circular dependency between apps: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		states[name] = visiting
+		path = append(path, name)
+
+		for _, dependencyName := range appDependencies(appParams) {
+			dependency, found := appsByName[dependencyName]
+			if !found {
+				return fmt.Errorf("App %s depends on %s, which is not in the manifest", name, dependencyName)
+			}
+
+			err := visit(dependency)
+			if err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		states[name] = visited
+		ordered = append(ordered, appParams)
+		return nil
+	}
+
+	ordered = cf.NewEmptyAppSet()
+	for _, appParams := range appSet {
+		if !appParams.Has("name") {
+			ordered = append(ordered, appParams)
+			continue
+		}
+
+		err = visit(appParams)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func appDependencies(appParams cf.AppParams) (dependencies []string) {
+	if appParams.Has("depends_on") {
+		dependencies, _ = appParams.Get("depends_on").([]string)
+	}
+	return
+}
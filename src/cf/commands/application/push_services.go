@@ -0,0 +1,95 @@
+package application
+
+import (
+	"cf"
+	"cf/commands/service"
+	"cf/terminal"
+	"time"
+)
+
+const DefaultServiceTimeout = 10 * time.Minute
+
+// createDeclaredServices creates the service instances declared in the
+// manifest that do not exist yet, and waits for them to be ready so the apps
+// can be bound to them. Existing instances are left as they are.
+func (cmd *Push) createDeclaredServices() (ok bool) {
+	var offerings cf.ServiceOfferings
+
+	for _, declaration := range cmd.declaredServices {
+		_, apiResponse := cmd.serviceRepo.FindInstanceByName(declaration.Name)
+		if apiResponse.IsSuccessful() {
+			continue
+		}
+		if apiResponse.IsError() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		cmd.ui.Say("Creating service %s in org %s / space %s as %s...",
+			terminal.EntityNameColor(declaration.Name),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+
+		if offerings == nil {
+			offerings, apiResponse = cmd.serviceRepo.GetServiceOfferings()
+			if apiResponse.IsNotSuccessful() {
+				cmd.ui.Failed(apiResponse.Message)
+				return
+			}
+		}
+
+		plan, err := service.FindServicePlan(offerings, declaration.Label, declaration.Plan)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+
+		_, apiResponse = cmd.serviceRepo.CreateServiceInstance(declaration.Name, plan.Guid, declaration.Params, true)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+
+		if !cmd.waitForServiceInstance(declaration.Name) {
+			return
+		}
+	}
+
+	ok = true
+	return
+}
+
+func (cmd *Push) waitForServiceInstance(name string) (ready bool) {
+	startTime := time.Now()
+
+	for {
+		instance, apiResponse := cmd.serviceRepo.FindInstanceByName(name)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+
+		switch instance.LastOperation.State {
+		case cf.LastOperationFailed:
+			cmd.ui.Failed("Creating service %s failed: %s", name, instance.LastOperation.Description)
+			return
+		case cf.LastOperationInProgress:
+		default:
+			ready = true
+			return
+		}
+
+		if time.Since(startTime) > cmd.ServiceTimeout {
+			cmd.ui.Failed("Timed out waiting for service %s to be created", name)
+			return
+		}
+
+		cmd.ui.Say("Waiting for service %s to be ready...", terminal.EntityNameColor(name))
+		cmd.ui.Wait(cmd.PingerThrottle)
+	}
+}
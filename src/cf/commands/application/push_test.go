@@ -19,6 +19,7 @@ import (
 	testreq "testhelpers/requirements"
 	testterm "testhelpers/terminal"
 	"testing"
+	"time"
 )
 
 func TestPushingRequirements(t *testing.T) {
@@ -206,6 +207,113 @@ func TestPushingAppWithCustomFlags(t *testing.T) {
 	assert.Equal(t, deps.starter.AppToStart.Name, "")
 }
 
+func TestPushingAppWithDockerImage(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	ui := callPush(t, []string{
+		"--docker-image", "repo/my-new-app:1.0",
+		"-b", "some-buildpack",
+		"-s", "customLinux",
+		"--no-route",
+		"my-new-app",
+	}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Ignoring buildpack", "some-buildpack"},
+		{"Ignoring stack", "customLinux"},
+		{"Creating app", "my-new-app"},
+		{"OK"},
+		{"Using docker image", "repo/my-new-app:1.0", "my-new-app"},
+	})
+
+	createdParams := deps.appRepo.CreatedAppParams()
+	assert.Equal(t, createdParams.Get("docker_image").(string), "repo/my-new-app:1.0")
+	assert.False(t, createdParams.Has("buildpack"))
+	assert.False(t, createdParams.Has("stack_guid"))
+
+	assert.Equal(t, deps.stackRepo.FindByNameName, "")
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "")
+	assert.Equal(t, deps.starter.AppToStart.Name, "my-new-app")
+}
+
+func TestPushingAppWithDockerImageFromManifest(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("docker image")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{"--no-route"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Ignoring buildpack", "some-buildpack"},
+		{"Creating app", "docker-app"},
+		{"Using docker image", "repo/docker-app:1.0"},
+	})
+
+	createdParams := deps.appRepo.CreatedAppParams()
+	assert.Equal(t, createdParams.Get("docker_image").(string), "repo/docker-app:1.0")
+	assert.False(t, createdParams.Has("docker"))
+	assert.False(t, createdParams.Has("buildpack"))
+	assert.Equal(t, deps.appBitsRepo.UploadedAppGuid, "")
+}
+
+func TestPushingAppWithDockerImageAndPath(t *testing.T) {
+	deps := getPushDependencies()
+
+	ui := callPush(t, []string{"-p", "/some/path", "--docker-image", "repo/my-new-app:1.0", "my-new-app"}, deps)
+
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
+func TestPushingAppWithPathAndDockerImageFromManifest(t *testing.T) {
+	deps := getPushDependencies()
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("docker image")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{"-p", "/some/path"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"docker-app cannot have both a path and a docker image"},
+	})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
+func TestPushWithInvalidDockerImageInManifest(t *testing.T) {
+	deps := getPushDependencies()
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("invalid docker")))
+	deps.manifestRepo.ReadManifestManifest = m
+	deps.manifestRepo.ReadManifestErrors = errs
+
+	ui := callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error", "reading", "manifest"},
+		{"Expected docker to be a set with an image."},
+	})
+
+	deps = getPushDependencies()
+	m, errs = manifest.Parse(strings.NewReader(maker.ManifestWithName("docker image with path")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui = callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"docker-app-with-path cannot have both a path and a docker image"},
+	})
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
 func TestPushingAppWithInvalidTimeout(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -460,6 +568,184 @@ func TestPushWithInvalidManifestProperties(t *testing.T) {
 	})
 }
 
+func TestPushingAppWithDiskQuotaAndHealthCheckFromManifest(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("health check")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("name").(string), "health-checked-app")
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("disk_quota").(uint64), uint64(2048))
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("health_check_type").(string), "http")
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("health_check_http_endpoint").(string), "/health")
+}
+
+func TestPushWithInvalidHealthCheckInManifest(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("invalid health check")))
+	deps.manifestRepo.ReadManifestManifest = m
+	deps.manifestRepo.ReadManifestErrors = errs
+
+	ui := callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error", "reading", "manifest"},
+		{"Invalid health check type", "process"},
+		{"endpoint can only be set with the http health check type"},
+	})
+}
+
+func TestPushingAppWithRoutesFromManifest(t *testing.T) {
+	deps := getPushDependencies()
+	domain := cf.Domain{}
+	domain.Name = "example.com"
+	domain.Guid = "example-domain-guid"
+	deps.domainRepo.FindByNameDomain = domain
+	deps.routeRepo.FindByHostAndDomainNotFound = true
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("multiple routes")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating route", "www.example.com"},
+		{"Binding", "www.example.com", "routed-app"},
+		{"Creating route", "api.example.com/v1"},
+		{"Binding", "api.example.com/v1", "routed-app"},
+		{"Creating route", "example.com"},
+		{"Binding", "example.com", "routed-app"},
+	})
+
+	assert.Equal(t, deps.domainRepo.FindByNameInCurrentSpaceName, "example.com")
+	assert.Equal(t, deps.routeRepo.CreatedHosts, []string{"www", "api", ""})
+	assert.Equal(t, deps.routeRepo.BoundRouteGuids, []string{"www-route-guid", "api-route-guid", "-route-guid"})
+	assert.Equal(t, deps.routeRepo.BoundAppGuid, "routed-app-guid")
+}
+
+func TestPushWithInvalidRoutesInManifest(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("invalid routes")))
+	deps.manifestRepo.ReadManifestManifest = m
+	deps.manifestRepo.ReadManifestErrors = errs
+
+	ui := callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error", "reading", "manifest"},
+		{"Expected routes to be an array"},
+		{"Route path v1 should start with /"},
+	})
+}
+
+func TestPushingAppWithRouteFlag(t *testing.T) {
+	deps := getPushDependencies()
+	domain := cf.Domain{}
+	domain.Name = "example.com"
+	domain.Guid = "example-domain-guid"
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = []cf.Domain{domain}
+	deps.routeRepo.FindByHostAndDomainNotFound = true
+	deps.appRepo.ReadNotFound = true
+
+	ui := callPush(t, []string{"--route", "my.blog.example.com/posts,example.com", "my-new-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating route", "my.blog.example.com/posts"},
+		{"Binding", "my.blog.example.com/posts", "my-new-app"},
+		{"Creating route", "example.com"},
+		{"Binding", "example.com", "my-new-app"},
+	})
+
+	assert.Equal(t, deps.routeRepo.CreatedHosts, []string{"my.blog", ""})
+	assert.Equal(t, deps.routeRepo.BoundRouteGuids, []string{"my.blog-route-guid", "-route-guid"})
+}
+
+func TestPushingAppWithRouteFlagForAnUnknownDomain(t *testing.T) {
+	deps := getPushDependencies()
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = []cf.Domain{}
+	deps.appRepo.ReadNotFound = true
+
+	ui := callPush(t, []string{"--route", "www.example.com", "my-new-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"No domain found for route www.example.com"},
+	})
+	assert.Equal(t, len(deps.routeRepo.BoundRouteGuids), 0)
+}
+
+func TestPushingAppWithRandomRoute(t *testing.T) {
+	deps := getPushDependencies()
+	domain := cf.Domain{}
+	domain.Name = "example.com"
+	domain.Guid = "example-domain-guid"
+	domain.Shared = true
+	deps.domainRepo.ListDomainsForOrgDomains = []cf.Domain{domain}
+	deps.routeRepo.FindByHostAndDomainNotFound = true
+	deps.routeRepo.FindByHostAndDomainTakenCount = 1
+	deps.appRepo.ReadNotFound = true
+
+	callPush(t, []string{"--random-route", "My_App"}, deps)
+
+	probed := deps.routeRepo.FindByHostAndDomainHosts
+	assert.True(t, len(probed) >= 2)
+	assert.True(t, strings.HasPrefix(probed[0], "my-app-"))
+	assert.True(t, strings.HasPrefix(probed[1], "my-app-"))
+	assert.Equal(t, deps.routeRepo.CreatedHost, probed[1])
+	assert.Equal(t, deps.routeRepo.CreatedDomainGuid, "example-domain-guid")
+	assert.Equal(t, deps.routeRepo.BoundRouteGuid, probed[1]+"-route-guid")
+}
+
+func TestPushingAppWithPruneRoutes(t *testing.T) {
+	deps := getPushDependencies()
+	domain := cf.Domain{}
+	domain.Name = "example.com"
+	domain.Guid = "example-domain-guid"
+	deps.domainRepo.FindByNameInCurrentSpaceDomains = []cf.Domain{domain}
+
+	keptRoute := cf.RouteSummary{}
+	keptRoute.Guid = "kept-route-guid"
+	keptRoute.Host = "kept"
+	keptRoute.Domain = domain.DomainFields
+
+	oldRoute := cf.RouteSummary{}
+	oldRoute.Guid = "old-route-guid"
+	oldRoute.Host = "old"
+	oldRoute.Domain = domain.DomainFields
+
+	existingApp := cf.Application{}
+	existingApp.Name = "existing-app"
+	existingApp.Guid = "existing-app-guid"
+	existingApp.Routes = []cf.RouteSummary{keptRoute, oldRoute}
+	deps.appRepo.ReadApp = existingApp
+	deps.appRepo.UpdateAppResult = existingApp
+
+	foundRoute := cf.Route{}
+	foundRoute.RouteSummary = keptRoute
+	deps.routeRepo.FindByHostAndDomainRoute = foundRoute
+
+	ui := callPush(t, []string{"--route", "kept.example.com", "--prune-routes", "existing-app"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Using route", "kept.example.com"},
+		{"Unbinding", "old.example.com", "existing-app"},
+		{"OK"},
+	})
+	assert.Equal(t, len(deps.routeRepo.BoundRouteGuids), 0)
+	assert.Equal(t, deps.routeRepo.UnboundRouteGuids, []string{"old-route-guid"})
+	assert.Equal(t, deps.routeRepo.UnboundAppGuid, "existing-app-guid")
+}
+
 func TestPushWithServicesThatAreNotFound(t *testing.T) {
 	deps := getPushDependencies()
 	deps.routeRepo.FindByHostAndDomainErr = true
@@ -476,6 +762,221 @@ func TestPushWithServicesThatAreNotFound(t *testing.T) {
 	})
 }
 
+func TestPushingDependentAppsInDependencyOrder(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("dependent apps")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	callPush(t, []string{"--no-route"}, deps)
+
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 3)
+	assert.Equal(t, deps.appRepo.CreateAppParams[0].Get("name").(string), "backend")
+	assert.Equal(t, deps.appRepo.CreateAppParams[1].Get("name").(string), "frontend")
+	assert.Equal(t, deps.appRepo.CreateAppParams[2].Get("name").(string), "worker")
+	assert.Equal(t, deps.starter.StartedApps, []string{"backend", "frontend", "worker"})
+}
+
+func TestPushingAppsConcurrently(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("dependent apps")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{"--max-in-flight", "3", "--no-route"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"[backend]", "Creating app", "backend"}})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"[frontend]", "Creating app", "frontend"}})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"[worker]", "Uploading", "worker"}})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app", "status", "details"},
+		{"backend", "pushed"},
+		{"frontend", "pushed"},
+		{"worker", "pushed"},
+		{"OK"},
+	})
+
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 3)
+	assert.Equal(t, len(deps.starter.UIs), 3)
+	assert.Equal(t, len(deps.starter.StartedApps), 3)
+
+	backendIndex, frontendIndex := -1, -1
+	for index, appName := range deps.starter.StartedApps {
+		switch appName {
+		case "backend":
+			backendIndex = index
+		case "frontend":
+			frontendIndex = index
+		}
+	}
+	assert.True(t, backendIndex >= 0 && backendIndex < frontendIndex)
+}
+
+func TestPushingAppsConcurrentlyWhenOneFails(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+	deps.serviceRepo.FindInstanceByNameErr = true
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("dependent apps")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{"--max-in-flight", "2", "--no-route"}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"[backend]", "FAILED"},
+		{"[backend]", "Could not find service", "backend-db", "backend"},
+	})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"[frontend]", "Not starting frontend", "backend failed to push"},
+	})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"app", "status", "details"},
+		{"backend", "failed", "Could not find service backend-db"},
+		{"frontend", "failed", "Not starting frontend"},
+		{"worker", "pushed"},
+		{"FAILED"},
+		{"2 of 3 apps failed to push"},
+	})
+
+	assert.Equal(t, deps.starter.StartedApps, []string{"worker"})
+}
+
+func TestPushWithInvalidDependencies(t *testing.T) {
+	deps := getPushDependencies()
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("invalid dependencies")))
+	deps.manifestRepo.ReadManifestManifest = m
+	deps.manifestRepo.ReadManifestErrors = errs
+
+	ui := callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error", "reading", "manifest"},
+		{"Expected depends_on to be an array of app names."},
+	})
+
+	deps = getPushDependencies()
+	m, errs = manifest.Parse(strings.NewReader(maker.ManifestWithName("unknown dependency")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui = callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"frontend depends on backend", "not in the manifest"},
+	})
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+
+	deps = getPushDependencies()
+	m, errs = manifest.Parse(strings.NewReader(maker.ManifestWithName("circular dependencies")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui = callPush(t, []string{}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Circular dependency", "frontend -> backend -> frontend"},
+	})
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
+func TestPushingWithInvalidMaxInFlight(t *testing.T) {
+	deps := getPushDependencies()
+
+	ui := callPush(t, []string{"--max-in-flight", "0", "my-new-app"}, deps)
+	assert.True(t, ui.FailedWithUsage)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func getDeclaredServicesDependencies() (deps pushDependencies) {
+	deps = getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	plan := cf.ServicePlanFields{}
+	plan.Name = "spark"
+	plan.Guid = "cleardb-spark-guid"
+	offering := cf.ServiceOffering{}
+	offering.Label = "cleardb"
+	offering.Plans = []cf.ServicePlanFields{plan}
+	deps.serviceRepo.ServiceOfferings = []cf.ServiceOffering{offering}
+
+	existingInstance := cf.ServiceInstance{}
+	existingInstance.Name = "existing-db"
+	deps.serviceRepo.FindInstanceByNameMap = generic.NewMap(map[interface{}]interface{}{
+		"existing-db": existingInstance,
+	})
+	deps.serviceRepo.FindInstanceByNameNotFound = true
+	return
+}
+
+func TestPushingCreatesMissingDeclaredServices(t *testing.T) {
+	deps := getDeclaredServicesDependencies()
+	deps.serviceRepo.FindInstanceByNameLastOperationStates = []string{cf.LastOperationInProgress, cf.LastOperationSucceeded}
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("declared services")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{}, deps)
+
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceNames, []string{"new-db"})
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstancePlanGuid, "cleardb-spark-guid")
+	assert.Equal(t, deps.serviceRepo.CreateServiceInstanceParams, map[string]interface{}{"max_connections": 10})
+	assert.True(t, deps.serviceRepo.CreateServiceInstanceAcceptsIncomplete)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating service", "new-db", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"Waiting for service", "new-db"},
+		{"Creating app", "db-app"},
+		{"Binding service", "new-db", "db-app"},
+		{"Binding service", "existing-db", "db-app"},
+	})
+	assert.Equal(t, len(deps.binder.AppsToBind), 2)
+}
+
+func TestPushingWhenDeclaredServiceFailsToCreate(t *testing.T) {
+	deps := getDeclaredServicesDependencies()
+	deps.serviceRepo.FindInstanceByNameLastOperationStates = []string{cf.LastOperationFailed}
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("declared services")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Creating service", "new-db"},
+		{"OK"},
+		{"FAILED"},
+		{"Creating service new-db failed"},
+	})
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
+func TestPushingWithDeclaredServiceOfUnknownPlan(t *testing.T) {
+	deps := getDeclaredServicesDependencies()
+	deps.serviceRepo.ServiceOfferings = []cf.ServiceOffering{}
+
+	m, errs := manifest.Parse(strings.NewReader(maker.ManifestWithName("declared services")))
+	testassert.AssertNoErrors(t, errs)
+	deps.manifestRepo.ReadManifestManifest = m
+
+	ui := callPush(t, []string{}, deps)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Could not find offering", "cleardb"},
+	})
+	assert.Equal(t, len(deps.serviceRepo.CreateServiceInstanceNames), 0)
+	assert.Equal(t, len(deps.appRepo.CreateAppParams), 0)
+}
+
 func TestPushingAppWithPath(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -566,6 +1067,57 @@ func TestPushingAppWithMemoryWithoutUnit(t *testing.T) {
 	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("memory").(uint64), uint64(512))
 }
 
+func TestPushingAppWithDiskQuotaAndHealthCheckFlags(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	callPush(t, []string{
+		"-k", "1G",
+		"--health-check-type", "none",
+		"my-new-app",
+	}, deps)
+
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("disk_quota").(uint64), uint64(1024))
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("health_check_type").(string), "none")
+	assert.False(t, deps.appRepo.CreatedAppParams().Has("health_check_http_endpoint"))
+}
+
+func TestPushingAppWithAHealthCheckEndpointUsesTheHttpType(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	callPush(t, []string{
+		"--health-check-http-endpoint", "/status",
+		"my-new-app",
+	}, deps)
+
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("health_check_type").(string), "http")
+	assert.Equal(t, deps.appRepo.CreatedAppParams().Get("health_check_http_endpoint").(string), "/status")
+}
+
+func TestPushingAppWithInvalidDiskQuotaOrHealthCheck(t *testing.T) {
+	deps := getPushDependencies()
+	deps.appRepo.ReadNotFound = true
+
+	ui := callPush(t, []string{"-k", "abcM", "my-new-app"}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"invalid", "disk quota"},
+	})
+
+	ui = callPush(t, []string{"--health-check-type", "process", "my-new-app"}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Invalid health check type", "process"},
+	})
+
+	ui = callPush(t, []string{"--health-check-type", "port", "--health-check-http-endpoint", "/status", "my-new-app"}, deps)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"endpoint can only be set with the http health check type"},
+	})
+}
+
 func TestPushingAppWithInvalidMemory(t *testing.T) {
 	deps := getPushDependencies()
 	deps.appRepo.ReadNotFound = true
@@ -936,6 +1488,7 @@ func callPush(t *testing.T, args []string, deps pushDependencies) (ui *testterm.
 	serviceRepo := deps.serviceRepo
 
 	cmd := NewPush(ui, config, manifestRepo, starter, stopper, binder, appRepo, domainRepo, routeRepo, stackRepo, serviceRepo, appBitsRepo)
+	cmd.PingerThrottle = 5 * time.Millisecond
 	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
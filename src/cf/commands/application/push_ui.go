@@ -0,0 +1,90 @@
+package application
+
+import (
+	"cf/terminal"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// pushUI is the output of a single app during a concurrent push. Every line is
+// prefixed with the app name, and a failure ends the app's push instead of the
+// whole command, so the other apps can carry on.
+type pushUI struct {
+	terminal.UI
+	prefix  string
+	mutex   *sync.Mutex
+	failure string
+}
+
+func newPushUI(ui terminal.UI, appName string, mutex *sync.Mutex) *pushUI {
+	return &pushUI{
+		UI:     ui,
+		prefix: fmt.Sprintf("[%s]", appName),
+		mutex:  mutex,
+	}
+}
+
+func (ui *pushUI) Say(message string, args ...interface{}) {
+	message = fmt.Sprintf(message, args...)
+
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+
+	for _, line := range strings.Split(message, "\n") {
+		ui.UI.Say("%s %s", ui.prefix, line)
+	}
+}
+
+func (ui *pushUI) Warn(message string, args ...interface{}) {
+	ui.Say(terminal.WarningColor(fmt.Sprintf(message, args...)))
+}
+
+func (ui *pushUI) Ok() {
+	ui.Say(terminal.SuccessColor("OK"))
+}
+
+// Failed records the failure and stops the goroutine pushing the app.
+func (ui *pushUI) Failed(message string, args ...interface{}) {
+	message = fmt.Sprintf(message, args...)
+	ui.Say(terminal.FailureColor("FAILED"))
+	ui.Say(message)
+
+	ui.failure = message
+	runtime.Goexit()
+}
+
+func (ui *pushUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed(err.Error())
+		return
+	}
+
+	for _, row := range rows {
+		ui.Say(row)
+	}
+}
+
+func (ui *pushUI) DisplayTable(table [][]string) {
+	if len(table) == 0 {
+		return
+	}
+	ui.Table(table[0]).Print(table[1:])
+}
+
+func (ui *pushUI) Table(headers []string) terminal.Table {
+	return terminal.NewTable(ui, headers)
+}
+
+// run calls task and returns the failure message, if the task failed.
+func (ui *pushUI) run(task func()) (failure string) {
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		task()
+	}()
+	<-done
+
+	return ui.failure
+}
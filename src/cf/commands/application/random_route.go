@@ -0,0 +1,50 @@
+package application
+
+import (
+	"cf"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxRandomRouteAttempts = 10
+
+var (
+	randomRouteAdjectives = []string{"amber", "brave", "calm", "dusty", "eager", "fuzzy", "gentle", "happy", "icy", "jolly", "lucky", "misty", "noble", "quiet", "rapid", "sunny", "tidy", "vivid", "witty", "young"}
+	randomRouteNouns      = []string{"badger", "canyon", "dolphin", "ember", "falcon", "glacier", "harbor", "island", "jaguar", "lagoon", "meadow", "nebula", "otter", "pebble", "quartz", "river", "summit", "tiger", "valley", "walrus"}
+	randomRouteSource     = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomRouteMutex      = new(sync.Mutex)
+)
+
+// randomRouteWord picks a word; the source is shared by concurrent pushes.
+func randomRouteWord(words []string) string {
+	randomRouteMutex.Lock()
+	defer randomRouteMutex.Unlock()
+
+	return words[randomRouteSource.Intn(len(words))]
+}
+
+// randomHostname appends random words to the app name until the host is not
+// taken on the domain yet.
+func (cmd *Push) randomHostname(appName string, domain cf.DomainFields) (hostName string) {
+	prefix := strings.Replace(strings.ToLower(appName), "_", "-", -1)
+
+	for attempt := 0; attempt < maxRandomRouteAttempts; attempt++ {
+		candidate := fmt.Sprintf("%s-%s-%s", prefix, randomRouteWord(randomRouteAdjectives), randomRouteWord(randomRouteNouns))
+
+		_, apiResponse := cmd.routeRepo.FindByHostAndDomain(candidate, domain.Name)
+		if apiResponse.IsNotFound() {
+			hostName = candidate
+			return
+		}
+		if apiResponse.IsError() {
+			cmd.ui.Failed(apiResponse.Message)
+			return
+		}
+	}
+
+	cmd.ui.Failed("Could not find a free random route for %s on %s", appName, domain.Name)
+	return
+}
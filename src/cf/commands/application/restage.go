@@ -0,0 +1,60 @@
+package application
+
+import (
+	"cf"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type Restage struct {
+	ui       terminal.UI
+	restager ApplicationRestager
+	appReq   requirements.ApplicationRequirement
+}
+
+type ApplicationRestager interface {
+	SetStagingTimeoutMinutes(timeout int)
+	SetStartupTimeoutMinutes(timeout int)
+	ApplicationRestage(app cf.Application) (updatedApp cf.Application, err error)
+}
+
+func NewRestage(ui terminal.UI, restager ApplicationRestager) (cmd *Restage) {
+	cmd = new(Restage)
+	cmd.ui = ui
+	cmd.restager = restager
+	return
+}
+
+func (cmd *Restage) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 || c.Int("staging-timeout") < 0 || c.Int("startup-timeout") < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restage")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *Restage) Run(c *cli.Context) {
+	if c.Int("staging-timeout") > 0 {
+		cmd.restager.SetStagingTimeoutMinutes(c.Int("staging-timeout"))
+	}
+	if c.Int("startup-timeout") > 0 {
+		cmd.restager.SetStartupTimeoutMinutes(c.Int("startup-timeout"))
+	}
+
+	_, err := cmd.restager.ApplicationRestage(cmd.appReq.GetApplication())
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+}
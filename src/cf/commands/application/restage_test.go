@@ -0,0 +1,79 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"github.com/stretchr/testify/assert"
+	testcmd "testhelpers/commands"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestRestageFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	restager := &testcmd.FakeAppRestager{}
+
+	ui := callRestage([]string{}, reqFactory, restager)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestage([]string{"--staging-timeout", "-1", "my-app"}, reqFactory, restager)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestage([]string{"my-app"}, reqFactory, restager)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRestageRequirements(t *testing.T) {
+	restager := &testcmd.FakeAppRestager{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callRestage([]string{"my-app"}, reqFactory, restager)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callRestage([]string{"my-app"}, reqFactory, restager)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callRestage([]string{"my-app"}, reqFactory, restager)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.ApplicationName, "my-app")
+}
+
+func TestRestageApplication(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	restager := &testcmd.FakeAppRestager{}
+
+	callRestage([]string{"my-app"}, reqFactory, restager)
+
+	assert.Equal(t, restager.AppToRestage, app)
+	assert.Equal(t, restager.StagingTimeout, 0)
+	assert.Equal(t, restager.StartupTimeout, 0)
+}
+
+func TestRestageApplicationWithTimeouts(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	restager := &testcmd.FakeAppRestager{}
+
+	callRestage([]string{"--staging-timeout", "20", "--startup-timeout", "8", "my-app"}, reqFactory, restager)
+
+	assert.Equal(t, restager.AppToRestage, app)
+	assert.Equal(t, restager.StagingTimeout, 20)
+	assert.Equal(t, restager.StartupTimeout, 8)
+}
+
+func callRestage(args []string, reqFactory *testreq.FakeReqFactory, restager ApplicationRestager) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("restage", args)
+
+	cmd := NewRestage(ui, restager)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"strconv"
+	"time"
+)
+
+type RestartAppInstance struct {
+	ui               terminal.UI
+	config           *configuration.Configuration
+	appInstancesRepo api.AppInstancesRepository
+	appReq           requirements.ApplicationRequirement
+
+	StartupTimeout time.Duration
+	PingerThrottle time.Duration
+}
+
+func NewRestartAppInstance(ui terminal.UI, config *configuration.Configuration, appInstancesRepo api.AppInstancesRepository) (cmd *RestartAppInstance) {
+	cmd = new(RestartAppInstance)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appInstancesRepo = appInstancesRepo
+
+	cmd.StartupTimeout = DefaultStartupTimeout
+	cmd.PingerThrottle = DefaultPingerThrottle
+	return
+}
+
+func (cmd *RestartAppInstance) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restart-app-instance")
+		return
+	}
+
+	index, err := strconv.Atoi(c.Args()[1])
+	if err != nil || index < 0 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "restart-app-instance")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *RestartAppInstance) Run(c *cli.Context) {
+	app := cmd.appReq.GetApplication()
+	index, _ := strconv.Atoi(c.Args()[1])
+
+	if index >= app.InstanceCount {
+		cmd.ui.Failed("Instance #%d does not exist, app %s has %d instances", index, app.Name, app.InstanceCount)
+		return
+	}
+
+	cmd.ui.Say("Restarting instance #%d of app %s in org %s / space %s as %s...",
+		index,
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	// the replacement is told apart from the instance being terminated by
+	// its start time, as both report the same index
+	instances, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Error getting instances of app %s\n%s", app.Name, apiResponse.Message)
+		return
+	}
+	if index >= len(instances) {
+		cmd.ui.Failed("Instance #%d of app %s is not reported by the API", index, app.Name)
+		return
+	}
+	previousSince := instances[index].Since
+
+	apiResponse = cmd.appInstancesRepo.DeleteInstance(app.Guid, index)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if !cmd.waitForInstanceToRun(app.Guid, index, previousSince) {
+		return
+	}
+
+	cmd.ui.Say(terminal.HeaderColor("\nInstance #%d running\n"), index)
+}
+
+func (cmd *RestartAppInstance) waitForInstanceToRun(appGuid string, index int, previousSince time.Time) (running bool) {
+	var lastState cf.InstanceState
+	startupStartTime := time.Now()
+
+	for {
+		if time.Since(startupStartTime) > cmd.StartupTimeout {
+			cmd.ui.Failed("Restart instance timeout")
+			return
+		}
+
+		instances, apiResponse := cmd.appInstancesRepo.GetInstances(appGuid)
+		if apiResponse.IsNotSuccessful() || index >= len(instances) {
+			cmd.ui.Wait(cmd.PingerThrottle)
+			continue
+		}
+
+		instance := instances[index]
+		replaced := instance.Since.After(previousSince)
+
+		if instance.State != lastState && (replaced || instance.State != cf.InstanceRunning) {
+			lastState = instance.State
+			cmd.ui.Say("instance #%d: %s", index, coloredInstanceState(instance))
+		}
+
+		switch instance.State {
+		case cf.InstanceRunning:
+			if replaced {
+				running = true
+				return
+			}
+		case cf.InstanceFlapping:
+			cmd.ui.Failed("Instance #%d failed to start", index)
+			return
+		}
+
+		cmd.ui.Wait(cmd.PingerThrottle)
+	}
+}
@@ -0,0 +1,207 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestRestartAppInstanceFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{}
+
+	ui := callRestartAppInstance(t, []string{}, reqFactory, appInstancesRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestartAppInstance(t, []string{"my-app"}, reqFactory, appInstancesRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestartAppInstance(t, []string{"my-app", "one"}, reqFactory, appInstancesRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestartAppInstance(t, []string{"my-app", "-1"}, reqFactory, appInstancesRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRestartAppInstance(t, []string{"my-app", "1"}, reqFactory, appInstancesRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRestartAppInstanceRequirements(t *testing.T) {
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callRestartAppInstance(t, []string{"my-app", "0"}, reqFactory, appInstancesRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callRestartAppInstance(t, []string{"my-app", "0"}, reqFactory, appInstancesRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	callRestartAppInstance(t, []string{"my-app", "0"}, reqFactory, appInstancesRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.ApplicationName, "my-app")
+}
+
+func TestRestartAppInstance(t *testing.T) {
+	previousSince := time.Now().Add(-time.Hour)
+	replacementSince := time.Now()
+
+	running := cf.AppInstanceFields{State: cf.InstanceRunning, Since: previousSince}
+	stillRunning := cf.AppInstanceFields{State: cf.InstanceRunning, Since: previousSince}
+	down := cf.AppInstanceFields{State: cf.InstanceDown, Since: previousSince}
+	starting := cf.AppInstanceFields{State: cf.InstanceStarting, Since: replacementSince}
+	replacement := cf.AppInstanceFields{State: cf.InstanceRunning, Since: replacementSince}
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{running, running},
+			[]cf.AppInstanceFields{running, stillRunning},
+			[]cf.AppInstanceFields{running, down},
+			[]cf.AppInstanceFields{running, starting},
+			[]cf.AppInstanceFields{running, replacement},
+		},
+	}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "1"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Restarting instance #1 of app", "my-app", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"instance #1", "down"},
+		{"instance #1", "starting"},
+		{"instance #1", "running"},
+		{"Instance #1 running"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+	})
+	assert.Equal(t, appInstancesRepo.DeleteInstanceAppGuid, "my-app-guid")
+	assert.Equal(t, appInstancesRepo.DeleteInstanceIndex, 1)
+}
+
+func TestRestartAppInstanceWhenTheIndexIsOutOfRange(t *testing.T) {
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "2"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Instance #2 does not exist", "my-app", "2 instances"},
+	})
+	assert.Equal(t, appInstancesRepo.DeleteInstanceAppGuid, "")
+}
+
+func TestRestartAppInstanceWhenTheReplacementFlaps(t *testing.T) {
+	previousSince := time.Now().Add(-time.Hour)
+	flapping := cf.AppInstanceFields{State: cf.InstanceFlapping, Since: time.Now()}
+	running := cf.AppInstanceFields{State: cf.InstanceRunning, Since: previousSince}
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{running, running},
+			[]cf.AppInstanceFields{flapping, running},
+		},
+	}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "0"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"instance #0", "crashing"},
+		{"FAILED"},
+		{"Instance #0 failed to start"},
+	})
+}
+
+func TestRestartAppInstanceWhenTheInstancesCannotBeRead(t *testing.T) {
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses:  [][]cf.AppInstanceFields{[]cf.AppInstanceFields{}},
+		GetInstancesErrorCodes: []string{"500"},
+	}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "0"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error getting instances of app", "my-app"},
+	})
+	assert.Equal(t, appInstancesRepo.DeleteInstanceAppGuid, "")
+}
+
+func TestRestartAppInstanceWhenTerminatingFails(t *testing.T) {
+	running := cf.AppInstanceFields{State: cf.InstanceRunning, Since: time.Now()}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{[]cf.AppInstanceFields{running, running}},
+		DeleteInstanceErr:     true,
+	}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "0"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error stopping instance"},
+	})
+}
+
+func TestRestartAppInstanceTimesOut(t *testing.T) {
+	running := cf.AppInstanceFields{State: cf.InstanceRunning, Since: time.Now()}
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			[]cf.AppInstanceFields{running, running},
+			[]cf.AppInstanceFields{running, running},
+			[]cf.AppInstanceFields{running, running},
+		},
+	}
+
+	ui := callRestartAppInstance(t, []string{"my-app", "0"}, getRestartAppInstanceReqFactory(), appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"FAILED"},
+		{"Restart instance timeout"},
+	})
+}
+
+func getRestartAppInstanceReqFactory() *testreq.FakeReqFactory {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.InstanceCount = 2
+	return &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+}
+
+func callRestartAppInstance(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, appInstancesRepo *testapi.FakeAppInstancesRepo) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("restart-app-instance", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewRestartAppInstance(ui, config, appInstancesRepo)
+	cmd.StartupTimeout = 50 * time.Millisecond
+	cmd.PingerThrottle = 5 * time.Millisecond
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -9,6 +9,7 @@ import (
 	"cf/terminal"
 	"errors"
 	"github.com/codegangsta/cli"
+	"strconv"
 )
 
 type Scale struct {
@@ -17,14 +18,16 @@ type Scale struct {
 	restarter ApplicationRestarter
 	appReq    requirements.ApplicationRequirement
 	appRepo   api.ApplicationRepository
+	orgRepo   api.OrganizationRepository
 }
 
-func NewScale(ui terminal.UI, config *configuration.Configuration, restarter ApplicationRestarter, appRepo api.ApplicationRepository) (cmd *Scale) {
+func NewScale(ui terminal.UI, config *configuration.Configuration, restarter ApplicationRestarter, appRepo api.ApplicationRepository, orgRepo api.OrganizationRepository) (cmd *Scale) {
 	cmd = new(Scale)
 	cmd.ui = ui
 	cmd.config = config
 	cmd.restarter = restarter
 	cmd.appRepo = appRepo
+	cmd.orgRepo = orgRepo
 	return
 }
 
@@ -36,7 +39,7 @@ func (cmd *Scale) GetRequirements(reqFactory requirements.Factory, c *cli.Contex
 		return
 	}
 
-	if c.Int("i") == -1 && c.String("m") == "" {
+	if c.Int("i") == -1 && c.String("m") == "" && c.String("k") == "" {
 		err = errors.New("Incorrect Usage")
 		cmd.ui.FailWithUsage(c, "scale")
 		return
@@ -62,6 +65,7 @@ func (cmd *Scale) Run(c *cli.Context) {
 	)
 
 	params := cf.NewEmptyAppParams()
+	scaledApp := currentApp
 
 	if c.String("m") != "" {
 		memory, err := extractMegaBytes(c.String("m"))
@@ -71,12 +75,36 @@ func (cmd *Scale) Run(c *cli.Context) {
 			return
 		}
 		params.Set("memory", memory)
+		scaledApp.Memory = memory
+	}
+
+	if c.String("k") != "" {
+		diskQuota, err := extractMegaBytes(c.String("k"))
+		if err != nil {
+			cmd.ui.Say("Invalid value for disk quota")
+			cmd.ui.FailWithUsage(c, "scale")
+			return
+		}
+		params.Set("disk_quota", diskQuota)
+		scaledApp.DiskQuota = diskQuota
 	}
 
 	if c.Int("i") != -1 {
 		params.Set("instances", c.Int("i"))
+		scaledApp.InstanceCount = c.Int("i")
 	}
 
+	cmd.ui.Say("")
+	cmd.ui.DisplayTable([][]string{
+		[]string{"", "before", "after"},
+		[]string{"instances", strconv.Itoa(currentApp.InstanceCount), strconv.Itoa(scaledApp.InstanceCount)},
+		[]string{"memory", megabytesToString(currentApp.Memory), megabytesToString(scaledApp.Memory)},
+		[]string{"disk", megabytesToString(currentApp.DiskQuota), megabytesToString(scaledApp.DiskQuota)},
+	})
+	cmd.ui.Say("")
+
+	cmd.warnAboutOrgQuota(currentApp, scaledApp)
+
 	_, apiResponse := cmd.appRepo.Update(currentApp.Guid, params)
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
@@ -87,6 +115,32 @@ func (cmd *Scale) Run(c *cli.Context) {
 	cmd.ui.Say("")
 }
 
+// warnAboutOrgQuota warns when the extra memory the scaled app needs is more
+// than the org quota has left. The cloud controller has the final say, so the
+// app is scaled anyway.
+func (cmd *Scale) warnAboutOrgQuota(currentApp, scaledApp cf.Application) {
+	currentMemory := currentApp.Memory * uint64(currentApp.InstanceCount)
+	scaledMemory := scaledApp.Memory * uint64(scaledApp.InstanceCount)
+	if scaledMemory <= currentMemory {
+		return
+	}
+
+	available, limited := orgMemoryAvailable(cmd.orgRepo, cmd.config)
+	if !limited {
+		return
+	}
+
+	increase := scaledMemory - currentMemory
+	if increase > available {
+		cmd.ui.Warn("Scaling needs %s more memory, but only %s is left in the quota of org %s",
+			megabytesToString(increase), megabytesToString(available), cmd.config.OrganizationFields.Name)
+	}
+}
+
+func megabytesToString(megabytes uint64) string {
+	return formatters.ByteSize(megabytes * formatters.MEGABYTE)
+}
+
 func extractMegaBytes(arg string) (megaBytes uint64, err error) {
 	if arg != "" {
 		var byteSize uint64
@@ -17,29 +17,29 @@ import (
 
 func TestScaleRequirements(t *testing.T) {
 	args := []string{"-m", "1G", "my-app"}
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 
 	reqFactory.LoginSuccess = false
 	reqFactory.TargetedSpaceSuccess = true
-	callScale(t, args, reqFactory, restarter, appRepo)
+	callScale(t, args, reqFactory, restarter, appRepo, orgRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory.LoginSuccess = true
 	reqFactory.TargetedSpaceSuccess = false
-	callScale(t, args, reqFactory, restarter, appRepo)
+	callScale(t, args, reqFactory, restarter, appRepo, orgRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory.LoginSuccess = true
 	reqFactory.TargetedSpaceSuccess = true
-	callScale(t, args, reqFactory, restarter, appRepo)
+	callScale(t, args, reqFactory, restarter, appRepo, orgRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 	assert.Equal(t, reqFactory.ApplicationName, "my-app")
 }
 
 func TestScaleFailsWithUsage(t *testing.T) {
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 
-	ui := callScale(t, []string{}, reqFactory, restarter, appRepo)
+	ui := callScale(t, []string{}, reqFactory, restarter, appRepo, orgRepo)
 
 	assert.True(t, ui.FailedWithUsage)
 	assert.False(t, testcmd.CommandDidPassRequirements)
@@ -47,11 +47,11 @@ func TestScaleFailsWithUsage(t *testing.T) {
 
 func TestScaleFailsWithoutFlags(t *testing.T) {
 	args := []string{"my-app"}
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 	reqFactory.LoginSuccess = true
 	reqFactory.TargetedSpaceSuccess = true
 
-	callScale(t, args, reqFactory, restarter, appRepo)
+	callScale(t, args, reqFactory, restarter, appRepo, orgRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 }
 
@@ -59,10 +59,10 @@ func TestScaleAll(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 	reqFactory.Application = app
 
-	ui := callScale(t, []string{"-i", "5", "-m", "512M", "my-app"}, reqFactory, restarter, appRepo)
+	ui := callScale(t, []string{"-i", "5", "-m", "512M", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
 
 	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
 		{"Scaling", "my-app", "my-org", "my-space", "my-user"},
@@ -74,14 +74,77 @@ func TestScaleAll(t *testing.T) {
 	assert.Equal(t, appRepo.UpdateParams.Get("instances"), 5)
 }
 
+func TestScaleOnlyDiskQuota(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
+	reqFactory.Application = app
+
+	callScale(t, []string{"-k", "2G", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
+
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, appRepo.UpdateAppGuid, "my-app-guid")
+	assert.Equal(t, appRepo.UpdateParams.Get("disk_quota").(uint64), uint64(2048))
+
+	assert.False(t, appRepo.UpdateParams.Has("memory"))
+	assert.False(t, appRepo.UpdateParams.Has("instances"))
+}
+
+func TestScaleShowsResourcesBeforeAndAfter(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.InstanceCount = 1
+	app.Memory = 256
+	app.DiskQuota = 1024
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
+	reqFactory.Application = app
+
+	ui := callScale(t, []string{"-i", "2", "-k", "2G", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Scaling", "my-app"},
+		{"before", "after"},
+		{"instances", "1", "2"},
+		{"memory", "256M", "256M"},
+		{"disk", "1G", "2G"},
+		{"OK"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"quota"},
+	})
+}
+
+func TestScaleWarnsWhenTheOrgQuotaWouldBeExceeded(t *testing.T) {
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.InstanceCount = 2
+	app.Memory = 256
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
+	reqFactory.Application = app
+	orgRepo.MemoryUsage = 1536
+
+	ui := callScale(t, []string{"-m", "512M", "-i", "3", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Scaling needs 1G more memory", "only 512M is left", "my-org"},
+		{"OK"},
+	})
+	assert.Equal(t, orgRepo.FindByNameName, "my-org")
+	assert.Equal(t, orgRepo.MemoryUsageOrgGuid, "my-org-guid")
+	assert.Equal(t, appRepo.UpdateAppGuid, "my-app-guid")
+}
+
 func TestScaleOnlyInstances(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 	reqFactory.Application = app
 
-	callScale(t, []string{"-i", "5", "my-app"}, reqFactory, restarter, appRepo)
+	callScale(t, []string{"-i", "5", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
 
 	assert.Equal(t, appRepo.UpdateAppGuid, "my-app-guid")
 	assert.Equal(t, appRepo.UpdateParams.Get("instances"), 5)
@@ -94,10 +157,10 @@ func TestScaleOnlyMemory(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 	reqFactory.Application = app
 
-	callScale(t, []string{"-m", "512M", "my-app"}, reqFactory, restarter, appRepo)
+	callScale(t, []string{"-m", "512M", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
 
 	assert.Equal(t, appRepo.UpdateAppGuid, "my-app-guid")
 	assert.Equal(t, appRepo.UpdateParams.Get("memory").(uint64), uint64(512))
@@ -110,10 +173,10 @@ func TestScaleMemoryWithIntAssumesMegabytes(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	reqFactory, restarter, appRepo := getScaleDependencies()
+	reqFactory, restarter, appRepo, orgRepo := getScaleDependencies()
 	reqFactory.Application = app
 
-	callScale(t, []string{"-m", "1024", "my-app"}, reqFactory, restarter, appRepo)
+	callScale(t, []string{"-m", "1024", "my-app"}, reqFactory, restarter, appRepo, orgRepo)
 
 	assert.Equal(t, appRepo.UpdateAppGuid, "my-app-guid")
 	assert.Equal(t, appRepo.UpdateParams.Get("memory").(uint64), uint64(1024))
@@ -122,14 +185,20 @@ func TestScaleMemoryWithIntAssumesMegabytes(t *testing.T) {
 	assert.False(t, appRepo.UpdateParams.Has("instances"))
 }
 
-func getScaleDependencies() (reqFactory *testreq.FakeReqFactory, restarter *testcmd.FakeAppRestarter, appRepo *testapi.FakeApplicationRepository) {
+func getScaleDependencies() (reqFactory *testreq.FakeReqFactory, restarter *testcmd.FakeAppRestarter, appRepo *testapi.FakeApplicationRepository, orgRepo *testapi.FakeOrgRepository) {
 	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
 	restarter = &testcmd.FakeAppRestarter{}
 	appRepo = &testapi.FakeApplicationRepository{}
+
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+	org.QuotaDefinition.MemoryLimit = 2048
+	orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
 	return
 }
 
-func callScale(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, restarter *testcmd.FakeAppRestarter, appRepo api.ApplicationRepository) (ui *testterm.FakeUI) {
+func callScale(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, restarter *testcmd.FakeAppRestarter, appRepo api.ApplicationRepository, orgRepo api.OrganizationRepository) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("scale", args)
 
@@ -147,7 +216,7 @@ func callScale(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory,
 		AccessToken:        token,
 	}
 
-	cmd := NewScale(ui, config, restarter, appRepo)
+	cmd := NewScale(ui, config, restarter, appRepo, orgRepo)
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 	return
 }
@@ -42,7 +42,7 @@ func TestRunWhenApplicationExists(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	app.EnvironmentVars = map[string]string{"foo": "bar"}
+	app.EnvironmentVars = map[string]interface{}{"foo": "bar"}
 	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
 	appRepo := &testapi.FakeApplicationRepository{}
 
@@ -76,7 +76,7 @@ func TestSetEnvWhenItAlreadyExists(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	app.EnvironmentVars = map[string]string{"DATABASE_URL": "mysql://example.com/my-db"}
+	app.EnvironmentVars = map[string]interface{}{"DATABASE_URL": "mysql://example.com/my-db"}
 	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
 	appRepo := &testapi.FakeApplicationRepository{}
 
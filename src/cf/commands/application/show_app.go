@@ -10,7 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type ShowApp struct {
@@ -19,10 +24,15 @@ type ShowApp struct {
 	appSummaryRepo   api.AppSummaryRepository
 	appInstancesRepo api.AppInstancesRepository
 	appReq           requirements.ApplicationRequirement
+
+	// SampleTimer fires when the next sample is due; tests replace it to
+	// sample without waiting.
+	SampleTimer func(interval time.Duration) <-chan time.Time
 }
 
 type ApplicationDisplayer interface {
 	ShowApp(app cf.Application)
+	WithUI(ui terminal.UI) ApplicationDisplayer
 }
 
 func NewShowApp(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository, appInstancesRepo api.AppInstancesRepository) (cmd *ShowApp) {
@@ -31,16 +41,26 @@ func NewShowApp(ui terminal.UI, config *configuration.Configuration, appSummaryR
 	cmd.config = config
 	cmd.appSummaryRepo = appSummaryRepo
 	cmd.appInstancesRepo = appInstancesRepo
+	cmd.SampleTimer = time.After
 	return
 }
 
 func (cmd *ShowApp) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
-	if len(c.Args()) < 1 {
+	if len(c.Args()) < 1 || c.Int("count") < 0 {
 		err = errors.New("Incorrect Usage")
 		cmd.ui.FailWithUsage(c, "app")
 		return
 	}
 
+	if c.String("sample") != "" {
+		interval, parseErr := time.ParseDuration(c.String("sample"))
+		if parseErr != nil || interval <= 0 {
+			err = errors.New("Incorrect Usage")
+			cmd.ui.FailWithUsage(c, "app")
+			return
+		}
+	}
+
 	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
 
 	reqs = []requirements.Requirement{
@@ -53,10 +73,30 @@ func (cmd *ShowApp) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 
 func (cmd *ShowApp) Run(c *cli.Context) {
 	app := cmd.appReq.GetApplication()
-	cmd.ShowApp(app)
+
+	if c.String("sample") != "" || c.Int("count") > 0 || c.String("csv") != "" {
+		interval := DefaultSampleInterval
+		if c.String("sample") != "" {
+			interval, _ = time.ParseDuration(c.String("sample"))
+		}
+		cmd.sampleInstances(app, interval, c.Int("count"), c.String("csv"))
+		return
+	}
+
+	cmd.showApp(app, c.Bool("stats"))
+}
+
+func (cmd *ShowApp) WithUI(ui terminal.UI) ApplicationDisplayer {
+	displayer := *cmd
+	displayer.ui = ui
+	return &displayer
 }
 
 func (cmd *ShowApp) ShowApp(app cf.Application) {
+	cmd.showApp(app, false)
+}
+
+func (cmd *ShowApp) showApp(app cf.Application, withStats bool) {
 
 	cmd.ui.Say("Showing health and status for app %s in org %s / space %s as %s...",
 		terminal.EntityNameColor(app.Name),
@@ -102,17 +142,105 @@ func (cmd *ShowApp) ShowApp(app cf.Application) {
 	table := [][]string{
 		[]string{"", "state", "since", "cpu", "memory", "disk"},
 	}
+	if withStats {
+		table[0] = append(table[0], "host", "port", "uptime")
+	}
 
+	now := time.Now()
 	for index, instance := range instances {
-		table = append(table, []string{
+		state := coloredInstanceState(instance)
+		if recentlyReplaced(instances, index, now) {
+			state = state + " (replaced)"
+		}
+
+		row := []string{
 			fmt.Sprintf("#%d", index),
-			coloredInstanceState(instance),
+			state,
 			instance.Since.Format("2006-01-02 03:04:05 PM"),
 			fmt.Sprintf("%.1f%%", instance.CpuUsage*100),
 			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.MemUsage), formatters.ByteSize(instance.MemQuota)),
 			fmt.Sprintf("%s of %s", formatters.ByteSize(instance.DiskUsage), formatters.ByteSize(instance.DiskQuota)),
-		})
+		}
+		if withStats {
+			row = append(row, instance.Host, strconv.Itoa(instance.Port), instance.Uptime.String())
+		}
+
+		table = append(table, row)
 	}
 
 	cmd.ui.DisplayTable(table)
 }
+
+func (cmd *ShowApp) sampleInstances(app cf.Application, interval time.Duration, count int, csvPath string) {
+	var csvOut io.Writer
+	if csvPath != "" {
+		file, err := os.Create(csvPath)
+		if err != nil {
+			cmd.ui.Failed("Error creating %s\n%s", csvPath, err.Error())
+			return
+		}
+		defer file.Close()
+		csvOut = file
+	}
+
+	recorder, err := newInstanceStatsRecorder(csvOut)
+	if err != nil {
+		cmd.ui.Failed("Error writing %s\n%s", csvPath, err.Error())
+		return
+	}
+
+	times := "until interrupted"
+	if count > 0 {
+		times = fmt.Sprintf("%d times", count)
+	}
+
+	cmd.ui.Say("Sampling instances of app %s every %s, %s, in org %s / space %s as %s...",
+		terminal.EntityNameColor(app.Name),
+		interval,
+		times,
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for sample := 1; count == 0 || sample <= count; sample++ {
+		taken := time.Now()
+		instances, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Warn("sample %d: %s", sample, apiResponse.Message)
+		} else {
+			err = recorder.record(taken, instances)
+			if err != nil {
+				cmd.ui.Failed("Error writing %s\n%s", csvPath, err.Error())
+				return
+			}
+			cmd.ui.Say("sample %d: %d instances at %s", sample, len(instances), taken.Format("03:04:05 PM"))
+		}
+
+		if sample == count {
+			break
+		}
+
+		interrupted := false
+		select {
+		case <-interrupt:
+			interrupted = true
+		case <-cmd.SampleTimer(interval):
+		}
+		if interrupted {
+			break
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.DisplayTable(recorder.summaryTable())
+
+	if csvPath != "" {
+		cmd.ui.Say("\nSamples saved to %s", terminal.EntityNameColor(csvPath))
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"cf/configuration"
 	"cf/formatters"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strings"
 	testapi "testhelpers/api"
 	testassert "testhelpers/assert"
 	testcmd "testhelpers/commands"
@@ -109,6 +112,135 @@ func TestDisplayingAppSummary(t *testing.T) {
 	})
 }
 
+func TestDisplayingAppSummaryWithARecentlyReplacedInstance(t *testing.T) {
+	reqApp := cf.Application{}
+	reqApp.Name = "my-app"
+	reqApp.Guid = "my-app-guid"
+
+	appSummary := cf.AppSummary{}
+	appSummary.State = "started"
+	appSummary.InstanceCount = 3
+	appSummary.RunningInstances = 3
+
+	appInstance := cf.AppInstanceFields{}
+	appInstance.State = cf.InstanceRunning
+	appInstance.Since = time.Now().Add(-3 * time.Hour)
+
+	appInstance2 := cf.AppInstanceFields{}
+	appInstance2.State = cf.InstanceRunning
+	appInstance2.Since = time.Now().Add(-2 * time.Minute)
+
+	instances := []cf.AppInstanceFields{appInstance, appInstance2, appInstance}
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{GetSummarySummary: appSummary}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{GetInstancesResponses: [][]cf.AppInstanceFields{instances}}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: reqApp}
+	ui := callApp(t, []string{"my-app"}, reqFactory, appSummaryRepo, appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"#1", "running", "(replaced)"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"#0", "replaced"},
+		{"#2", "replaced"},
+	})
+}
+
+func TestAppFailsWithUsageForInvalidSampling(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: cf.Application{}}
+
+	ui := callApp(t, []string{"--sample", "often", "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callApp(t, []string{"--sample", "0s", "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = new(testterm.FakeUI)
+	cmd := NewShowApp(ui, &configuration.Configuration{}, &testapi.FakeAppSummaryRepo{}, &testapi.FakeAppInstancesRepo{})
+	_, err := cmd.GetRequirements(reqFactory, testcmd.NewContext("app", []string{"--sample", "10s", "my-app"}))
+	assert.NoError(t, err)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestDisplayingAppSummaryWithStats(t *testing.T) {
+	reqApp := cf.Application{}
+	reqApp.Name = "my-app"
+	reqApp.Guid = "my-app-guid"
+
+	appSummary := cf.AppSummary{}
+	appSummary.State = "started"
+	appSummary.InstanceCount = 1
+	appSummary.RunningInstances = 1
+
+	appInstance := cf.AppInstanceFields{}
+	appInstance.State = cf.InstanceRunning
+	appInstance.Host = "10.0.0.1"
+	appInstance.Port = 61035
+	appInstance.Uptime = time.Hour + 2*time.Minute
+
+	appSummaryRepo := &testapi.FakeAppSummaryRepo{GetSummarySummary: appSummary}
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{GetInstancesResponses: [][]cf.AppInstanceFields{{appInstance}}}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: reqApp}
+	ui := callApp(t, []string{"--stats", "my-app"}, reqFactory, appSummaryRepo, appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"state", "since", "cpu", "memory", "disk", "host", "port", "uptime"},
+		{"#0", "running", "10.0.0.1", "61035", "1h2m0s"},
+	})
+}
+
+func TestSamplingAppInstances(t *testing.T) {
+	reqApp := cf.Application{}
+	reqApp.Name = "my-app"
+	reqApp.Guid = "my-app-guid"
+
+	sample := func(cpu float64, mem uint64) []cf.AppInstanceFields {
+		instance := cf.AppInstanceFields{}
+		instance.State = cf.InstanceRunning
+		instance.CpuUsage = cpu
+		instance.MemUsage = mem * formatters.MEGABYTE
+		instance.DiskUsage = 32 * formatters.MEGABYTE
+		return []cf.AppInstanceFields{instance}
+	}
+
+	appInstancesRepo := &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses: [][]cf.AppInstanceFields{
+			sample(0.1, 10),
+			sample(0.5, 30),
+			sample(0.3, 20),
+		},
+	}
+
+	file, err := ioutil.TempFile("", "samples")
+	assert.NoError(t, err)
+	file.Close()
+	defer os.Remove(file.Name())
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: reqApp}
+	ui := callApp(t, []string{"--sample", "10s", "--count", "3", "--csv", file.Name(), "my-app"}, reqFactory, &testapi.FakeAppSummaryRepo{}, appInstancesRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Sampling instances of app", "my-app", "10s", "3 times", "my-org", "my-space", "my-user"},
+		{"sample 1", "1 instances"},
+		{"sample 2"},
+		{"sample 3"},
+		{"OK"},
+		{"samples", "cpu min/avg/max", "memory min/avg/max", "disk min/avg/max"},
+		{"#0", "3", "10.0% / 30.0% / 50.0%", "10M / 20M / 30M", "32M / 32M / 32M"},
+		{"Samples saved to", file.Name()},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"sample 4"},
+	})
+
+	contents, err := ioutil.ReadFile(file.Name())
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	assert.Equal(t, len(lines), 4)
+	assert.Equal(t, lines[0], "time,instance,state,cpu,memory,disk")
+	assert.Contains(t, lines[2], ",0,running,50.00,31457280,33554432")
+}
+
 func TestDisplayingStoppedAppSummary(t *testing.T) {
 	testDisplayingAppSummaryWithErrorCode(t, cf.APP_STOPPED)
 }
@@ -187,6 +319,11 @@ func callApp(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, ap
 	}
 
 	cmd := NewShowApp(ui, config, appSummaryRepo, appInstancesRepo)
+	cmd.SampleTimer = func(interval time.Duration) <-chan time.Time {
+		timer := make(chan time.Time, 1)
+		timer <- time.Now()
+		return timer
+	}
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	return
@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
 	"errors"
@@ -41,6 +42,7 @@ type Start struct {
 type ApplicationStarter interface {
 	SetStartTimeoutSeconds(timeout int)
 	ApplicationStart(app cf.Application) (updatedApp cf.Application, err error)
+	WithUI(ui terminal.UI) ApplicationStarter
 }
 
 func NewStart(ui terminal.UI, config *configuration.Configuration, appDisplayer ApplicationDisplayer, appRepo api.ApplicationRepository, appInstancesRepo api.AppInstancesRepository, logRepo api.LogsRepository) (cmd *Start) {
@@ -100,6 +102,20 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 		return
 	}
 
+	return cmd.stageAndStart(app, "Starting", func() (cf.Application, net.ApiResponse) {
+		params := cf.NewEmptyAppParams()
+		params.Set("state", "STARTED")
+		return cmd.appRepo.Update(app.Guid, params)
+	})
+}
+
+func (cmd *Start) ApplicationRestage(app cf.Application) (updatedApp cf.Application, err error) {
+	return cmd.stageAndStart(app, "Restaging", func() (cf.Application, net.ApiResponse) {
+		return cmd.appRepo.Restage(app.Guid)
+	})
+}
+
+func (cmd *Start) stageAndStart(app cf.Application, action string, stage func() (cf.Application, net.ApiResponse)) (updatedApp cf.Application, err error) {
 	stopLoggingChan := make(chan bool, 1)
 	defer close(stopLoggingChan)
 	loggingStartedChan := make(chan bool)
@@ -109,17 +125,15 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 
 	<-loggingStartedChan
 
-	cmd.ui.Say("Starting app %s in org %s / space %s as %s...",
+	cmd.ui.Say("%s app %s in org %s / space %s as %s...",
+		action,
 		terminal.EntityNameColor(app.Name),
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	params := cf.NewEmptyAppParams()
-	params.Set("state", "STARTED")
-
-	updatedApp, apiResponse := cmd.appRepo.Update(app.Guid, params)
+	updatedApp, apiResponse := stage()
 
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
@@ -140,10 +154,27 @@ func (cmd *Start) ApplicationStart(app cf.Application) (updatedApp cf.Applicatio
 	return
 }
 
+// WithUI returns a copy of the starter reporting to ui, so that several apps
+// can be started at once, each with its own output and timeouts.
+func (cmd *Start) WithUI(ui terminal.UI) ApplicationStarter {
+	starter := *cmd
+	starter.ui = ui
+	starter.appDisplayer = cmd.appDisplayer.WithUI(ui)
+	return &starter
+}
+
 func (cmd *Start) SetStartTimeoutSeconds(timeout int) {
 	cmd.StartupTimeout = time.Duration(timeout) * time.Second
 }
 
+func (cmd *Start) SetStagingTimeoutMinutes(timeout int) {
+	cmd.StagingTimeout = time.Duration(timeout) * time.Minute
+}
+
+func (cmd *Start) SetStartupTimeoutMinutes(timeout int) {
+	cmd.StartupTimeout = time.Duration(timeout) * time.Minute
+}
+
 func (cmd Start) tailStagingLogs(app cf.Application, startChan chan bool, stopChan chan bool) {
 	logChan := make(chan *logmessage.Message, 1000)
 	go func() {
@@ -178,6 +209,12 @@ func (cmd Start) waitForInstancesToStage(app cf.Application) {
 	_, apiResponse := cmd.appInstancesRepo.GetInstances(app.Guid)
 
 	for apiResponse.IsNotSuccessful() && time.Since(stagingStartTime) < cmd.StagingTimeout {
+		if apiResponse.ErrorCode == cf.STAGING_FAILED {
+			cmd.ui.Say("")
+			cmd.ui.Failed("Staging failed: %s\n\nTIP: use '%s' for more information",
+				apiResponse.Message, terminal.CommandColor(cf.Name()+" logs "+app.Name+" --recent"))
+			return
+		}
 		if apiResponse.ErrorCode != cf.APP_NOT_STAGED {
 			cmd.ui.Say("")
 			cmd.ui.Failed(apiResponse.Message)
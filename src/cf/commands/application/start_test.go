@@ -132,6 +132,14 @@ func TestStartCommandSetsTimeoutsFromEnv(t *testing.T) {
 	assert.Equal(t, cmd.StartupTimeout, 3*time.Minute)
 }
 
+func TestStartCommandSetsTimeoutsInMinutes(t *testing.T) {
+	cmd := NewStart(new(testterm.FakeUI), &configuration.Configuration{}, &testcmd.FakeAppDisplayer{}, &testapi.FakeApplicationRepository{}, &testapi.FakeAppInstancesRepo{}, &testapi.FakeLogsRepository{})
+	cmd.SetStagingTimeoutMinutes(20)
+	cmd.SetStartupTimeoutMinutes(8)
+	assert.Equal(t, cmd.StagingTimeout, 20*time.Minute)
+	assert.Equal(t, cmd.StartupTimeout, 8*time.Minute)
+}
+
 func TestStartCommandFailsWithUsage(t *testing.T) {
 	t.Parallel()
 
@@ -287,7 +295,8 @@ func TestStartApplicationWhenStagingFails(t *testing.T) {
 		{"my-app"},
 		{"OK"},
 		{"FAILED"},
-		{"Error staging app"},
+		{"Staging failed", "Error staging app"},
+		{"TIP", "logs my-app --recent"},
 	})
 }
 
@@ -443,3 +452,81 @@ func TestStartApplicationWithLoggingFailure(t *testing.T) {
 		testassert.Line{"Ooops"},
 	})
 }
+
+func TestApplicationRestageStreamsStagingLogs(t *testing.T) {
+	t.Parallel()
+
+	displayApp := &testcmd.FakeAppDisplayer{}
+	ui, appRepo, appInstancesRepo := restageAppWithInstancesAndErrors(t, displayApp, defaultInstanceReponses, defaultInstanceErrorCodes)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Restaging app", "my-app", "my-org", "my-space", "my-user"},
+		{"OK"},
+		{"0 of 2 instances running", "2 starting"},
+		{"Started"},
+	})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Log Line 1"},
+		{"Log Line 2"},
+	})
+
+	assert.Equal(t, appRepo.RestageAppGuid, "my-app-guid")
+	assert.Equal(t, appRepo.UpdateAppGuid, "")
+	assert.Equal(t, appInstancesRepo.GetInstancesAppGuid, "my-app-guid")
+	assert.Equal(t, displayApp.AppToDisplay, defaultAppForStart)
+}
+
+func TestApplicationRestageWhenStagingFails(t *testing.T) {
+	t.Parallel()
+
+	displayApp := &testcmd.FakeAppDisplayer{}
+	instances := [][]cf.AppInstanceFields{[]cf.AppInstanceFields{}}
+	errorCodes := []string{cf.STAGING_FAILED}
+
+	ui, _, _ := restageAppWithInstancesAndErrors(t, displayApp, instances, errorCodes)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Restaging app", "my-app"},
+		{"OK"},
+		{"FAILED"},
+		{"Staging failed", "Error staging app"},
+		{"TIP", "logs my-app --recent"},
+	})
+}
+
+func restageAppWithInstancesAndErrors(t *testing.T, displayApp ApplicationDisplayer, instances [][]cf.AppInstanceFields, errorCodes []string) (ui *testterm.FakeUI, appRepo *testapi.FakeApplicationRepository, appInstancesRepo *testapi.FakeAppInstancesRepo) {
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	appRepo = &testapi.FakeApplicationRepository{RestageAppResult: defaultAppForStart}
+	appInstancesRepo = &testapi.FakeAppInstancesRepo{
+		GetInstancesResponses:  instances,
+		GetInstancesErrorCodes: errorCodes,
+	}
+	logRepo := &testapi.FakeLogsRepository{
+		TailLogMessages: []*logmessage.Message{
+			NewLogMessage("Log Line 1", defaultAppForStart.Guid, LogMessageTypeStaging, time.Now()),
+			NewLogMessage("Log Line 2", defaultAppForStart.Guid, LogMessageTypeStaging, time.Now()),
+		},
+	}
+
+	ui = new(testterm.FakeUI)
+	cmd := NewStart(ui, config, displayApp, appRepo, appInstancesRepo, logRepo)
+	cmd.StagingTimeout = 5 * time.Millisecond
+	cmd.StartupTimeout = defaultStartTimeout
+	cmd.PingerThrottle = 5 * time.Millisecond
+
+	cmd.ApplicationRestage(defaultAppForStart)
+	return
+}
@@ -12,6 +12,7 @@ import (
 
 type ApplicationStopper interface {
 	ApplicationStop(app cf.Application) (updatedApp cf.Application, err error)
+	WithUI(ui terminal.UI) ApplicationStopper
 }
 
 type Stop struct {
@@ -71,6 +72,12 @@ func (cmd *Stop) ApplicationStop(app cf.Application) (updatedApp cf.Application,
 	return
 }
 
+func (cmd *Stop) WithUI(ui terminal.UI) ApplicationStopper {
+	stopper := *cmd
+	stopper.ui = ui
+	return &stopper
+}
+
 func (cmd *Stop) Run(c *cli.Context) {
 	app := cmd.appReq.GetApplication()
 	cmd.ApplicationStop(app)
@@ -40,7 +40,7 @@ func TestUnsetEnvWhenApplicationExists(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	app.EnvironmentVars = map[string]string{"foo": "bar", "DATABASE_URL": "mysql://example.com/my-db"}
+	app.EnvironmentVars = map[string]interface{}{"foo": "bar", "DATABASE_URL": "mysql://example.com/my-db"}
 	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
 	appRepo := &testapi.FakeApplicationRepository{}
 
@@ -63,7 +63,7 @@ func TestUnsetEnvWhenUnsettingTheEnvFails(t *testing.T) {
 	app := cf.Application{}
 	app.Name = "my-app"
 	app.Guid = "my-app-guid"
-	app.EnvironmentVars = map[string]string{"DATABASE_URL": "mysql://example.com/my-db"}
+	app.EnvironmentVars = map[string]interface{}{"DATABASE_URL": "mysql://example.com/my-db"}
 	reqFactory := &testreq.FakeReqFactory{Application: app, LoginSuccess: true, TargetedSpaceSuccess: true}
 	appRepo := &testapi.FakeApplicationRepository{
 		ReadApp:   app,
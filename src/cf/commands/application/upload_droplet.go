@@ -0,0 +1,81 @@
+package application
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"os"
+)
+
+type UploadDroplet struct {
+	ui          terminal.UI
+	config      *configuration.Configuration
+	restarter   ApplicationRestarter
+	appBitsRepo api.ApplicationBitsRepository
+	appReq      requirements.ApplicationRequirement
+}
+
+func NewUploadDroplet(ui terminal.UI, config *configuration.Configuration, restarter ApplicationRestarter, appBitsRepo api.ApplicationBitsRepository) (cmd *UploadDroplet) {
+	cmd = new(UploadDroplet)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.restarter = restarter
+	cmd.appBitsRepo = appBitsRepo
+	return
+}
+
+func (cmd *UploadDroplet) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "upload-droplet")
+		return
+	}
+
+	cmd.appReq = reqFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+	return
+}
+
+func (cmd *UploadDroplet) Run(c *cli.Context) {
+	app := cmd.appReq.GetApplication()
+	path := c.Args()[1]
+
+	_, err := os.Stat(path)
+	if err != nil {
+		cmd.ui.Failed("Error reading droplet %s\n%s", path, err.Error())
+		return
+	}
+
+	cmd.ui.Say("Uploading droplet %s to app %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(path),
+		terminal.EntityNameColor(app.Name),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	apiResponse := cmd.appBitsRepo.UploadDroplet(app.Guid, path)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+
+	if app.State != "started" {
+		cmd.ui.Say("\nTIP: use '%s' to run the uploaded droplet", terminal.CommandColor(cf.Name()+" start "+app.Name))
+		return
+	}
+
+	cmd.ui.Say("")
+	cmd.restarter.ApplicationRestart(app)
+}
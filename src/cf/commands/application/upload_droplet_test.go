@@ -0,0 +1,114 @@
+package application_test
+
+import (
+	"cf"
+	. "cf/commands/application"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestUploadDropletFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+
+	ui := callUploadDroplet(t, []string{"my-app"}, reqFactory, &testcmd.FakeAppRestarter{}, &testapi.FakeApplicationBitsRepository{})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUploadDroplet(t, []string{"my-app", "droplet.tgz"}, reqFactory, &testcmd.FakeAppRestarter{}, &testapi.FakeApplicationBitsRepository{})
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestUploadDropletRestartsAStartedApp(t *testing.T) {
+	path := writeDropletFile(t)
+	defer os.Remove(path)
+
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.State = "started"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	restarter := &testcmd.FakeAppRestarter{}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{}
+
+	ui := callUploadDroplet(t, []string{"my-app", path}, reqFactory, restarter, appBitsRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Uploading droplet", path, "my-app", "my-org", "my-space", "my-user"},
+		{"OK"},
+	})
+	assert.Equal(t, appBitsRepo.UploadedDropletAppGuid, "my-app-guid")
+	assert.Equal(t, appBitsRepo.UploadedDropletPath, path)
+	assert.Equal(t, restarter.AppToRestart, app)
+}
+
+func TestUploadDropletLeavesAStoppedAppStopped(t *testing.T) {
+	path := writeDropletFile(t)
+	defer os.Remove(path)
+
+	app := cf.Application{}
+	app.Name = "my-app"
+	app.Guid = "my-app-guid"
+	app.State = "stopped"
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true, Application: app}
+	restarter := &testcmd.FakeAppRestarter{}
+
+	ui := callUploadDroplet(t, []string{"my-app", path}, reqFactory, restarter, &testapi.FakeApplicationBitsRepository{})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"TIP", "start my-app"},
+	})
+	assert.Equal(t, restarter.AppToRestart.Guid, "")
+}
+
+func TestUploadDropletWhenTheFileIsMissing(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	appBitsRepo := &testapi.FakeApplicationBitsRepository{}
+
+	ui := callUploadDroplet(t, []string{"my-app", "/does/not/exist.tgz"}, reqFactory, &testcmd.FakeAppRestarter{}, appBitsRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading droplet", "/does/not/exist.tgz"},
+	})
+	assert.Equal(t, appBitsRepo.UploadedDropletAppGuid, "")
+}
+
+func writeDropletFile(t *testing.T) (path string) {
+	file, err := ioutil.TempFile("", "droplet")
+	assert.NoError(t, err)
+	file.WriteString("droplet contents")
+	file.Close()
+	return file.Name()
+}
+
+func callUploadDroplet(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, restarter *testcmd.FakeAppRestarter, appBitsRepo *testapi.FakeApplicationBitsRepository) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("upload-droplet", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewUploadDroplet(ui, config, restarter, appBitsRepo)
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
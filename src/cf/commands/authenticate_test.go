@@ -93,6 +93,30 @@ func TestUnsuccessfullyAuthenticatingWithoutInteractivity(t *testing.T) {
 	})
 }
 
+func TestSuccessfullyAuthenticatingWithClientCredentials(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	auth := &testapi.FakeAuthenticationRepository{
+		AccessToken: "my_client_token",
+		ConfigRepo:  configRepo,
+	}
+
+	ui := callAuthenticate([]string{"--client-credentials", "my-client", "my-secret"}, configRepo, auth)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+	})
+
+	savedConfig := testconfig.SavedConfiguration
+	assert.Equal(t, savedConfig.AccessToken, "my_client_token")
+	assert.Equal(t, savedConfig.ClientId, "my-client")
+	assert.Equal(t, savedConfig.ClientSecret, "my-secret")
+	assert.Equal(t, auth.ClientId, "my-client")
+	assert.Equal(t, auth.ClientSecret, "my-secret")
+	assert.Empty(t, auth.Email)
+}
+
 func callAuthenticate(args []string, configRepo configuration.ConfigurationRepository, auth api.AuthenticationRepository) (ui *testterm.FakeUI) {
 	ui = new(testterm.FakeUI)
 	ctxt := testcmd.NewContext("auth", args)
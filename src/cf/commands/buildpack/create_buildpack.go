@@ -26,6 +26,7 @@ func NewCreateBuildpack(ui terminal.UI, buildpackRepo api.BuildpackRepository, b
 func (cmd CreateBuildpack) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 	}
 	return
 }
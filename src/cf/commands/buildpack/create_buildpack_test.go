@@ -13,7 +13,7 @@ import (
 )
 
 func TestCreateBuildpackRequirements(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	repo.FindByNameBuildpack = cf.Buildpack{}
@@ -23,10 +23,15 @@ func TestCreateBuildpackRequirements(t *testing.T) {
 	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false}
 	callCreateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callCreateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
 }
 
 func TestCreateBuildpack(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 	ui := callCreateBuildpack([]string{"my-buildpack", "my.war", "5"}, reqFactory, repo, bitsRepo)
 
@@ -40,7 +45,7 @@ func TestCreateBuildpack(t *testing.T) {
 }
 
 func TestCreateBuildpackWhenItAlreadyExists(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	repo.CreateBuildpackExists = true
@@ -55,7 +60,7 @@ func TestCreateBuildpackWhenItAlreadyExists(t *testing.T) {
 }
 
 func TestCreateBuildpackWithPosition(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 	ui := callCreateBuildpack([]string{"my-buildpack", "my.war", "5"}, reqFactory, repo, bitsRepo)
 
@@ -69,7 +74,7 @@ func TestCreateBuildpackWithPosition(t *testing.T) {
 }
 
 func TestCreateBuildpackWithInvalidPath(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	bitsRepo.UploadBuildpackErr = true
@@ -84,7 +89,7 @@ func TestCreateBuildpackWithInvalidPath(t *testing.T) {
 }
 
 func TestCreateBuildpackFailsWithUsage(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	ui := callCreateBuildpack([]string{}, reqFactory, repo, bitsRepo)
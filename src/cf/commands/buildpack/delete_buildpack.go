@@ -1,6 +1,7 @@
 package buildpack
 
 import (
+	"cf"
 	"cf/api"
 	"cf/requirements"
 	"cf/terminal"
@@ -31,6 +32,7 @@ func (cmd *DeleteBuildpack) GetRequirements(reqFactory requirements.Factory, c *
 
 	reqs = []requirements.Requirement{
 		loginReq,
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 	}
 
 	return
@@ -20,7 +20,7 @@ func TestDeleteBuildpackGetRequirements(t *testing.T) {
 
 	ctxt := testcmd.NewContext("delete-buildpack", []string{"my-buildpack"})
 
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	assert.True(t, testcmd.CommandDidPassRequirements)
@@ -29,6 +29,12 @@ func TestDeleteBuildpackGetRequirements(t *testing.T) {
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
 }
 
 func TestDeleteBuildpackSuccess(t *testing.T) {
@@ -42,7 +48,7 @@ func TestDeleteBuildpackSuccess(t *testing.T) {
 	cmd := NewDeleteBuildpack(ui, buildpackRepo)
 
 	ctxt := testcmd.NewContext("delete-buildpack", []string{"my-buildpack"})
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
@@ -68,7 +74,7 @@ func TestDeleteBuildpackNoConfirmation(t *testing.T) {
 	cmd := NewDeleteBuildpack(ui, buildpackRepo)
 
 	ctxt := testcmd.NewContext("delete-buildpack", []string{"my-buildpack"})
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
@@ -80,7 +86,7 @@ func TestDeleteBuildpackNoConfirmation(t *testing.T) {
 }
 
 func TestDeleteBuildpackThatDoesNotExist(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	buildpack := cf.Buildpack{}
 	buildpack.Name = "my-buildpack"
 	buildpack.Guid = "my-buildpack-guid"
@@ -118,7 +124,7 @@ func TestDeleteBuildpackDeleteError(t *testing.T) {
 	cmd := NewDeleteBuildpack(ui, buildpackRepo)
 
 	ctxt := testcmd.NewContext("delete-buildpack", []string{"my-buildpack"})
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
@@ -144,7 +150,7 @@ func TestDeleteBuildpackForceFlagSkipsConfirmation(t *testing.T) {
 	cmd := NewDeleteBuildpack(ui, buildpackRepo)
 
 	ctxt := testcmd.NewContext("delete-buildpack", []string{"-f", "my-buildpack"})
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
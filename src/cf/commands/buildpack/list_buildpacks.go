@@ -1,6 +1,7 @@
 package buildpack
 
 import (
+	"cf"
 	"cf/api"
 	"cf/requirements"
 	"cf/terminal"
@@ -29,15 +30,10 @@ func (cmd ListBuildpacks) GetRequirements(reqFactory requirements.Factory, c *cl
 func (cmd ListBuildpacks) Run(c *cli.Context) {
 	cmd.ui.Say("Getting buildpacks...\n")
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	buildpackChan, statusChan := cmd.buildpackRepo.ListBuildpacks(stopChan)
-
 	table := cmd.ui.Table([]string{"buildpack", "position"})
 	noBuildpacks := true
 
-	for buildpacks := range buildpackChan {
+	apiStatus := cmd.buildpackRepo.ListBuildpacks(func(buildpacks []cf.Buildpack) bool {
 		rows := [][]string{}
 		for _, buildpack := range buildpacks {
 			position := ""
@@ -51,9 +47,9 @@ func (cmd ListBuildpacks) Run(c *cli.Context) {
 		}
 		table.Print(rows)
 		noBuildpacks = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching buildpacks.\n%s", apiStatus.Message)
 		return
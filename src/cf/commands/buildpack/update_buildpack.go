@@ -1,6 +1,7 @@
 package buildpack
 
 import (
+	"cf"
 	"cf/api"
 	"cf/requirements"
 	"cf/terminal"
@@ -35,6 +36,7 @@ func (cmd *UpdateBuildpack) GetRequirements(reqFactory requirements.Factory, c *
 
 	reqs = []requirements.Requirement{
 		loginReq,
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 		cmd.buildpackReq,
 	}
 
@@ -14,25 +14,30 @@ import (
 func TestUpdateBuildpackRequirements(t *testing.T) {
 	repo, bitsRepo := getRepositories()
 
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: true}
 	callUpdateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 
-	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: false}
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: false}
 	callUpdateBuildpack([]string{"my-buildpack", "-p", "buildpack.zip", "extraArg"}, reqFactory, repo, bitsRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 
-	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: false}
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: false}
 	callUpdateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, BuildpackSuccess: true}
 	callUpdateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false, BuildpackSuccess: true}
+	callUpdateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
 }
 
 func TestUpdateBuildpack(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	ui := callUpdateBuildpack([]string{"my-buildpack"}, reqFactory, repo, bitsRepo)
@@ -43,7 +48,7 @@ func TestUpdateBuildpack(t *testing.T) {
 }
 
 func TestUpdateBuildpackPosition(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	ui := callUpdateBuildpack([]string{"-i", "999", "my-buildpack"}, reqFactory, repo, bitsRepo)
@@ -56,7 +61,7 @@ func TestUpdateBuildpackPosition(t *testing.T) {
 }
 
 func TestUpdateBuildpackPath(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: true}
 	repo, bitsRepo := getRepositories()
 
 	ui := callUpdateBuildpack([]string{"-p", "buildpack.zip", "my-buildpack"}, reqFactory, repo, bitsRepo)
@@ -70,7 +75,7 @@ func TestUpdateBuildpackPath(t *testing.T) {
 }
 
 func TestUpdateBuildpackWithInvalidPath(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, BuildpackSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, BuildpackSuccess: true}
 	repo, bitsRepo := getRepositories()
 	bitsRepo.UploadBuildpackErr = true
 
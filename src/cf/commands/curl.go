@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"bytes"
+	"cf/api"
+	"cf/requirements"
+	"cf/terminal"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"io/ioutil"
+	"strings"
+)
+
+type Curl struct {
+	ui       terminal.UI
+	curlRepo api.CurlRepository
+}
+
+func NewCurl(ui terminal.UI, curlRepo api.CurlRepository) (cmd *Curl) {
+	cmd = new(Curl)
+	cmd.ui = ui
+	cmd.curlRepo = curlRepo
+	return
+}
+
+func (cmd *Curl) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect number of arguments")
+		cmd.ui.FailWithUsage(c, "curl")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd *Curl) Run(c *cli.Context) {
+	path := c.Args()[0]
+	headers := c.StringSlice("H")
+
+	body, err := requestBody(c.String("d"))
+	if err != nil {
+		cmd.ui.Failed("Error reading request body\n%s", err.Error())
+		return
+	}
+
+	method := strings.ToUpper(c.String("X"))
+	if method == "" {
+		method = "GET"
+		if body != "" {
+			method = "POST"
+		}
+	}
+
+	resHeaders, resBody, apiResponse := cmd.curlRepo.Request(method, path, headers, body)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed(apiResponse.Message)
+		return
+	}
+
+	// error responses are printed as they came back, like any other response
+	if c.Bool("all-pages") && apiResponse.StatusCode < 300 {
+		resBody, err = cmd.fetchRemainingPages(resBody, headers)
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return
+		}
+	}
+
+	if c.Bool("i") {
+		cmd.ui.Say("%s", resHeaders)
+	}
+
+	cmd.ui.Say("%s", prettyPrintJSON(resBody))
+}
+
+func requestBody(data string) (body string, err error) {
+	if !strings.HasPrefix(data, "@") {
+		body = data
+		return
+	}
+
+	contents, err := ioutil.ReadFile(strings.TrimPrefix(data, "@"))
+	if err != nil {
+		return
+	}
+
+	body = string(contents)
+	return
+}
+
+type curlPage map[string]interface{}
+
+func (page curlPage) nextUrl() string {
+	nextUrl, _ := page["next_url"].(string)
+	return nextUrl
+}
+
+func (page curlPage) resources() []interface{} {
+	resources, _ := page["resources"].([]interface{})
+	return resources
+}
+
+func (cmd *Curl) fetchRemainingPages(firstBody string, headers []string) (body string, err error) {
+	firstPage := curlPage{}
+	err = json.Unmarshal([]byte(firstBody), &firstPage)
+	if err != nil {
+		err = errors.New("--all-pages requires a paginated JSON response")
+		return
+	}
+
+	resources := firstPage.resources()
+	page := firstPage
+
+	for page.nextUrl() != "" {
+		_, pageBody, apiResponse := cmd.curlRepo.Request("GET", page.nextUrl(), headers, "")
+		if apiResponse.IsNotSuccessful() {
+			err = errors.New(apiResponse.Message)
+			return
+		}
+		if apiResponse.StatusCode > 299 {
+			err = fmt.Errorf("Error fetching %s, status code: %d\n%s", page.nextUrl(), apiResponse.StatusCode, pageBody)
+			return
+		}
+
+		page = curlPage{}
+		err = json.Unmarshal([]byte(pageBody), &page)
+		if err != nil {
+			return
+		}
+
+		resources = append(resources, page.resources()...)
+	}
+
+	firstPage["resources"] = resources
+	firstPage["total_pages"] = 1
+	firstPage["prev_url"] = nil
+	firstPage["next_url"] = nil
+
+	merged, err := json.Marshal(firstPage)
+	if err != nil {
+		return
+	}
+
+	body = string(merged)
+	return
+}
+
+func prettyPrintJSON(body string) string {
+	buffer := new(bytes.Buffer)
+	err := json.Indent(buffer, []byte(body), "", "   ")
+	if err != nil {
+		return body
+	}
+	return buffer.String()
+}
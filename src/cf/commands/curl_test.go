@@ -0,0 +1,152 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestCurlRequirements(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+
+	callCurl([]string{"/foo"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: false})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	callCurl([]string{"/foo"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestCurlFailsWithUsage(t *testing.T) {
+	ui := callCurl([]string{}, &testapi.FakeCurlRepository{}, &testreq.FakeReqFactory{LoginSuccess: true})
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callCurl([]string{"/foo", "/bar"}, &testapi.FakeCurlRepository{}, &testreq.FakeReqFactory{LoginSuccess: true})
+	assert.True(t, ui.FailedWithUsage)
+}
+
+func TestCurlPerformsAGetRequestByDefault(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{ResponseBody: `{"name":"my-app"}`}
+
+	ui := callCurl([]string{"/v2/apps/my-app-guid"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, curlRepo.Method, "GET")
+	assert.Equal(t, curlRepo.Path, "/v2/apps/my-app-guid")
+	assert.Equal(t, curlRepo.Body, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"{"},
+		{`"name": "my-app"`},
+		{"}"},
+	})
+}
+
+func TestCurlWithDataDefaultsToPost(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+
+	callCurl([]string{"-d", `{"name":"new-app"}`, "/v2/apps"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, curlRepo.Method, "POST")
+	assert.Equal(t, curlRepo.Body, `{"name":"new-app"}`)
+}
+
+func TestCurlWithMethodAndHeaders(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{}
+
+	callCurl([]string{"-X", "put", "-H", "Content-Type: text/plain", "-H", "X-Custom: custom-value", "-d", "hello", "/v2/foo"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, curlRepo.Method, "PUT")
+	assert.Equal(t, curlRepo.Headers, []string{"Content-Type: text/plain", "X-Custom: custom-value"})
+	assert.Equal(t, curlRepo.Body, "hello")
+}
+
+func TestCurlReadsDataFromAFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "curl-body")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(`{"name":"from-file"}`)
+	assert.NoError(t, err)
+	file.Close()
+
+	curlRepo := &testapi.FakeCurlRepository{}
+	callCurl([]string{"-d", "@" + file.Name(), "/v2/apps"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, curlRepo.Body, `{"name":"from-file"}`)
+}
+
+func TestCurlWithIncludeShowsResponseHeaders(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseHeader: "HTTP/1.1 200 OK\r\nContent-Type: text/plain\r\n",
+		ResponseBody:   "plain text body",
+	}
+
+	ui := callCurl([]string{"-i", "/foo"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"HTTP/1.1 200 OK"},
+		{"Content-Type: text/plain"},
+		{"plain text body"},
+	})
+}
+
+func TestCurlFollowsAllPages(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseBodies: []string{
+			`{"total_results": 3, "total_pages": 2, "next_url": "/v2/apps?page=2", "resources": [{"name":"app-1"},{"name":"app-2"}]}`,
+			`{"total_results": 3, "total_pages": 2, "next_url": null, "resources": [{"name":"app-3"}]}`,
+		},
+	}
+
+	ui := callCurl([]string{"--all-pages", "/v2/apps"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.Equal(t, curlRepo.RequestedPaths, []string{"/v2/apps", "/v2/apps?page=2"})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{`"name": "app-1"`},
+		{`"name": "app-2"`},
+		{`"name": "app-3"`},
+		{`"total_pages": 1`},
+	})
+}
+
+func TestCurlShowsErrorResponses(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{
+		ResponseStatus: 404,
+		ResponseHeader: "HTTP/1.1 404 Not Found\r\nContent-Type: application/json\r\n",
+		ResponseBody:   `{"code":10000,"description":"Unknown request"}`,
+	}
+
+	ui := callCurl([]string{"-i", "--all-pages", "/v2/not-there"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"HTTP/1.1 404 Not Found"},
+		{"Content-Type: application/json"},
+		{`"code": 10000`},
+		{`"description": "Unknown request"`},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{{"FAILED"}})
+	assert.Equal(t, curlRepo.RequestedPaths, []string{"/v2/not-there"})
+}
+
+func TestCurlWhenTheRequestFails(t *testing.T) {
+	curlRepo := &testapi.FakeCurlRepository{Error: true}
+
+	ui := callCurl([]string{"/v2/apps"}, curlRepo, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error performing request"},
+	})
+}
+
+func callCurl(args []string, curlRepo *testapi.FakeCurlRepository, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewCurl(ui, curlRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("curl", args), reqFactory)
+	return
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/formatters"
@@ -49,15 +50,10 @@ func (cmd *ListDomains) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	domainsChan, statusChan := cmd.domainRepo.ListDomainsForOrg(org.Guid, stopChan)
-
 	table := cmd.ui.Table([]string{"name", "status", "spaces"})
 	noDomains := true
 
-	for domains := range domainsChan {
+	apiStatus := cmd.domainRepo.ListDomainsForOrg(org.Guid, func(domains []cf.Domain) bool {
 		rows := [][]string{}
 		for _, domain := range domains {
 
@@ -78,9 +74,9 @@ func (cmd *ListDomains) Run(c *cli.Context) {
 		}
 		table.Print(rows)
 		noDomains = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching domains.\n%s", apiStatus.Message)
 		return
@@ -30,8 +30,10 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName = make(map[string]Command)
 
 	factory.cmdsByName["api"] = NewApi(ui, config, repoLocator.GetEndpointRepository())
+	factory.cmdsByName["apply-org-config"] = organization.NewApplyOrgConfig(ui, config, repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository(), repoLocator.GetDomainRepository(), repoLocator.GetQuotaRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["apps"] = application.NewListApps(ui, config, repoLocator.GetAppSummaryRepository())
 	factory.cmdsByName["auth"] = NewAuthenticate(ui, configRepo, repoLocator.GetAuthenticationRepository())
+	factory.cmdsByName["autoscale"] = application.NewAutoscale(ui, config, repoLocator.GetApplicationRepository(), repoLocator.GetAppInstancesRepository(), repoLocator.GetOrganizationRepository())
 	factory.cmdsByName["buildpacks"] = buildpack.NewListBuildpacks(ui, repoLocator.GetBuildpackRepository())
 	factory.cmdsByName["create-buildpack"] = buildpack.NewCreateBuildpack(ui, repoLocator.GetBuildpackRepository(), repoLocator.GetBuildpackBitsRepository())
 	factory.cmdsByName["create-domain"] = domain.NewCreateDomain(ui, config, repoLocator.GetDomainRepository())
@@ -41,6 +43,7 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["create-service-broker"] = servicebroker.NewCreateServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["create-user"] = user.NewCreateUser(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["create-user-provided-service"] = service.NewCreateUserProvidedService(ui, config, repoLocator.GetUserProvidedServiceInstanceRepository())
+	factory.cmdsByName["curl"] = NewCurl(ui, repoLocator.GetCurlRepository())
 	factory.cmdsByName["delete"] = application.NewDeleteApp(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["delete-buildpack"] = buildpack.NewDeleteBuildpack(ui, repoLocator.GetBuildpackRepository())
 	factory.cmdsByName["delete-domain"] = domain.NewDeleteDomain(ui, config, repoLocator.GetDomainRepository())
@@ -52,24 +55,34 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["delete-space"] = space.NewDeleteSpace(ui, config, repoLocator.GetSpaceRepository(), configRepo)
 	factory.cmdsByName["delete-user"] = user.NewDeleteUser(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["domains"] = domain.NewListDomains(ui, config, repoLocator.GetDomainRepository())
+	factory.cmdsByName["download-bits"] = application.NewDownloadBits(ui, config, repoLocator.GetApplicationBitsRepository(), false)
+	factory.cmdsByName["download-droplet"] = application.NewDownloadBits(ui, config, repoLocator.GetApplicationBitsRepository(), true)
 	factory.cmdsByName["env"] = application.NewEnv(ui, config)
 	factory.cmdsByName["events"] = application.NewEvents(ui, config, repoLocator.GetAppEventsRepository())
+	factory.cmdsByName["export-space"] = space.NewExportSpace(ui, config, repoLocator.GetAppSummaryRepository(), repoLocator.GetServiceSummaryRepository(), repoLocator.GetUserProvidedServiceInstanceRepository())
 	factory.cmdsByName["files"] = application.NewFiles(ui, config, repoLocator.GetAppFilesRepository())
+	factory.cmdsByName["import-space"] = space.NewImportSpace(ui, config, repoLocator.GetServiceRepository(), repoLocator.GetUserProvidedServiceInstanceRepository(), repoLocator.GetApplicationRepository(), repoLocator.GetDomainRepository(), repoLocator.GetRouteRepository(), repoLocator.GetServiceBindingRepository(), repoLocator.GetApplicationBitsRepository())
+	factory.cmdsByName["import-users"] = user.NewImportUsers(ui, config, repoLocator.GetUserRepository(), repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
+	factory.cmdsByName["info"] = NewInfo(ui, config, repoLocator.GetEndpointRepository())
 	factory.cmdsByName["login"] = NewLogin(ui, configRepo, repoLocator.GetAuthenticationRepository(), repoLocator.GetEndpointRepository(), repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
 	factory.cmdsByName["logout"] = NewLogout(ui, configRepo)
 	factory.cmdsByName["logs"] = application.NewLogs(ui, config, repoLocator.GetLogsRepository())
 	factory.cmdsByName["marketplace"] = service.NewMarketplaceServices(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["map-domain"] = domain.NewDomainMapper(ui, config, repoLocator.GetDomainRepository(), true)
+	factory.cmdsByName["oauth-token"] = NewOAuthToken(ui, repoLocator.GetAuthenticationRepository())
 	factory.cmdsByName["org"] = organization.NewShowOrg(ui, config)
+	factory.cmdsByName["org-access"] = user.NewOrgAccess(ui, config, repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["org-users"] = user.NewOrgUsers(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["orgs"] = organization.NewListOrgs(ui, config, repoLocator.GetOrganizationRepository())
 	factory.cmdsByName["passwd"] = NewPassword(ui, repoLocator.GetPasswordRepository(), configRepo)
 	factory.cmdsByName["quotas"] = organization.NewListQuotas(ui, config, repoLocator.GetQuotaRepository())
+	factory.cmdsByName["remove-user-from-org"] = user.NewRemoveUserFromOrg(ui, config, repoLocator.GetUserRepository())
 	factory.cmdsByName["rename"] = application.NewRenameApp(ui, config, repoLocator.GetApplicationRepository())
 	factory.cmdsByName["rename-org"] = organization.NewRenameOrg(ui, config, repoLocator.GetOrganizationRepository())
 	factory.cmdsByName["rename-service"] = service.NewRenameService(ui, config, repoLocator.GetServiceRepository())
 	factory.cmdsByName["rename-service-broker"] = servicebroker.NewRenameServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["rename-space"] = space.NewRenameSpace(ui, config, repoLocator.GetSpaceRepository(), configRepo)
+	factory.cmdsByName["restart-app-instance"] = application.NewRestartAppInstance(ui, config, repoLocator.GetAppInstancesRepository())
 	factory.cmdsByName["routes"] = route.NewListRoutes(ui, config, repoLocator.GetRouteRepository())
 	factory.cmdsByName["service"] = service.NewShowService(ui)
 	factory.cmdsByName["service-auth-tokens"] = serviceauthtoken.NewListServiceAuthTokens(ui, config, repoLocator.GetServiceAuthTokenRepository())
@@ -84,6 +97,7 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["spaces"] = space.NewListSpaces(ui, config, repoLocator.GetSpaceRepository())
 	factory.cmdsByName["stacks"] = NewStacks(ui, config, repoLocator.GetStackRepository())
 	factory.cmdsByName["target"] = NewTarget(ui, configRepo, repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
+	factory.cmdsByName["token-info"] = NewTokenInfo(ui, config)
 	factory.cmdsByName["unbind-service"] = service.NewUnbindService(ui, config, repoLocator.GetServiceBindingRepository())
 	factory.cmdsByName["unmap-domain"] = domain.NewDomainMapper(ui, config, repoLocator.GetDomainRepository(), false)
 	factory.cmdsByName["unset-env"] = application.NewUnsetEnv(ui, config, repoLocator.GetApplicationRepository())
@@ -93,6 +107,7 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["update-service-broker"] = servicebroker.NewUpdateServiceBroker(ui, config, repoLocator.GetServiceBrokerRepository())
 	factory.cmdsByName["update-service-auth-token"] = serviceauthtoken.NewUpdateServiceAuthToken(ui, config, repoLocator.GetServiceAuthTokenRepository())
 	factory.cmdsByName["update-user-provided-service"] = service.NewUpdateUserProvidedService(ui, config, repoLocator.GetUserProvidedServiceInstanceRepository())
+	factory.cmdsByName["user-roles"] = user.NewUserRoles(ui, config, repoLocator.GetUserRepository())
 
 	createRoute := route.NewCreateRoute(ui, config, repoLocator.GetRouteRepository())
 	factory.cmdsByName["create-route"] = createRoute
@@ -110,8 +125,11 @@ func NewFactory(ui terminal.UI, config *configuration.Configuration, configRepo
 	factory.cmdsByName["start"] = start
 	factory.cmdsByName["stop"] = stop
 	factory.cmdsByName["restart"] = restart
+	factory.cmdsByName["restage"] = application.NewRestage(ui, start)
+	factory.cmdsByName["upload-droplet"] = application.NewUploadDroplet(ui, config, restart, repoLocator.GetApplicationBitsRepository())
+	factory.cmdsByName["copy-source"] = application.NewCopySource(ui, config, start, stop, repoLocator.GetApplicationRepository(), repoLocator.GetApplicationBitsRepository(), repoLocator.GetOrganizationRepository(), repoLocator.GetSpaceRepository())
 	factory.cmdsByName["push"] = application.NewPush(ui, config, manifestRepo, start, stop, bind, repoLocator.GetApplicationRepository(), repoLocator.GetDomainRepository(), repoLocator.GetRouteRepository(), repoLocator.GetStackRepository(), repoLocator.GetServiceRepository(), repoLocator.GetApplicationBitsRepository())
-	factory.cmdsByName["scale"] = application.NewScale(ui, config, restart, repoLocator.GetApplicationRepository())
+	factory.cmdsByName["scale"] = application.NewScale(ui, config, restart, repoLocator.GetApplicationRepository(), repoLocator.GetOrganizationRepository())
 
 	spaceRoleSetter := user.NewSetSpaceRole(ui, config, repoLocator.GetSpaceRepository(), repoLocator.GetUserRepository())
 	factory.cmdsByName["set-space-role"] = spaceRoleSetter
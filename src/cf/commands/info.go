@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type Info struct {
+	ui           terminal.UI
+	config       *configuration.Configuration
+	endpointRepo api.EndpointRepository
+}
+
+func NewInfo(ui terminal.UI, config *configuration.Configuration, endpointRepo api.EndpointRepository) (cmd Info) {
+	cmd.ui = ui
+	cmd.config = config
+	cmd.endpointRepo = endpointRepo
+	return
+}
+
+func (cmd Info) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	return
+}
+
+func (cmd Info) Run(c *cli.Context) {
+	if cmd.config.Target == "" {
+		cmd.ui.Failed("No API endpoint set. Use '%s api' to set one.", cf.Name())
+		return
+	}
+
+	cmd.ui.Say("API endpoint: %s", terminal.EntityNameColor(cmd.config.Target))
+	cmd.ui.Say("API version: %s", terminal.EntityNameColor(cmd.config.ApiVersion))
+	cmd.ui.Say("Authorization endpoint: %s", terminal.EntityNameColor(cmd.config.AuthorizationEndpoint))
+	cmd.ui.Say("UAA endpoint: %s", terminal.EntityNameColor(cmd.presentEndpoint(cf.UaaEndpointKey)))
+	cmd.ui.Say("Loggregator endpoint: %s", terminal.EntityNameColor(cmd.presentEndpoint(cf.LoggregatorEndpointKey)))
+	cmd.ui.Say("Doppler endpoint: %s", terminal.EntityNameColor(cmd.presentEndpoint(cf.DopplerEndpointKey)))
+	cmd.ui.Say("Routing API endpoint: %s", terminal.EntityNameColor(cmd.presentEndpoint(cf.RoutingApiEndpointKey)))
+	cmd.ui.Say("Min CLI version: %s", terminal.EntityNameColor(presentVersion(cmd.config.MinCliVersion)))
+	cmd.ui.Say("Min recommended CLI version: %s", terminal.EntityNameColor(presentVersion(cmd.config.MinRecommendedCliVersion)))
+	cmd.ui.Say("CLI version: %s", terminal.EntityNameColor(cf.Version))
+
+	cmd.warnAboutCliVersion()
+}
+
+func (cmd Info) presentEndpoint(name cf.EndpointType) string {
+	endpoint, apiResponse := cmd.endpointRepo.GetEndpoint(name)
+	if apiResponse.IsNotSuccessful() {
+		return "none"
+	}
+
+	envVar := api.EndpointOverrideEnvVar(name)
+	if envVar != "" && os.Getenv(envVar) != "" {
+		return fmt.Sprintf("%s (from %s)", endpoint, envVar)
+	}
+	return endpoint
+}
+
+func presentVersion(version string) string {
+	if version == "" {
+		return "none"
+	}
+	return version
+}
+
+func (cmd Info) warnAboutCliVersion() {
+	if cmd.config.MinCliVersion != "" && compareVersions(cf.Version, cmd.config.MinCliVersion) < 0 {
+		cmd.ui.Say("")
+		cmd.ui.Warn("This CLI version is older than the minimum version supported by %s.\nPlease upgrade to %s or later.",
+			cmd.config.Target, cmd.config.MinCliVersion)
+		return
+	}
+
+	if cmd.config.MinRecommendedCliVersion != "" && compareVersions(cf.Version, cmd.config.MinRecommendedCliVersion) < 0 {
+		cmd.ui.Say("")
+		cmd.ui.Warn("Upgrading to CLI version %s or later is recommended.", cmd.config.MinRecommendedCliVersion)
+	}
+}
+
+// compareVersions compares the leading numeric components of two dotted
+// versions, so "6.0.0.rc2-SHA" is treated as 6.0.0.
+func compareVersions(a, b string) int {
+	aParts := numericVersionParts(a)
+	bParts := numericVersionParts(b)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func numericVersionParts(version string) (parts []int) {
+	for _, segment := range strings.Split(version, ".") {
+		part, err := strconv.Atoi(segment)
+		if err != nil {
+			return
+		}
+		parts = append(parts, part)
+	}
+	return
+}
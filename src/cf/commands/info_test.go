@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"cf"
+	. "cf/commands"
+	"cf/configuration"
+	"os"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestInfoShowsAdvertisedEndpoints(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:                "https://api.sys.corp.example",
+		ApiVersion:            "2.1.0",
+		AuthorizationEndpoint: "https://login.sys.corp.example",
+	}
+	endpointRepo := &testapi.FakeEndpointRepo{
+		GetEndpointEndpoints: map[cf.EndpointType]string{
+			cf.UaaEndpointKey:         "https://uaa.sys.corp.example",
+			cf.LoggregatorEndpointKey: "wss://logs.apps.corp.example:8443",
+		},
+	}
+
+	ui := callInfo(config, endpointRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"API endpoint", "https://api.sys.corp.example"},
+		{"API version", "2.1.0"},
+		{"Authorization endpoint", "https://login.sys.corp.example"},
+		{"UAA endpoint", "https://uaa.sys.corp.example"},
+		{"Loggregator endpoint", "wss://logs.apps.corp.example:8443"},
+		{"CLI version", cf.Version},
+	})
+}
+
+func TestInfoShowsEndpointOverrides(t *testing.T) {
+	os.Setenv("CF_UAA_ENDPOINT", "https://uaa.override.example")
+	defer os.Setenv("CF_UAA_ENDPOINT", "")
+
+	config := &configuration.Configuration{Target: "https://api.sys.corp.example"}
+	endpointRepo := &testapi.FakeEndpointRepo{
+		GetEndpointEndpoints: map[cf.EndpointType]string{
+			cf.UaaEndpointKey: "https://uaa.override.example",
+		},
+	}
+
+	ui := callInfo(config, endpointRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"UAA endpoint", "https://uaa.override.example (from CF_UAA_ENDPOINT)"},
+	})
+}
+
+func TestInfoWarnsWhenTheCliIsTooOld(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:        "https://api.sys.corp.example",
+		MinCliVersion: "99.0.0",
+	}
+
+	ui := callInfo(config, &testapi.FakeEndpointRepo{})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Min CLI version", "99.0.0"},
+		{"older than the minimum version"},
+		{"upgrade to 99.0.0"},
+	})
+}
+
+func TestInfoRecommendsUpgrading(t *testing.T) {
+	config := &configuration.Configuration{
+		Target:                   "https://api.sys.corp.example",
+		MinCliVersion:            "6.0.0",
+		MinRecommendedCliVersion: "6.10.0",
+	}
+
+	ui := callInfo(config, &testapi.FakeEndpointRepo{})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Upgrading to CLI version 6.10.0"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"older than the minimum version"},
+	})
+}
+
+func TestInfoWithoutATarget(t *testing.T) {
+	ui := callInfo(&configuration.Configuration{}, &testapi.FakeEndpointRepo{})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"No API endpoint set"},
+	})
+}
+
+func callInfo(config *configuration.Configuration, endpointRepo *testapi.FakeEndpointRepo) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewInfo(ui, config, endpointRepo)
+	testcmd.RunCommand(cmd, testcmd.NewContext("info", []string{}), &testreq.FakeReqFactory{})
+	return
+}
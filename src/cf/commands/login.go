@@ -92,7 +92,10 @@ func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
 		cmd.ui.Say("API endpoint: %s", terminal.EntityNameColor(api))
 	}
 
-	endpoint, apiResponse := cmd.endpointRepo.UpdateEndpoint(api)
+	endpoint, apiResponse := cmd.endpointRepo.UpdateEndpoint(api, c.Bool("allow-insecure-http"))
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
 
 	if !strings.HasPrefix(endpoint, "https://") {
 		cmd.ui.Say(terminal.WarningColor("Warning: Insecure http API endpoint detected: secure https API endpoints are recommended\n"))
@@ -102,6 +105,10 @@ func (cmd Login) setApi(c *cli.Context) (apiResponse net.ApiResponse) {
 }
 
 func (cmd Login) authenticate(c *cli.Context) (apiResponse net.ApiResponse) {
+	if c.Bool("sso") {
+		return cmd.authenticateWithPasscode()
+	}
+
 	username := c.String("u")
 	if username == "" {
 		username = cmd.ui.Ask("Username%s", terminal.PromptColor(">"))
@@ -128,6 +135,26 @@ func (cmd Login) authenticate(c *cli.Context) (apiResponse net.ApiResponse) {
 	return
 }
 
+func (cmd Login) authenticateWithPasscode() (apiResponse net.ApiResponse) {
+	passcodeUrl := cmd.config.AuthorizationEndpoint + "/passcode"
+
+	for i := 0; i < maxLoginTries; i++ {
+		passcode := cmd.ui.AskForPassword("One Time Code (Get one at %s)%s", passcodeUrl, terminal.PromptColor(">"))
+
+		cmd.ui.Say("Authenticating...")
+
+		apiResponse = cmd.authenticator.AuthenticateWithPasscode(passcode)
+		if apiResponse.IsSuccessful() {
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+			break
+		}
+
+		cmd.ui.Say(apiResponse.Message)
+	}
+	return
+}
+
 func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse net.ApiResponse) {
 	orgName := c.String("o")
 
@@ -146,22 +173,13 @@ func (cmd Login) setOrganization(c *cli.Context, userChanged bool) (apiResponse
 			return
 		}
 
-		stopChan := make(chan bool)
-		defer close(stopChan)
-
-		orgsChan, statusChan := cmd.orgRepo.ListOrgs(stopChan)
-
 		availableOrgs := []cf.Organization{}
 
-		for orgs := range orgsChan {
+		apiResponse = cmd.orgRepo.ListOrgs(func(orgs []cf.Organization) bool {
 			availableOrgs = append(availableOrgs, orgs...)
-			if len(availableOrgs) > maxChoices {
-				stopChan <- true
-				break
-			}
-		}
+			return len(availableOrgs) <= maxChoices
+		})
 
-		apiResponse = <-statusChan
 		if apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed("Error finding avilable orgs\n%s", apiResponse.Message)
 			return
@@ -225,22 +243,13 @@ func (cmd Login) setSpace(c *cli.Context, userChanged bool) (apiResponse net.Api
 			return
 		}
 
-		stopChan := make(chan bool)
-		defer close(stopChan)
-
-		spacesChan, statusChan := cmd.spaceRepo.ListSpaces(stopChan)
-
 		var availableSpaces []cf.Space
 
-		for spaces := range spacesChan {
+		apiResponse = cmd.spaceRepo.ListSpaces(func(spaces []cf.Space) bool {
 			availableSpaces = append(availableSpaces, spaces...)
-			if len(availableSpaces) > maxChoices {
-				stopChan <- true
-				break
-			}
-		}
+			return len(availableSpaces) <= maxChoices
+		})
 
-		apiResponse = <-statusChan
 		if apiResponse.IsNotSuccessful() {
 			cmd.ui.Failed("Error finding avilable spaces\n%s", apiResponse.Message)
 			return
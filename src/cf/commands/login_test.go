@@ -397,6 +397,31 @@ func TestSuccessfullyLoggingInWithOnlyOneSpace(t *testing.T) {
 	assert.True(t, c.ui.ShowConfigurationCalled)
 }
 
+func TestSuccessfullyLoggingInWithSSOPasscode(t *testing.T) {
+	c := LoginTestContext{
+		Flags:  []string{"--sso", "-a", "api.example.com", "-o", "my-org", "-s", "my-space"},
+		Inputs: []string{"my-passcode"},
+	}
+
+	callLogin(t, &c, func(c *LoginTestContext) {
+		config, _ := c.configRepo.Get()
+		config.AuthorizationEndpoint = "https://login.example.com"
+	})
+
+	savedConfig := testconfig.SavedConfiguration
+
+	assert.Equal(t, savedConfig.AccessToken, "my_access_token")
+	assert.Equal(t, savedConfig.OrganizationFields.Guid, "my-org-guid")
+	assert.Equal(t, savedConfig.SpaceFields.Guid, "my-space-guid")
+	assert.Equal(t, c.authRepo.Passcode, "my-passcode")
+	assert.Empty(t, c.authRepo.Email)
+
+	testassert.SliceContains(t, c.ui.PasswordPrompts, testassert.Lines{
+		{"One Time Code", "https://login.example.com/passcode"},
+	})
+	assert.True(t, c.ui.ShowConfigurationCalled)
+}
+
 func TestUnsuccessfullyLoggingInWithAuthError(t *testing.T) {
 	c := LoginTestContext{
 		Flags:  []string{"-u", "user@example.com"},
@@ -441,6 +466,20 @@ func TestUnsuccessfullyLoggingInWithUpdateEndpointError(t *testing.T) {
 	})
 }
 
+func TestLoggingInWithAllowInsecureHttp(t *testing.T) {
+	c := LoginTestContext{
+		Flags: []string{"-a", "http://api.example.com", "-u", "user@example.com", "-p", "password", "-o", "my-org", "-s", "my-space", "--allow-insecure-http"},
+	}
+
+	callLogin(t, &c, defaultBeforeBlock)
+
+	assert.Equal(t, c.endpointRepo.UpdateEndpointEndpoint, "http://api.example.com")
+	assert.True(t, c.endpointRepo.UpdateEndpointAllowInsecureHttp)
+	testassert.SliceContains(t, c.ui.Outputs, testassert.Lines{
+		{"Warning: Insecure http API endpoint detected"},
+	})
+}
+
 func TestUnsuccessfullyLoggingInWithOrgFindByNameErr(t *testing.T) {
 	c := LoginTestContext{
 		Flags:  []string{"-u", "user@example.com", "-o", "my-org", "-s", "my-space"},
@@ -0,0 +1,56 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestOAuthTokenRequirements(t *testing.T) {
+	auth := &testapi.FakeAuthenticationRepository{}
+
+	callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: false})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: true})
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestOAuthTokenPrintsARefreshedToken(t *testing.T) {
+	configRepo := testconfig.FakeConfigRepository{}
+	configRepo.Delete()
+
+	auth := &testapi.FakeAuthenticationRepository{
+		ConfigRepo:  configRepo,
+		AccessToken: "bearer my-fresh-token",
+	}
+
+	ui := callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	assert.True(t, auth.RefreshTokenCalled)
+	assert.Equal(t, ui.Outputs, []string{"bearer my-fresh-token"})
+}
+
+func TestOAuthTokenWhenRefreshFails(t *testing.T) {
+	auth := &testapi.FakeAuthenticationRepository{AuthError: true}
+
+	ui := callOAuthToken(auth, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error refreshing token"},
+	})
+}
+
+func callOAuthToken(auth *testapi.FakeAuthenticationRepository, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewOAuthToken(ui, auth)
+	testcmd.RunCommand(cmd, testcmd.NewContext("oauth-token", []string{}), reqFactory)
+	return
+}
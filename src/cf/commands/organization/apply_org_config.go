@@ -0,0 +1,215 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type ApplyOrgConfig struct {
+	ui         terminal.UI
+	config     *configuration.Configuration
+	orgRepo    api.OrganizationRepository
+	spaceRepo  api.SpaceRepository
+	domainRepo api.DomainRepository
+	quotaRepo  api.QuotaRepository
+	userRepo   api.UserRepository
+}
+
+func NewApplyOrgConfig(ui terminal.UI, config *configuration.Configuration, orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository, domainRepo api.DomainRepository, quotaRepo api.QuotaRepository, userRepo api.UserRepository) (cmd *ApplyOrgConfig) {
+	cmd = new(ApplyOrgConfig)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.orgRepo = orgRepo
+	cmd.spaceRepo = spaceRepo
+	cmd.domainRepo = domainRepo
+	cmd.quotaRepo = quotaRepo
+	cmd.userRepo = userRepo
+	return
+}
+
+func (cmd *ApplyOrgConfig) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "apply-org-config")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	}
+	return
+}
+
+func (cmd *ApplyOrgConfig) Run(c *cli.Context) {
+	path := c.Args()[0]
+
+	document, err := parseOrgConfigFile(path)
+	if err != nil {
+		cmd.ui.Failed("Error reading %s\n%s", path, err.Error())
+		return
+	}
+
+	cmd.ui.Say("Comparing %s with the orgs on %s as %s...",
+		terminal.EntityNameColor(path),
+		terminal.EntityNameColor(cmd.config.Target),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	planner := &orgConfigPlanner{
+		orgRepo:    cmd.orgRepo,
+		spaceRepo:  cmd.spaceRepo,
+		domainRepo: cmd.domainRepo,
+		quotaRepo:  cmd.quotaRepo,
+		userRepo:   cmd.userRepo,
+		prune:      c.Bool("prune"),
+	}
+	changes, apiResponse := planner.plan(document)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed planning changes.\n%s", apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(changes) == 0 {
+		cmd.ui.Say("No changes. The orgs match %s", terminal.EntityNameColor(path))
+		return
+	}
+
+	cmd.ui.Say("Plan:")
+	for _, change := range changes {
+		cmd.ui.Say("   %s", change)
+	}
+	cmd.ui.Say("")
+
+	if !c.Bool("f") && !cmd.ui.Confirm("Apply %d changes?%s", len(changes), terminal.PromptColor(">")) {
+		return
+	}
+
+	cmd.ui.Say("Applying changes...")
+
+	applier := newOrgConfigApplier(cmd.orgRepo, cmd.spaceRepo, cmd.domainRepo, cmd.quotaRepo, cmd.userRepo)
+	for index, change := range changes {
+		apiResponse = applier.apply(change)
+		if apiResponse.IsNotSuccessful() {
+			cmd.ui.Failed("Failed to %s after applying %d of %d changes.\n%s", change.description(), index, len(changes), apiResponse.Message)
+			return
+		}
+		cmd.ui.Say("   %s", change)
+	}
+
+	cmd.ui.Ok()
+}
+
+// orgConfigApplier carries out planned changes, looking up the guids of orgs
+// and spaces that were created earlier in the same run.
+type orgConfigApplier struct {
+	orgRepo    api.OrganizationRepository
+	spaceRepo  api.SpaceRepository
+	domainRepo api.DomainRepository
+	quotaRepo  api.QuotaRepository
+	userRepo   api.UserRepository
+
+	orgGuids   map[string]string
+	spaceGuids map[string]string
+}
+
+func newOrgConfigApplier(orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository, domainRepo api.DomainRepository, quotaRepo api.QuotaRepository, userRepo api.UserRepository) (applier *orgConfigApplier) {
+	applier = &orgConfigApplier{
+		orgRepo:    orgRepo,
+		spaceRepo:  spaceRepo,
+		domainRepo: domainRepo,
+		quotaRepo:  quotaRepo,
+		userRepo:   userRepo,
+		orgGuids:   map[string]string{},
+		spaceGuids: map[string]string{},
+	}
+	return
+}
+
+func (applier *orgConfigApplier) apply(change orgConfigChange) (apiResponse net.ApiResponse) {
+	if change.Kind == changeCreateOrg {
+		return applier.orgRepo.Create(change.Org)
+	}
+
+	orgGuid, apiResponse := applier.orgGuid(change.Org)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	switch change.Kind {
+	case changeSetQuota:
+		apiResponse = applier.quotaRepo.Update(orgGuid, change.quotaGuid)
+	case changeCreateDomain:
+		_, apiResponse = applier.domainRepo.Create(change.Name, orgGuid)
+	case changeCreateSpace:
+		var space cf.Space
+		space, apiResponse = applier.spaceRepo.Create(change.Space, orgGuid)
+		if apiResponse.IsSuccessful() {
+			applier.spaceGuids[orgGuid+"/"+change.Space] = space.Guid
+		}
+	case changeGrantRole, changeRevokeRole:
+		apiResponse = applier.applyRole(change, orgGuid)
+	}
+	return
+}
+
+func (applier *orgConfigApplier) applyRole(change orgConfigChange, orgGuid string) (apiResponse net.ApiResponse) {
+	if change.Space == "" {
+		if change.Kind == changeGrantRole {
+			return applier.userRepo.SetOrgRole(change.userGuid, orgGuid, change.Role)
+		}
+		return applier.userRepo.UnsetOrgRole(change.userGuid, orgGuid, change.Role)
+	}
+
+	spaceGuid, apiResponse := applier.spaceGuid(orgGuid, change.Space)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	if change.Kind == changeGrantRole {
+		return applier.userRepo.SetSpaceRole(change.userGuid, spaceGuid, orgGuid, change.Role)
+	}
+	return applier.userRepo.UnsetSpaceRole(change.userGuid, spaceGuid, change.Role)
+}
+
+func (applier *orgConfigApplier) orgGuid(name string) (guid string, apiResponse net.ApiResponse) {
+	guid, found := applier.orgGuids[name]
+	if found {
+		return
+	}
+
+	org, apiResponse := applier.orgRepo.FindByName(name)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	guid = org.Guid
+	applier.orgGuids[name] = guid
+	return
+}
+
+func (applier *orgConfigApplier) spaceGuid(orgGuid, name string) (guid string, apiResponse net.ApiResponse) {
+	key := orgGuid + "/" + name
+	guid, found := applier.spaceGuids[key]
+	if found {
+		return
+	}
+
+	space, apiResponse := applier.spaceRepo.FindByNameInOrg(name, orgGuid)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	guid = space.Guid
+	applier.spaceGuids[key] = guid
+	return
+}
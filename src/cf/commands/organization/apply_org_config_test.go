@@ -0,0 +1,273 @@
+package organization_test
+
+import (
+	"cf"
+	"cf/commands/organization"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+const orgConfigYAML = `orgs:
+- name: my-org
+  quota: paid
+  domains: [existing.com, new.com]
+  users:
+    alice: [OrgManager, OrgAuditor]
+  spaces:
+  - name: development
+    users:
+      bob: [SpaceDeveloper]
+  - name: production
+    users:
+      alice: [SpaceManager]
+- name: new-org
+  users:
+    bob: [BillingManager]
+`
+
+type orgConfigFixtures struct {
+	reqFactory *testreq.FakeReqFactory
+	orgRepo    *testapi.FakeOrgRepository
+	spaceRepo  *testapi.FakeSpaceRepository
+	domainRepo *testapi.FakeDomainRepository
+	quotaRepo  *testapi.FakeQuotaRepository
+	userRepo   *testapi.FakeUserRepository
+}
+
+func TestApplyOrgConfigFailsWithUsage(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+
+	ui := callApplyOrgConfig(t, []string{}, []string{}, fixtures)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callApplyOrgConfig(t, []string{"one.yml", "two.yml"}, []string{}, fixtures)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callApplyOrgConfig(t, []string{"orgs.yml"}, []string{}, fixtures)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestApplyOrgConfigRequirements(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, ScopeSuccess: true}
+	callApplyOrgConfig(t, []string{"orgs.yml"}, []string{}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callApplyOrgConfig(t, []string{"orgs.yml"}, []string{}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, fixtures.reqFactory.Scope, cf.ADMIN_SCOPE)
+}
+
+func TestApplyOrgConfigPlansAndAppliesTheDifferences(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, orgConfigYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{path}, []string{"y"}, fixtures)
+
+	plan := testassert.Lines{
+		{"~ set quota paid on org my-org"},
+		{"+ create domain new.com in org my-org"},
+		{"+ create space production in org my-org"},
+		{"+ grant OrgAuditor to alice in org my-org"},
+		{"+ grant SpaceManager to alice in space my-org/production"},
+		{"+ create org new-org"},
+		{"+ grant BillingManager to bob in org new-org"},
+	}
+	testassert.SliceContains(t, ui.Outputs, append(testassert.Lines{{"Comparing", path, "my-user"}, {"OK"}, {"Plan:"}}, plan...))
+	testassert.SliceContains(t, ui.Outputs, append(append(testassert.Lines{{"Applying changes..."}}, plan...), testassert.Line{"OK"}))
+	assert.Contains(t, ui.Prompts[0], "Apply 7 changes?")
+
+	assert.Equal(t, fixtures.quotaRepo.UpdateOrgGuid, "my-org-guid")
+	assert.Equal(t, fixtures.quotaRepo.UpdateQuotaGuid, "paid-guid")
+	assert.Equal(t, fixtures.domainRepo.CreatedDomains, []string{"new.com my-org-guid"})
+	assert.Equal(t, fixtures.spaceRepo.CreatedSpaces, []string{"production my-org-guid"})
+	assert.Equal(t, fixtures.orgRepo.CreateName, "new-org")
+	assert.Equal(t, fixtures.userRepo.SetOrgRoleCalls, []string{
+		"alice-guid my-org-guid " + cf.ORG_AUDITOR,
+		"bob-guid new-org-guid " + cf.BILLING_MANAGER,
+	})
+	assert.Equal(t, fixtures.userRepo.SetSpaceRoleCalls, []string{
+		"alice-guid production-guid " + cf.SPACE_MANAGER,
+	})
+	assert.Equal(t, len(fixtures.userRepo.UnsetSpaceRoleCalls), 0)
+}
+
+func TestApplyOrgConfigWithPrune(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, orgConfigYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{"--prune", "-f", path}, []string{}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Plan:"},
+		{"+ grant SpaceManager to alice in space my-org/production"},
+		{"- revoke SpaceAuditor from carol in space my-org/development"},
+		{"+ create org new-org"},
+		{"Applying changes..."},
+	})
+	assert.Equal(t, len(ui.Prompts), 0)
+	assert.Equal(t, fixtures.userRepo.UnsetSpaceRoleCalls, []string{"carol-guid development-guid " + cf.SPACE_AUDITOR})
+	assert.Equal(t, len(fixtures.userRepo.UnsetOrgRoleCalls), 0)
+}
+
+func TestApplyOrgConfigWhenTheChangesAreNotConfirmed(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, orgConfigYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{path}, []string{"n"}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{{"Plan:"}})
+	assert.Equal(t, fixtures.orgRepo.CreateName, "")
+	assert.Equal(t, fixtures.quotaRepo.UpdateOrgGuid, "")
+	assert.Equal(t, len(fixtures.userRepo.SetOrgRoleCalls), 0)
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{{"Applying changes..."}})
+}
+
+func TestApplyOrgConfigWhenNothingChanged(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, `orgs:
+- name: my-org
+  quota: free
+  users:
+    alice: [OrgManager]
+  spaces:
+  - name: development
+    users:
+      bob: [SpaceDeveloper]
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{path}, []string{}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"No changes", path},
+	})
+	assert.Equal(t, len(ui.Prompts), 0)
+}
+
+func TestApplyOrgConfigWhenAUserDoesNotExist(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, "orgs:\n- name: my-org\n  users:\n    dave: [OrgManager]\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{path}, []string{}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed planning changes"},
+		{"not found"},
+	})
+	assert.Equal(t, len(ui.Prompts), 0)
+}
+
+func TestApplyOrgConfigValidatesTheFile(t *testing.T) {
+	fixtures := createOrgConfigFixtures()
+	path := writeOrgConfigFile(t, `orgs:
+- name: my-org
+  users:
+    alice: [SpaceDeveloper]
+  spaces:
+  - name: development
+  - name: development
+- quota: paid
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callApplyOrgConfig(t, []string{path}, []string{}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading", path},
+		{"Org my-org: invalid org role SpaceDeveloper for user alice"},
+		{"Org my-org: space development is listed more than once"},
+		{"Org 2 has no name"},
+	})
+}
+
+func createOrgConfigFixtures() (fixtures orgConfigFixtures) {
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+	org.QuotaDefinition.Name = "free"
+
+	development := cf.Space{}
+	development.Name = "development"
+	development.Guid = "development-guid"
+	org.Spaces = []cf.SpaceFields{development.SpaceFields}
+
+	existingDomain := cf.Domain{}
+	existingDomain.Name = "existing.com"
+
+	paid := cf.QuotaFields{}
+	paid.Name = "paid"
+	paid.Guid = "paid-guid"
+
+	alice := cf.UserFields{Guid: "alice-guid", Username: "alice"}
+	bob := cf.UserFields{Guid: "bob-guid", Username: "bob"}
+	carol := cf.UserFields{Guid: "carol-guid", Username: "carol"}
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
+	fixtures.orgRepo = &testapi.FakeOrgRepository{
+		OrganizationsByName: map[string]cf.Organization{"my-org": org},
+	}
+	fixtures.spaceRepo = &testapi.FakeSpaceRepository{FindByNameInOrgSpace: development}
+	fixtures.domainRepo = &testapi.FakeDomainRepository{ListDomainsForOrgDomains: []cf.Domain{existingDomain}}
+	fixtures.quotaRepo = &testapi.FakeQuotaRepository{FindByNameQuota: paid}
+	fixtures.userRepo = &testapi.FakeUserRepository{
+		ExistingUsers: map[string]cf.UserFields{"alice": alice, "bob": bob, "carol": carol},
+		ListUsersByGuidAndRole: map[string]map[string][]cf.UserFields{
+			"my-org-guid": {
+				cf.ORG_MANAGER: {alice},
+			},
+			"development-guid": {
+				cf.SPACE_DEVELOPER: {bob},
+				cf.SPACE_AUDITOR:   {carol},
+			},
+		},
+	}
+	return
+}
+
+func writeOrgConfigFile(t *testing.T, contents string) (path string) {
+	dir, err := ioutil.TempDir("", "apply-org-config")
+	assert.NoError(t, err)
+
+	path = filepath.Join(dir, "orgs.yml")
+	err = ioutil.WriteFile(path, []byte(contents), 0600)
+	assert.NoError(t, err)
+	return
+}
+
+func callApplyOrgConfig(t *testing.T, args []string, inputs []string, fixtures orgConfigFixtures) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{Inputs: inputs}
+	ctxt := testcmd.NewContext("apply-org-config", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	config := &configuration.Configuration{
+		Target:      "https://api.example.com",
+		AccessToken: token,
+	}
+
+	cmd := organization.NewApplyOrgConfig(ui, config, fixtures.orgRepo, fixtures.spaceRepo, fixtures.domainRepo, fixtures.quotaRepo, fixtures.userRepo)
+	testcmd.RunCommand(cmd, ctxt, fixtures.reqFactory)
+	return
+}
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -31,24 +32,19 @@ func (cmd ListOrgs) GetRequirements(reqFactory requirements.Factory, c *cli.Cont
 func (cmd ListOrgs) Run(c *cli.Context) {
 	cmd.ui.Say("Getting orgs as %s...\n", terminal.EntityNameColor(cmd.config.Username()))
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	orgsChan, statusChan := cmd.orgRepo.ListOrgs(stopChan)
-
 	table := cmd.ui.Table([]string{"name"})
 	noOrgs := true
 
-	for orgs := range orgsChan {
+	apiStatus := cmd.orgRepo.ListOrgs(func(orgs []cf.Organization) bool {
 		rows := [][]string{}
 		for _, org := range orgs {
 			rows = append(rows, []string{org.Name})
 		}
 		table.Print(rows)
 		noOrgs = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching orgs.\n%s", apiStatus.Message)
 		return
@@ -0,0 +1,109 @@
+package organization
+
+import (
+	"cf"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"launchpad.net/goyaml"
+	"sort"
+	"strings"
+)
+
+// orgConfigDocument is the desired state read by apply-org-config. Users map
+// usernames to the roles they should hold in the org or space.
+type orgConfigDocument struct {
+	Orgs []orgConfig
+}
+
+type orgConfig struct {
+	Name    string
+	Quota   string
+	Domains []string
+	Users   map[string][]string
+	Spaces  []spaceConfig
+}
+
+type spaceConfig struct {
+	Name  string
+	Users map[string][]string
+}
+
+func parseOrgConfigFile(path string) (document orgConfigDocument, err error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	err = goyaml.Unmarshal(contents, &document)
+	if err != nil {
+		return
+	}
+
+	err = validateOrgConfig(document)
+	return
+}
+
+func validateOrgConfig(document orgConfigDocument) (err error) {
+	problems := []string{}
+	addProblem := func(message string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(message, args...))
+	}
+
+	if len(document.Orgs) == 0 {
+		addProblem("No orgs found")
+	}
+
+	orgNames := map[string]bool{}
+	for index, org := range document.Orgs {
+		if org.Name == "" {
+			addProblem("Org %d has no name", index+1)
+			continue
+		}
+		if orgNames[org.Name] {
+			addProblem("Org %s is listed more than once", org.Name)
+		}
+		orgNames[org.Name] = true
+
+		for _, username := range sortedUsernames(org.Users) {
+			for _, role := range org.Users[username] {
+				if cf.UserInputToOrgRole[role] == "" {
+					addProblem("Org %s: invalid org role %s for user %s", org.Name, role, username)
+				}
+			}
+		}
+
+		spaceNames := map[string]bool{}
+		for spaceIndex, space := range org.Spaces {
+			if space.Name == "" {
+				addProblem("Org %s: space %d has no name", org.Name, spaceIndex+1)
+				continue
+			}
+			if spaceNames[space.Name] {
+				addProblem("Org %s: space %s is listed more than once", org.Name, space.Name)
+			}
+			spaceNames[space.Name] = true
+
+			for _, username := range sortedUsernames(space.Users) {
+				for _, role := range space.Users[username] {
+					if cf.UserInputToSpaceRole[role] == "" {
+						addProblem("Org %s: invalid space role %s for user %s in space %s", org.Name, role, username, space.Name)
+					}
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		err = errors.New(strings.Join(problems, "\n"))
+	}
+	return
+}
+
+func sortedUsernames(users map[string][]string) (usernames []string) {
+	for username := range users {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return
+}
@@ -0,0 +1,320 @@
+package organization
+
+import (
+	"cf"
+	"cf/api"
+	"cf/net"
+	"fmt"
+	"sort"
+)
+
+const (
+	changeCreateOrg = iota
+	changeSetQuota
+	changeCreateDomain
+	changeCreateSpace
+	changeGrantRole
+	changeRevokeRole
+)
+
+var orgConfigOrgRoles = []string{cf.ORG_MANAGER, cf.BILLING_MANAGER, cf.ORG_AUDITOR}
+var orgConfigSpaceRoles = []string{cf.SPACE_MANAGER, cf.SPACE_DEVELOPER, cf.SPACE_AUDITOR}
+
+// orgConfigChange is one step of a plan. Space is empty for changes to the org
+// itself; Name holds the quota, domain or username the change is about.
+type orgConfigChange struct {
+	Kind  int
+	Org   string
+	Space string
+	Name  string
+	Role  string
+
+	quotaGuid string
+	userGuid  string
+}
+
+func (change orgConfigChange) String() string {
+	switch change.Kind {
+	case changeSetQuota:
+		return "~ " + change.description()
+	case changeRevokeRole:
+		return "- " + change.description()
+	}
+	return "+ " + change.description()
+}
+
+func (change orgConfigChange) description() string {
+	switch change.Kind {
+	case changeCreateOrg:
+		return fmt.Sprintf("create org %s", change.Org)
+	case changeSetQuota:
+		return fmt.Sprintf("set quota %s on org %s", change.Name, change.Org)
+	case changeCreateDomain:
+		return fmt.Sprintf("create domain %s in org %s", change.Name, change.Org)
+	case changeCreateSpace:
+		return fmt.Sprintf("create space %s in org %s", change.Space, change.Org)
+	case changeGrantRole:
+		return fmt.Sprintf("grant %s to %s in %s", change.Role, change.Name, change.scopeName())
+	case changeRevokeRole:
+		return fmt.Sprintf("revoke %s from %s in %s", change.Role, change.Name, change.scopeName())
+	}
+	return ""
+}
+
+func (change orgConfigChange) scopeName() string {
+	if change.Space == "" {
+		return "org " + change.Org
+	}
+	return fmt.Sprintf("space %s/%s", change.Org, change.Space)
+}
+
+type roleGrant struct {
+	role string
+	user cf.UserFields
+}
+
+type orgConfigPlanner struct {
+	orgRepo    api.OrganizationRepository
+	spaceRepo  api.SpaceRepository
+	domainRepo api.DomainRepository
+	quotaRepo  api.QuotaRepository
+	userRepo   api.UserRepository
+	prune      bool
+
+	users map[string]cf.UserFields
+}
+
+func (planner *orgConfigPlanner) plan(document orgConfigDocument) (changes []orgConfigChange, apiResponse net.ApiResponse) {
+	planner.users = map[string]cf.UserFields{}
+
+	for _, config := range document.Orgs {
+		var orgChanges []orgConfigChange
+		orgChanges, apiResponse = planner.planOrg(config)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+		changes = append(changes, orgChanges...)
+	}
+	return
+}
+
+func (planner *orgConfigPlanner) planOrg(config orgConfig) (changes []orgConfigChange, apiResponse net.ApiResponse) {
+	org, apiResponse := planner.orgRepo.FindByName(config.Name)
+	exists := apiResponse.IsSuccessful()
+	if apiResponse.IsError() {
+		return
+	}
+	apiResponse = net.NewSuccessfulApiResponse()
+
+	if !exists {
+		changes = append(changes, orgConfigChange{Kind: changeCreateOrg, Org: config.Name})
+	}
+
+	if config.Quota != "" && config.Quota != org.QuotaDefinition.Name {
+		var quota cf.QuotaFields
+		quota, apiResponse = planner.quotaRepo.FindByName(config.Quota)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+		changes = append(changes, orgConfigChange{Kind: changeSetQuota, Org: config.Name, Name: quota.Name, quotaGuid: quota.Guid})
+	}
+
+	existingDomains := map[string]bool{}
+	if exists {
+		apiResponse = planner.domainRepo.ListDomainsForOrg(org.Guid, func(domains []cf.Domain) bool {
+			for _, domain := range domains {
+				existingDomains[domain.Name] = true
+			}
+			return true
+		})
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+	for _, domain := range config.Domains {
+		if !existingDomains[domain] {
+			changes = append(changes, orgConfigChange{Kind: changeCreateDomain, Org: config.Name, Name: domain})
+		}
+	}
+
+	existingSpaces := map[string]cf.SpaceFields{}
+	for _, space := range org.Spaces {
+		existingSpaces[space.Name] = space
+	}
+	for _, space := range config.Spaces {
+		if _, found := existingSpaces[space.Name]; !found {
+			changes = append(changes, orgConfigChange{Kind: changeCreateSpace, Org: config.Name, Space: space.Name})
+		}
+	}
+
+	scopes := []orgConfigScope{{name: "", guid: org.Guid, users: config.Users}}
+	for _, space := range config.Spaces {
+		scopes = append(scopes, orgConfigScope{name: space.Name, guid: existingSpaces[space.Name].Guid, users: space.Users})
+	}
+
+	current, apiResponse := planner.listGrants(scopes)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	grants, revokes := []orgConfigChange{}, []orgConfigChange{}
+	for index, scope := range scopes {
+		desired := map[string]bool{}
+		for _, username := range sortedUsernames(scope.users) {
+			var user cf.UserFields
+			user, apiResponse = planner.findUser(username)
+			if apiResponse.IsNotSuccessful() {
+				return
+			}
+
+			for _, role := range scope.users[username] {
+				role = scope.role(role)
+				desired[user.Guid+" "+role] = true
+				if _, found := current[index][user.Guid+" "+role]; !found {
+					grants = append(grants, orgConfigChange{Kind: changeGrantRole, Org: config.Name, Space: scope.name, Name: user.Username, Role: role, userGuid: user.Guid})
+				}
+			}
+		}
+
+		if !planner.prune {
+			continue
+		}
+
+		for _, grant := range sortedGrants(current[index]) {
+			if !desired[grant.key()] {
+				revokes = append(revokes, orgConfigChange{Kind: changeRevokeRole, Org: config.Name, Space: scope.name, Name: grant.user.Username, Role: grant.role, userGuid: grant.user.Guid})
+			}
+		}
+	}
+
+	// Space roles are revoked before org roles; the cloud controller will not
+	// drop an org role while it backs a role in one of the org's spaces.
+	sort.Stable(revokesSpacesFirst(revokes))
+
+	changes = append(changes, grants...)
+	changes = append(changes, revokes...)
+	return
+}
+
+func (planner *orgConfigPlanner) findUser(username string) (user cf.UserFields, apiResponse net.ApiResponse) {
+	user, found := planner.users[username]
+	if found {
+		return
+	}
+
+	user, apiResponse = planner.userRepo.FindByUsername(username)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+	planner.users[username] = user
+	return
+}
+
+type orgConfigScope struct {
+	name  string
+	guid  string
+	users map[string][]string
+}
+
+func (scope orgConfigScope) roles() []string {
+	if scope.name == "" {
+		return orgConfigOrgRoles
+	}
+	return orgConfigSpaceRoles
+}
+
+func (scope orgConfigScope) role(input string) string {
+	if scope.name == "" {
+		return cf.UserInputToOrgRole[input]
+	}
+	return cf.UserInputToSpaceRole[input]
+}
+
+func (grant roleGrant) key() string {
+	return grant.user.Guid + " " + grant.role
+}
+
+// listGrants returns the role grants of every scope that already exists,
+// keyed by user guid and role.
+func (planner *orgConfigPlanner) listGrants(scopes []orgConfigScope) (grants []map[string]roleGrant, apiResponse net.ApiResponse) {
+	type grantQuery struct {
+		scopeIndex  int
+		role        string
+		users       []cf.UserFields
+		apiResponse net.ApiResponse
+	}
+
+	queries := []*grantQuery{}
+	for index, scope := range scopes {
+		if scope.guid == "" {
+			continue
+		}
+		for _, role := range scope.roles() {
+			queries = append(queries, &grantQuery{scopeIndex: index, role: role})
+		}
+	}
+
+	cf.RunInParallel(len(queries), cf.MAX_PARALLEL_REQUESTS, func(index int) {
+		query := queries[index]
+		scope := scopes[query.scopeIndex]
+		cb := func(users []cf.UserFields) bool {
+			query.users = append(query.users, users...)
+			return true
+		}
+
+		if scope.name == "" {
+			query.apiResponse = planner.userRepo.ListUsersInOrgForRole(scope.guid, query.role, cb)
+		} else {
+			query.apiResponse = planner.userRepo.ListUsersInSpaceForRole(scope.guid, query.role, cb)
+		}
+	})
+
+	grants = make([]map[string]roleGrant, len(scopes))
+	for index := range grants {
+		grants[index] = map[string]roleGrant{}
+	}
+
+	for _, query := range queries {
+		if query.apiResponse.IsNotSuccessful() {
+			apiResponse = query.apiResponse
+			return
+		}
+		for _, user := range query.users {
+			planner.users[user.Username] = user
+			grant := roleGrant{role: query.role, user: user}
+			grants[query.scopeIndex][grant.key()] = grant
+		}
+	}
+	return
+}
+
+func sortedGrants(grants map[string]roleGrant) (sorted []roleGrant) {
+	for _, grant := range grants {
+		sorted = append(sorted, grant)
+	}
+	sort.Sort(grantsByUsername(sorted))
+	return
+}
+
+type grantsByUsername []roleGrant
+
+func (grants grantsByUsername) Len() int { return len(grants) }
+func (grants grantsByUsername) Swap(i, j int) {
+	grants[i], grants[j] = grants[j], grants[i]
+}
+func (grants grantsByUsername) Less(i, j int) bool {
+	if grants[i].user.Username != grants[j].user.Username {
+		return grants[i].user.Username < grants[j].user.Username
+	}
+	return grants[i].role < grants[j].role
+}
+
+type revokesSpacesFirst []orgConfigChange
+
+func (changes revokesSpacesFirst) Len() int { return len(changes) }
+func (changes revokesSpacesFirst) Swap(i, j int) {
+	changes[i], changes[j] = changes[j], changes[i]
+}
+func (changes revokesSpacesFirst) Less(i, j int) bool {
+	return changes[i].Space != "" && changes[j].Space == ""
+}
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -35,6 +36,7 @@ func (cmd *SetQuota) GetRequirements(reqFactory requirements.Factory, c *cli.Con
 
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 		cmd.orgReq,
 	}
 	return
@@ -34,7 +34,7 @@ func TestSetQuotaFailsWithUsage(t *testing.T) {
 func TestSetQuotaRequirements(t *testing.T) {
 	quotaRepo := &testapi.FakeQuotaRepository{}
 
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	callSetQuota(t, []string{"my-org", "my-quota"}, reqFactory, quotaRepo)
 
 	assert.True(t, testcmd.CommandDidPassRequirements)
@@ -44,6 +44,12 @@ func TestSetQuotaRequirements(t *testing.T) {
 	callSetQuota(t, []string{"my-org", "my-quota"}, reqFactory, quotaRepo)
 
 	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callSetQuota(t, []string{"my-org", "my-quota"}, reqFactory, quotaRepo)
+
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
 }
 
 func TestSetQuota(t *testing.T) {
@@ -56,7 +62,7 @@ func TestSetQuota(t *testing.T) {
 	quota.Guid = "my-quota-guid"
 
 	quotaRepo := &testapi.FakeQuotaRepository{FindByNameQuota: quota}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, Organization: org}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true, Organization: org}
 
 	ui := callSetQuota(t, []string{"my-org", "my-quota"}, reqFactory, quotaRepo)
 
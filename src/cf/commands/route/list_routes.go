@@ -1,6 +1,7 @@
 package route
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -32,15 +33,10 @@ func (cmd ListRoutes) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	routesChan, statusChan := cmd.routeRepo.ListRoutes(stopChan)
-
 	table := cmd.ui.Table([]string{"host", "domain", "apps"})
 	noRoutes := true
 
-	for routes := range routesChan {
+	apiStatus := cmd.routeRepo.ListRoutes(func(routes []cf.Route) bool {
 		rows := [][]string{}
 		for _, route := range routes {
 			appNames := ""
@@ -56,9 +52,9 @@ func (cmd ListRoutes) Run(c *cli.Context) {
 		}
 		table.Print(rows)
 		noRoutes = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching routes.\n%s", apiStatus.Message)
 		return
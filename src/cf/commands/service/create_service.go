@@ -65,7 +65,7 @@ func (cmd CreateService) Run(c *cli.Context) {
 	}
 
 	var identicalAlreadyExists bool
-	identicalAlreadyExists, apiResponse = cmd.serviceRepo.CreateServiceInstance(name, plan.Guid)
+	identicalAlreadyExists, apiResponse = cmd.serviceRepo.CreateServiceInstance(name, plan.Guid, nil, false)
 	if apiResponse.IsNotSuccessful() {
 		cmd.ui.Failed(apiResponse.Message)
 		return
@@ -78,6 +78,16 @@ func (cmd CreateService) Run(c *cli.Context) {
 	}
 }
 
+// FindServicePlan finds the plan named planName of the offering with label.
+func FindServicePlan(offerings []cf.ServiceOffering, label, planName string) (plan cf.ServicePlanFields, err error) {
+	offering, err := findOffering(offerings, label)
+	if err != nil {
+		return
+	}
+
+	return findPlan(offering.Plans, planName)
+}
+
 func findOffering(offerings []cf.ServiceOffering, name string) (offering cf.ServiceOffering, err error) {
 	for _, offering := range offerings {
 		if name == offering.Label {
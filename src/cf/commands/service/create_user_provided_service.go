@@ -40,7 +40,7 @@ func (cmd CreateUserProvidedService) Run(c *cli.Context) {
 
 	params := c.String("p")
 	params = strings.Trim(params, `"`)
-	paramsMap := make(map[string]string)
+	paramsMap := make(map[string]interface{})
 
 	err := json.Unmarshal([]byte(params), &paramsMap)
 	if err != nil && params != "" {
@@ -63,7 +63,7 @@ func (cmd CreateUserProvidedService) Run(c *cli.Context) {
 	cmd.ui.Ok()
 }
 
-func (cmd CreateUserProvidedService) mapValuesFromPrompt(params string, paramsMap map[string]string) map[string]string {
+func (cmd CreateUserProvidedService) mapValuesFromPrompt(params string, paramsMap map[string]interface{}) map[string]interface{} {
 	for _, param := range strings.Split(params, ",") {
 		param = strings.Trim(param, " ")
 		paramsMap[param] = cmd.ui.Ask("%s%s", param, terminal.PromptColor(">"))
@@ -30,7 +30,7 @@ func TestCreateUserProvidedServiceWithParameterList(t *testing.T) {
 	})
 
 	assert.Equal(t, repo.CreateName, "my-custom-service")
-	assert.Equal(t, repo.CreateParams, map[string]string{
+	assert.Equal(t, repo.CreateParams, map[string]interface{}{
 		"foo": "foo value",
 		"bar": "bar value",
 		"baz": "baz value",
@@ -53,7 +53,7 @@ func TestCreateUserProvidedServiceWithJson(t *testing.T) {
 	assert.Empty(t, ui.Prompts)
 
 	assert.Equal(t, repo.CreateName, "my-custom-service")
-	assert.Equal(t, repo.CreateParams, map[string]string{
+	assert.Equal(t, repo.CreateParams, map[string]interface{}{
 		"foo": "foo value",
 		"bar": "bar value",
 		"baz": "baz value",
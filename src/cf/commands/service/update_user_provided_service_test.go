@@ -76,7 +76,7 @@ func TestUpdateUserProvidedServiceWithJson(t *testing.T) {
 		{"OK"},
 	})
 	assert.Equal(t, repo.UpdateServiceInstance.Name, serviceInstance.Name)
-	assert.Equal(t, repo.UpdateServiceInstance.Params, map[string]string{"foo": "bar"})
+	assert.Equal(t, repo.UpdateServiceInstance.Params, map[string]interface{}{"foo": "bar"})
 	assert.Equal(t, repo.UpdateServiceInstance.SysLogDrainUrl, "syslog://example.com")
 }
 
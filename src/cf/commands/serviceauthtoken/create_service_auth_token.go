@@ -32,6 +32,7 @@ func (cmd CreateServiceAuthTokenFields) GetRequirements(reqFactory requirements.
 
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 	}
 	return
 }
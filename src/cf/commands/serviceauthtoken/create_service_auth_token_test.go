@@ -38,6 +38,11 @@ func TestCreateServiceAuthTokenRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callCreateServiceAuthToken(t, args, reqFactory, authTokenRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callCreateServiceAuthToken(t, args, reqFactory, authTokenRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory.LoginSuccess = false
@@ -47,7 +52,7 @@ func TestCreateServiceAuthTokenRequirements(t *testing.T) {
 
 func TestCreateServiceAuthToken(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider", "a value"}
 
 	ui := callCreateServiceAuthToken(t, args, reqFactory, authTokenRepo)
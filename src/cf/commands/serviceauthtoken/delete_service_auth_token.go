@@ -1,6 +1,7 @@
 package serviceauthtoken
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -30,7 +31,10 @@ func (cmd DeleteServiceAuthTokenFields) GetRequirements(reqFactory requirements.
 		return
 	}
 
-	reqs = append(reqs, reqFactory.NewLoginRequirement())
+	reqs = append(reqs,
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	)
 	return
 }
 
@@ -36,6 +36,11 @@ func TestDeleteServiceAuthTokenRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory.LoginSuccess = false
@@ -51,7 +56,7 @@ func TestDeleteServiceAuthToken(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{
 		FindByLabelAndProviderServiceAuthTokenFields: expectedToken,
 	}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider"}
 
 	ui := callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
@@ -67,7 +72,7 @@ func TestDeleteServiceAuthToken(t *testing.T) {
 
 func TestDeleteServiceAuthTokenWithN(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider"}
 
 	ui := callDeleteServiceAuthToken(t, args, []string{"N"}, reqFactory, authTokenRepo)
@@ -87,7 +92,7 @@ func TestDeleteServiceAuthTokenWithY(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{
 		FindByLabelAndProviderServiceAuthTokenFields: expectedToken,
 	}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider"}
 
 	ui := callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
@@ -111,7 +116,7 @@ func TestDeleteServiceAuthTokenWithForce(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{
 		FindByLabelAndProviderServiceAuthTokenFields: expectedToken,
 	}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"-f", "a label", "a provider"}
 	ui := callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
 
@@ -128,7 +133,7 @@ func TestDeleteServiceAuthTokenWhenTokenDoesNotExist(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{
 		FindByLabelAndProviderApiResponse: net.NewNotFoundApiResponse("not found"),
 	}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider"}
 
 	ui := callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
@@ -143,7 +148,7 @@ func TestDeleteServiceAuthTokenFailsWithError(t *testing.T) {
 	authTokenRepo := &testapi.FakeAuthTokenRepo{
 		FindByLabelAndProviderApiResponse: net.NewApiResponseWithMessage("OH NOES"),
 	}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider"}
 
 	ui := callDeleteServiceAuthToken(t, args, []string{"Y"}, reqFactory, authTokenRepo)
@@ -1,6 +1,7 @@
 package serviceauthtoken
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -24,6 +25,7 @@ func NewListServiceAuthTokens(ui terminal.UI, config *configuration.Configuratio
 func (cmd ListServiceAuthTokens) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 	}
 	return
 }
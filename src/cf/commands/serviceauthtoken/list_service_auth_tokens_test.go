@@ -24,11 +24,16 @@ func TestListServiceAuthTokensRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callListServiceAuthTokens(t, reqFactory, authTokenRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callListServiceAuthTokens(t, reqFactory, authTokenRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
 func TestListServiceAuthTokens(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	authTokenRepo := &testapi.FakeAuthTokenRepo{}
 	authToken := cf.ServiceAuthTokenFields{}
 	authToken.Label = "a label"
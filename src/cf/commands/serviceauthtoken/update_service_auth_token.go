@@ -1,6 +1,7 @@
 package serviceauthtoken
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -31,6 +32,7 @@ func (cmd UpdateServiceAuthTokenFields) GetRequirements(reqFactory requirements.
 
 	reqs = []requirements.Requirement{
 		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
 	}
 	return
 }
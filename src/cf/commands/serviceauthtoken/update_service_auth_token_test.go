@@ -38,6 +38,11 @@ func TestUpdateServiceAuthTokenRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callUpdateServiceAuthToken(t, args, reqFactory, authTokenRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callUpdateServiceAuthToken(t, args, reqFactory, authTokenRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 
 	reqFactory.LoginSuccess = false
@@ -52,7 +57,7 @@ func TestUpdateServiceAuthToken(t *testing.T) {
 	foundAuthToken.Provider = "found provider"
 
 	authTokenRepo := &testapi.FakeAuthTokenRepo{FindByLabelAndProviderServiceAuthTokenFields: foundAuthToken}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	args := []string{"a label", "a provider", "a value"}
 
 	ui := callUpdateServiceAuthToken(t, args, reqFactory, authTokenRepo)
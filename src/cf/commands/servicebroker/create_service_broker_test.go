@@ -15,7 +15,7 @@ import (
 )
 
 func TestCreateServiceBrokerFailsWithUsage(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	serviceBrokerRepo := &testapi.FakeServiceBrokerRepo{}
 
 	ui := callCreateServiceBroker(t, []string{}, reqFactory, serviceBrokerRepo)
@@ -45,11 +45,16 @@ func TestCreateServiceBrokerRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callCreateServiceBroker(t, args, reqFactory, serviceBrokerRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callCreateServiceBroker(t, args, reqFactory, serviceBrokerRepo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
 func TestCreateServiceBroker(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	serviceBrokerRepo := &testapi.FakeServiceBrokerRepo{}
 	args := []string{"my-broker", "my username", "my password", "http://example.com"}
 	ui := callCreateServiceBroker(t, args, reqFactory, serviceBrokerRepo)
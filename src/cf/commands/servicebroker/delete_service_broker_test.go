@@ -32,6 +32,11 @@ func TestDeleteServiceBrokerRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callDeleteServiceBroker(t, []string{"-f", "my-broker"}, reqFactory, repo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callDeleteServiceBroker(t, []string{"-f", "my-broker"}, reqFactory, repo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
@@ -71,7 +76,7 @@ func TestDeleteWithForceOption(t *testing.T) {
 	serviceBroker.Name = "service-broker-to-delete"
 	serviceBroker.Guid = "service-broker-to-delete-guid"
 
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo := &testapi.FakeServiceBrokerRepo{FindByNameServiceBroker: serviceBroker}
 	ui := callDeleteServiceBroker(t, []string{"-f", "service-broker-to-delete"}, reqFactory, repo)
 
@@ -86,7 +91,7 @@ func TestDeleteWithForceOption(t *testing.T) {
 }
 
 func TestDeleteAppThatDoesNotExist(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo := &testapi.FakeServiceBrokerRepo{FindByNameNotFound: true}
 	ui := callDeleteServiceBroker(t, []string{"-f", "service-broker-to-delete"}, reqFactory, repo)
 
@@ -127,7 +132,7 @@ func deleteServiceBroker(t *testing.T, confirmation string, args []string) (ui *
 	serviceBroker.Name = "service-broker-to-delete"
 	serviceBroker.Guid = "service-broker-to-delete-guid"
 
-	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	repo = &testapi.FakeServiceBrokerRepo{FindByNameServiceBroker: serviceBroker}
 	ui = &testterm.FakeUI{
 		Inputs: []string{confirmation},
@@ -1,6 +1,7 @@
 package servicebroker
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -22,21 +23,20 @@ func NewListServiceBrokers(ui terminal.UI, config *configuration.Configuration,
 }
 
 func (cmd ListServiceBrokers) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	}
 	return
 }
 
 func (cmd ListServiceBrokers) Run(c *cli.Context) {
 	cmd.ui.Say("Getting service brokers as %s...\n", terminal.EntityNameColor(cmd.config.Username()))
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	serviceBrokersChan, statusChan := cmd.repo.ListServiceBrokers(stopChan)
-
 	table := cmd.ui.Table([]string{"name", "url"})
 	noServiceBrokers := true
 
-	for serviceBrokers := range serviceBrokersChan {
+	apiStatus := cmd.repo.ListServiceBrokers(func(serviceBrokers []cf.ServiceBroker) bool {
 		rows := [][]string{}
 		for _, serviceBroker := range serviceBrokers {
 			rows = append(rows, []string{
@@ -46,9 +46,9 @@ func (cmd ListServiceBrokers) Run(c *cli.Context) {
 		}
 		table.Print(rows)
 		noServiceBrokers = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching service brokers.\n%s", apiStatus.Message)
 		return
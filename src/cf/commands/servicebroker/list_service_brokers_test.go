@@ -14,6 +14,23 @@ import (
 	"testing"
 )
 
+func TestListServiceBrokersRequirements(t *testing.T) {
+	repo := &testapi.FakeServiceBrokerRepo{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callListServiceBrokersWithReqFactory(t, []string{}, reqFactory, repo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callListServiceBrokersWithReqFactory(t, []string{}, reqFactory, repo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
+	callListServiceBrokersWithReqFactory(t, []string{}, reqFactory, repo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
 func TestListServiceBrokers(t *testing.T) {
 	broker := cf.ServiceBroker{}
 	broker.Name = "service-broker-to-list-a"
@@ -69,6 +86,11 @@ func TestListingServiceBrokersWhenFindFails(t *testing.T) {
 }
 
 func callListServiceBrokers(t *testing.T, args []string, serviceBrokerRepo *testapi.FakeServiceBrokerRepo) (ui *testterm.FakeUI) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
+	return callListServiceBrokersWithReqFactory(t, args, reqFactory, serviceBrokerRepo)
+}
+
+func callListServiceBrokersWithReqFactory(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, serviceBrokerRepo *testapi.FakeServiceBrokerRepo) (ui *testterm.FakeUI) {
 	ui = &testterm.FakeUI{}
 
 	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
@@ -87,7 +109,7 @@ func callListServiceBrokers(t *testing.T, args []string, serviceBrokerRepo *test
 
 	ctxt := testcmd.NewContext("service-brokers", args)
 	cmd := NewListServiceBrokers(ui, config, serviceBrokerRepo)
-	testcmd.RunCommand(cmd, ctxt, &testreq.FakeReqFactory{})
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
 
 	return
 }
@@ -1,6 +1,7 @@
 package servicebroker
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -29,7 +30,10 @@ func (cmd RenameServiceBroker) GetRequirements(reqFactory requirements.Factory,
 		return
 	}
 
-	reqs = append(reqs, reqFactory.NewLoginRequirement())
+	reqs = append(reqs,
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	)
 
 	return
 }
@@ -39,11 +39,16 @@ func TestRenameServiceBrokerRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callRenameServiceBroker(t, args, reqFactory, repo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callRenameServiceBroker(t, args, reqFactory, repo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
 func TestRenameServiceBroker(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	broker := cf.ServiceBroker{}
 	broker.Name = "my-found-broker"
 	broker.Guid = "my-found-broker-guid"
@@ -45,11 +45,16 @@ func TestUpdateServiceBrokerRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callUpdateServiceBroker(t, args, reqFactory, repo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callUpdateServiceBroker(t, args, reqFactory, repo)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
 func TestUpdateServiceBroker(t *testing.T) {
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	broker := cf.ServiceBroker{}
 	broker.Name = "my-found-broker"
 	broker.Guid = "my-found-broker-guid"
@@ -0,0 +1,152 @@
+package space
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+	"io/ioutil"
+)
+
+type ExportSpace struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	appSummaryRepo     api.AppSummaryRepository
+	serviceSummaryRepo api.ServiceSummaryRepository
+	userProvidedRepo   api.UserProvidedServiceInstanceRepository
+	spaceReq           requirements.SpaceRequirement
+}
+
+func NewExportSpace(ui terminal.UI, config *configuration.Configuration, appSummaryRepo api.AppSummaryRepository, serviceSummaryRepo api.ServiceSummaryRepository, userProvidedRepo api.UserProvidedServiceInstanceRepository) (cmd *ExportSpace) {
+	cmd = new(ExportSpace)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.appSummaryRepo = appSummaryRepo
+	cmd.serviceSummaryRepo = serviceSummaryRepo
+	cmd.userProvidedRepo = userProvidedRepo
+	return
+}
+
+func (cmd *ExportSpace) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "export-space")
+		return
+	}
+
+	cmd.spaceReq = reqFactory.NewSpaceRequirement(c.Args()[0])
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedOrgRequirement(),
+		cmd.spaceReq,
+	}
+	return
+}
+
+func (cmd *ExportSpace) Run(c *cli.Context) {
+	space := cmd.spaceReq.GetSpace()
+	outputPath := c.String("o")
+
+	if outputPath != "" {
+		cmd.ui.Say("Exporting space %s in org %s as %s...",
+			terminal.EntityNameColor(space.Name),
+			terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+	}
+
+	bundle, err := cmd.buildBundle(space.SpaceFields)
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+		return
+	}
+
+	contents, err := writeSpaceBundle(bundle)
+	if err != nil {
+		cmd.ui.Failed("Error writing bundle\n%s", err.Error())
+		return
+	}
+
+	if outputPath == "" {
+		cmd.ui.Say("%s", contents)
+		return
+	}
+
+	err = ioutil.WriteFile(outputPath, contents, 0600)
+	if err != nil {
+		cmd.ui.Failed("Error writing %s\n%s", outputPath, err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("Exported %d apps and %d services to %s", len(bundle.Apps), len(bundle.Services), terminal.EntityNameColor(outputPath))
+
+	for _, service := range bundle.Services {
+		if service.UserProvided && len(service.Credentials) > 0 {
+			cmd.ui.Warn("The bundle contains user-provided service credentials. Keep it somewhere safe.")
+			break
+		}
+	}
+}
+
+func (cmd *ExportSpace) buildBundle(space cf.SpaceFields) (bundle spaceBundle, err error) {
+	bundle.Space = space.Name
+
+	apps, apiResponse := cmd.appSummaryRepo.GetSummariesInSpace(space.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New("Failed fetching apps.\n" + apiResponse.Message)
+		return
+	}
+
+	instances, apiResponse := cmd.serviceSummaryRepo.GetSummariesInSpace(space.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New("Failed fetching services.\n" + apiResponse.Message)
+		return
+	}
+
+	servicesByApp := map[string][]string{}
+	for _, instance := range instances {
+		service := spaceBundleService{Name: instance.Name}
+
+		if instance.IsUserProvided() {
+			var fields cf.ServiceInstanceFields
+			fields, apiResponse = cmd.userProvidedRepo.FindByGuid(instance.Guid)
+			if apiResponse.IsNotSuccessful() {
+				err = errors.New("Failed fetching credentials of " + instance.Name + ".\n" + apiResponse.Message)
+				return
+			}
+			service.UserProvided = true
+			service.Credentials = fields.Params
+			service.SyslogDrainUrl = fields.SysLogDrainUrl
+		} else {
+			service.Label = instance.ServiceOffering.Label
+			service.Provider = instance.ServiceOffering.Provider
+			service.Plan = instance.ServicePlan.Name
+		}
+
+		bundle.Services = append(bundle.Services, service)
+		for _, appName := range instance.ApplicationNames {
+			servicesByApp[appName] = append(servicesByApp[appName], instance.Name)
+		}
+	}
+
+	for _, app := range apps {
+		bundleApp := spaceBundleApp{
+			Name:      app.Name,
+			Memory:    app.Memory,
+			Instances: app.InstanceCount,
+			Command:   app.Command,
+			Buildpack: app.BuildpackUrl,
+			Env:       app.EnvironmentVars,
+			Services:  servicesByApp[app.Name],
+		}
+		for _, route := range app.RouteSummaries {
+			bundleApp.Routes = append(bundleApp.Routes, spaceBundleRoute{Host: route.Host, Domain: route.Domain.Name})
+		}
+		bundle.Apps = append(bundle.Apps, bundleApp)
+	}
+	return
+}
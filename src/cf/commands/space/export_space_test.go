@@ -0,0 +1,222 @@
+package space_test
+
+import (
+	"cf"
+	. "cf/commands/space"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"launchpad.net/goyaml"
+	"os"
+	"path/filepath"
+	"strings"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+type exportSpaceFixtures struct {
+	reqFactory         *testreq.FakeReqFactory
+	appSummaryRepo     *testapi.FakeAppSummaryRepo
+	serviceSummaryRepo *testapi.FakeServiceSummaryRepo
+	userProvidedRepo   *testapi.FakeUserProvidedServiceInstanceRepo
+}
+
+func TestExportSpaceFailsWithUsage(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+
+	ui := callExportSpace(t, []string{}, fixtures)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callExportSpace(t, []string{"my-space"}, fixtures)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestExportSpaceRequirements(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedOrgSuccess: true}
+	callExportSpace(t, []string{"my-space"}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: false}
+	callExportSpace(t, []string{"my-space"}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true}
+	callExportSpace(t, []string{"my-space"}, fixtures)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, fixtures.reqFactory.SpaceName, "my-space")
+}
+
+func TestExportSpaceWritesABundle(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+
+	dir, err := ioutil.TempDir("", "export-space")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bundle.yml")
+
+	ui := callExportSpace(t, []string{"-o", path, "my-space"}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Exporting space", "my-space", "my-org", "my-user"},
+		{"OK"},
+		{"Exported 1 apps and 2 services", path},
+		{"user-provided service credentials"},
+	})
+	assert.Equal(t, fixtures.appSummaryRepo.GetSummariesInSpaceGuid, "my-space-guid")
+	assert.Equal(t, fixtures.serviceSummaryRepo.GetSummariesInSpaceGuid, "my-space-guid")
+
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+
+	bundle := map[string]interface{}{}
+	err = goyaml.Unmarshal(contents, &bundle)
+	assert.NoError(t, err)
+	assert.Equal(t, bundle["space"], "my-space")
+
+	services := bundle["services"].([]interface{})
+	assert.Equal(t, len(services), 2)
+	mysql := services[0].(map[interface{}]interface{})
+	assert.Equal(t, mysql["name"], "my-db")
+	assert.Equal(t, mysql["label"], "cleardb")
+	assert.Equal(t, mysql["plan"], "spark")
+	logger := services[1].(map[interface{}]interface{})
+	assert.Equal(t, logger["user_provided"], true)
+	assert.Equal(t, logger["credentials"], map[interface{}]interface{}{"password": "secret"})
+
+	apps := bundle["apps"].([]interface{})
+	app := apps[0].(map[interface{}]interface{})
+	assert.Equal(t, app["name"], "my-app")
+	assert.Equal(t, app["memory"], 256)
+	assert.Equal(t, app["instances"], 2)
+	assert.Equal(t, app["command"], "bundle exec rackup")
+	assert.Equal(t, app["env"], map[interface{}]interface{}{"RAILS_ENV": "production"})
+	assert.Equal(t, app["services"], []interface{}{"my-db", "my-logger"})
+	assert.Equal(t, app["routes"], []interface{}{
+		map[interface{}]interface{}{"host": "my-app", "domain": "example.com"},
+	})
+}
+
+func TestExportSpaceKeepsStructuredValues(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+	fixtures.appSummaryRepo.GetSummariesInCurrentSpaceApps[0].EnvironmentVars = map[string]interface{}{
+		"WORKERS": float64(4),
+		"CACHE":   map[string]interface{}{"host": "localhost"},
+	}
+	loggerFields := fixtures.userProvidedRepo.FindByGuidInstances["my-logger-guid"]
+	loggerFields.Params = map[string]interface{}{"hosts": []interface{}{"db-1", "db-2"}}
+	fixtures.userProvidedRepo.FindByGuidInstances["my-logger-guid"] = loggerFields
+
+	ui := callExportSpace(t, []string{"my-space"}, fixtures)
+
+	bundle := map[string]interface{}{}
+	err := goyaml.Unmarshal([]byte(strings.Join(ui.Outputs, "\n")), &bundle)
+	assert.NoError(t, err)
+
+	logger := bundle["services"].([]interface{})[1].(map[interface{}]interface{})
+	assert.Equal(t, logger["credentials"], map[interface{}]interface{}{"hosts": []interface{}{"db-1", "db-2"}})
+
+	app := bundle["apps"].([]interface{})[0].(map[interface{}]interface{})
+	assert.Equal(t, app["env"], map[interface{}]interface{}{
+		"WORKERS": 4,
+		"CACHE":   map[interface{}]interface{}{"host": "localhost"},
+	})
+}
+
+func TestExportSpacePrintsTheBundleWithoutAnOutputFile(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+
+	ui := callExportSpace(t, []string{"my-space"}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"space: my-space"},
+		{"services:"},
+		{"name: my-db"},
+		{"apps:"},
+		{"name: my-app"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{{"Exporting space"}})
+}
+
+func TestExportSpaceWhenCredentialsCannotBeRead(t *testing.T) {
+	fixtures := createExportSpaceFixtures()
+	fixtures.userProvidedRepo.FindByGuidInstances = map[string]cf.ServiceInstanceFields{}
+
+	ui := callExportSpace(t, []string{"my-space"}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed fetching credentials of my-logger"},
+	})
+}
+
+func createExportSpaceFixtures() (fixtures exportSpaceFixtures) {
+	space := cf.Space{}
+	space.Name = "my-space"
+	space.Guid = "my-space-guid"
+
+	domain := cf.DomainFields{}
+	domain.Name = "example.com"
+	route := cf.RouteSummary{}
+	route.Host = "my-app"
+	route.Domain = domain
+
+	app := cf.AppSummary{}
+	app.Name = "my-app"
+	app.Memory = 256
+	app.InstanceCount = 2
+	app.Command = "bundle exec rackup"
+	app.EnvironmentVars = map[string]interface{}{"RAILS_ENV": "production"}
+	app.RouteSummaries = []cf.RouteSummary{route}
+
+	db := cf.ServiceInstance{}
+	db.Name = "my-db"
+	db.Guid = "my-db-guid"
+	db.ApplicationNames = []string{"my-app"}
+	db.ServicePlan.Name = "spark"
+	db.ServicePlan.Guid = "spark-guid"
+	db.ServiceOffering.Label = "cleardb"
+
+	logger := cf.ServiceInstance{}
+	logger.Name = "my-logger"
+	logger.Guid = "my-logger-guid"
+	logger.ApplicationNames = []string{"my-app"}
+
+	loggerFields := cf.ServiceInstanceFields{}
+	loggerFields.Name = "my-logger"
+	loggerFields.Params = map[string]interface{}{"password": "secret"}
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedOrgSuccess: true, Space: space}
+	fixtures.appSummaryRepo = &testapi.FakeAppSummaryRepo{GetSummariesInCurrentSpaceApps: []cf.AppSummary{app}}
+	fixtures.serviceSummaryRepo = &testapi.FakeServiceSummaryRepo{GetSummariesInCurrentSpaceInstances: []cf.ServiceInstance{db, logger}}
+	fixtures.userProvidedRepo = &testapi.FakeUserProvidedServiceInstanceRepo{
+		FindByGuidInstances: map[string]cf.ServiceInstanceFields{"my-logger-guid": loggerFields},
+	}
+	return
+}
+
+func callExportSpace(t *testing.T, args []string, fixtures exportSpaceFixtures) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("export-space", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewExportSpace(ui, config, fixtures.appSummaryRepo, fixtures.serviceSummaryRepo, fixtures.userProvidedRepo)
+	testcmd.RunCommand(cmd, ctxt, fixtures.reqFactory)
+	return
+}
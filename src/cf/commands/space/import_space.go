@@ -0,0 +1,295 @@
+package space
+
+import (
+	"cf"
+	"cf/api"
+	"cf/commands/service"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"fmt"
+	"generic"
+	"github.com/codegangsta/cli"
+	"path/filepath"
+)
+
+type ImportSpace struct {
+	ui                 terminal.UI
+	config             *configuration.Configuration
+	serviceRepo        api.ServiceRepository
+	userProvidedRepo   api.UserProvidedServiceInstanceRepository
+	appRepo            api.ApplicationRepository
+	domainRepo         api.DomainRepository
+	routeRepo          api.RouteRepository
+	serviceBindingRepo api.ServiceBindingRepository
+	appBitsRepo        api.ApplicationBitsRepository
+}
+
+func NewImportSpace(ui terminal.UI, config *configuration.Configuration, serviceRepo api.ServiceRepository, userProvidedRepo api.UserProvidedServiceInstanceRepository, appRepo api.ApplicationRepository, domainRepo api.DomainRepository, routeRepo api.RouteRepository, serviceBindingRepo api.ServiceBindingRepository, appBitsRepo api.ApplicationBitsRepository) (cmd *ImportSpace) {
+	cmd = new(ImportSpace)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.serviceRepo = serviceRepo
+	cmd.userProvidedRepo = userProvidedRepo
+	cmd.appRepo = appRepo
+	cmd.domainRepo = domainRepo
+	cmd.routeRepo = routeRepo
+	cmd.serviceBindingRepo = serviceBindingRepo
+	cmd.appBitsRepo = appBitsRepo
+	return
+}
+
+func (cmd *ImportSpace) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "import-space")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewTargetedSpaceRequirement(),
+	}
+	return
+}
+
+func (cmd *ImportSpace) Run(c *cli.Context) {
+	path := c.Args()[0]
+
+	bundle, err := readSpaceBundle(path)
+	if err != nil {
+		cmd.ui.Failed("Error reading %s\n%s", path, err.Error())
+		return
+	}
+
+	cmd.ui.Say("Importing %s into org %s / space %s as %s...",
+		terminal.EntityNameColor(path),
+		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
+		terminal.EntityNameColor(cmd.config.SpaceFields.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	importer := &spaceImporter{
+		cmd:          cmd,
+		bundleDir:    filepath.Dir(path),
+		skipExisting: c.Bool("skip-existing"),
+	}
+
+	table := cmd.ui.Table([]string{"resource", "name", "result"})
+	failures, total := 0, len(bundle.Services)+len(bundle.Apps)
+
+	for _, bundleService := range bundle.Services {
+		result, apiResponse := importer.importService(bundleService)
+		if apiResponse.IsNotSuccessful() {
+			failures++
+			result = "FAILED: " + apiResponse.Message
+		}
+		table.Print([][]string{{"service", bundleService.Name, result}})
+	}
+
+	for _, bundleApp := range bundle.Apps {
+		result, apiResponse := importer.importApp(bundleApp)
+		if apiResponse.IsNotSuccessful() {
+			failures++
+			result = "FAILED: " + apiResponse.Message
+		}
+		table.Print([][]string{{"app", bundleApp.Name, result}})
+	}
+
+	cmd.ui.Say("")
+	if failures > 0 {
+		cmd.ui.Failed("%d of %d resources failed to import", failures, total)
+		return
+	}
+
+	cmd.ui.Ok()
+}
+
+type spaceImporter struct {
+	cmd          *ImportSpace
+	bundleDir    string
+	skipExisting bool
+}
+
+func (importer *spaceImporter) importService(bundleService spaceBundleService) (result string, apiResponse net.ApiResponse) {
+	cmd := importer.cmd
+
+	instance, apiResponse := cmd.serviceRepo.FindInstanceByName(bundleService.Name)
+	if apiResponse.IsError() {
+		return
+	}
+
+	if apiResponse.IsSuccessful() {
+		if !bundleService.UserProvided || importer.skipExisting {
+			result = "skipped, already exists"
+			return
+		}
+
+		instance.Params = bundleService.Credentials
+		instance.SysLogDrainUrl = bundleService.SyslogDrainUrl
+		apiResponse = cmd.userProvidedRepo.Update(instance.ServiceInstanceFields)
+		result = "updated"
+		return
+	}
+
+	if bundleService.UserProvided {
+		apiResponse = cmd.userProvidedRepo.Create(bundleService.Name, bundleService.SyslogDrainUrl, bundleService.Credentials)
+		result = "created"
+		return
+	}
+
+	offerings, apiResponse := cmd.serviceRepo.GetServiceOfferings()
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	plan, found := findBundlePlan(offerings, bundleService)
+	if !found {
+		apiResponse = net.NewNotFoundApiResponse("Plan %s of service %s not found", bundleService.Plan, bundleService.Label)
+		return
+	}
+
+	_, apiResponse = cmd.serviceRepo.CreateServiceInstance(bundleService.Name, plan.Guid, nil, false)
+	result = "created"
+	return
+}
+
+func findBundlePlan(offerings cf.ServiceOfferings, bundleService spaceBundleService) (plan cf.ServicePlanFields, found bool) {
+	for _, offering := range offerings {
+		if offering.Label != bundleService.Label {
+			continue
+		}
+		if bundleService.Provider != "" && offering.Provider != bundleService.Provider {
+			continue
+		}
+		for _, plan = range offering.Plans {
+			if plan.Name == bundleService.Plan {
+				found = true
+				return
+			}
+		}
+	}
+	return
+}
+
+func (importer *spaceImporter) importApp(bundleApp spaceBundleApp) (result string, apiResponse net.ApiResponse) {
+	cmd := importer.cmd
+
+	app, apiResponse := cmd.appRepo.Read(bundleApp.Name)
+	if apiResponse.IsError() {
+		return
+	}
+
+	exists := apiResponse.IsSuccessful()
+	if exists && importer.skipExisting {
+		result = "skipped, already exists"
+		return
+	}
+
+	params := bundleAppParams(bundleApp)
+	if exists {
+		app, apiResponse = cmd.appRepo.Update(app.Guid, params)
+		result = "updated"
+	} else {
+		params.Set("space_guid", cmd.config.SpaceFields.Guid)
+		app, apiResponse = cmd.appRepo.Create(params)
+		result = "created"
+	}
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	for _, bundleRoute := range bundleApp.Routes {
+		apiResponse = importer.bindRoute(app, bundleRoute)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+
+	for _, serviceName := range bundleApp.Services {
+		apiResponse = importer.bindService(app, serviceName)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+
+	if bundleApp.Path != "" {
+		apiResponse = cmd.appBitsRepo.UploadApp(app.Guid, filepath.Join(importer.bundleDir, bundleApp.Path))
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+		result += ", bits uploaded"
+	}
+	return
+}
+
+func bundleAppParams(bundleApp spaceBundleApp) (params cf.AppParams) {
+	params = cf.NewEmptyAppParams()
+	params.Set("name", bundleApp.Name)
+
+	if bundleApp.Memory != 0 {
+		params.Set("memory", bundleApp.Memory)
+	}
+	if bundleApp.Instances != 0 {
+		params.Set("instances", bundleApp.Instances)
+	}
+	if bundleApp.Command != "" {
+		params.Set("command", bundleApp.Command)
+	}
+	if bundleApp.Buildpack != "" {
+		params.Set("buildpack", bundleApp.Buildpack)
+	}
+	if len(bundleApp.Env) > 0 {
+		params.Set("env", generic.NewMap(bundleApp.Env))
+	}
+	return
+}
+
+func (importer *spaceImporter) bindRoute(app cf.Application, bundleRoute spaceBundleRoute) (apiResponse net.ApiResponse) {
+	cmd := importer.cmd
+
+	domain, apiResponse := cmd.domainRepo.FindByNameInCurrentSpace(bundleRoute.Domain)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	route, apiResponse := cmd.routeRepo.FindByHostAndDomain(bundleRoute.Host, bundleRoute.Domain)
+	if apiResponse.IsError() {
+		return
+	}
+	if apiResponse.IsNotFound() {
+		route, apiResponse = cmd.routeRepo.CreateInSpace(bundleRoute.Host, domain.Guid, cmd.config.SpaceFields.Guid)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+
+	for _, boundRoute := range app.Routes {
+		if boundRoute.Guid == route.Guid {
+			return
+		}
+	}
+
+	apiResponse = cmd.routeRepo.Bind(route.Guid, app.Guid)
+	if apiResponse.IsNotSuccessful() {
+		apiResponse.Message = fmt.Sprintf("Could not bind route %s\n%s", bundleRoute, apiResponse.Message)
+	}
+	return
+}
+
+func (importer *spaceImporter) bindService(app cf.Application, serviceName string) (apiResponse net.ApiResponse) {
+	cmd := importer.cmd
+
+	instance, apiResponse := cmd.serviceRepo.FindInstanceByName(serviceName)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	apiResponse = cmd.serviceBindingRepo.Create(instance.Guid, app.Guid)
+	if apiResponse.ErrorCode == service.AppAlreadyBoundErrorCode {
+		apiResponse = net.NewSuccessfulApiResponse()
+	}
+	return
+}
@@ -0,0 +1,322 @@
+package space_test
+
+import (
+	"cf"
+	. "cf/commands/space"
+	"cf/configuration"
+	"generic"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+const spaceBundleYAML = `space: development
+services:
+- name: my-db
+  label: cleardb
+  plan: spark
+- name: my-logger
+  user_provided: true
+  credentials:
+    password: secret
+apps:
+- name: my-app
+  memory: 256
+  instances: 2
+  command: bundle exec rackup
+  env:
+    RAILS_ENV: production
+  routes:
+  - host: my-app
+    domain: example.com
+  services: [my-db]
+  path: my-app
+`
+
+type importSpaceFixtures struct {
+	reqFactory         *testreq.FakeReqFactory
+	serviceRepo        *testapi.FakeServiceRepo
+	userProvidedRepo   *testapi.FakeUserProvidedServiceInstanceRepo
+	appRepo            *testapi.FakeApplicationRepository
+	domainRepo         *testapi.FakeDomainRepository
+	routeRepo          *testapi.FakeRouteRepository
+	serviceBindingRepo *testapi.FakeServiceBindingRepo
+	appBitsRepo        *testapi.FakeApplicationBitsRepository
+}
+
+func TestImportSpaceFailsWithUsage(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+
+	ui := callImportSpace(t, []string{}, fixtures)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callImportSpace(t, []string{"bundle.yml"}, fixtures)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestImportSpaceKeepsStructuredValues(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	path := writeSpaceBundleFile(t, `services:
+- name: my-logger
+  user_provided: true
+  credentials:
+    hosts: [db-1, db-2]
+    tls:
+      verify: true
+apps:
+- name: my-app
+  env:
+    WORKERS: 4
+    CACHE:
+      host: localhost
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	callImportSpace(t, []string{path}, fixtures)
+
+	assert.Equal(t, fixtures.userProvidedRepo.CreateParams, map[string]interface{}{
+		"hosts": []interface{}{"db-1", "db-2"},
+		"tls":   map[string]interface{}{"verify": true},
+	})
+
+	env := fixtures.appRepo.CreatedAppParams().Get("env").(generic.Map)
+	assert.Equal(t, env.Get("WORKERS"), 4)
+	assert.Equal(t, env.Get("CACHE"), map[string]interface{}{"host": "localhost"})
+}
+
+func TestImportSpaceRequirements(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: false, TargetedSpaceSuccess: true}
+	callImportSpace(t, []string{"bundle.yml"}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: false}
+	callImportSpace(t, []string{"bundle.yml"}, fixtures)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestImportSpaceCreatesServicesRoutesAndApps(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	path := writeSpaceBundleFile(t, spaceBundleYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportSpace(t, []string{path}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Importing", path, "my-org", "my-space", "my-user"},
+		{"service", "my-db", "created"},
+		{"service", "my-logger", "created"},
+		{"app", "my-app", "created, bits uploaded"},
+		{"OK"},
+	})
+
+	assert.Equal(t, fixtures.serviceRepo.CreateServiceInstanceName, "my-db")
+	assert.Equal(t, fixtures.serviceRepo.CreateServiceInstancePlanGuid, "spark-guid")
+	assert.Equal(t, fixtures.userProvidedRepo.CreateName, "my-logger")
+	assert.Equal(t, fixtures.userProvidedRepo.CreateParams, map[string]interface{}{"password": "secret"})
+
+	params := fixtures.appRepo.CreatedAppParams()
+	assert.Equal(t, params.Get("name"), "my-app")
+	assert.Equal(t, params.Get("space_guid"), "my-space-guid")
+	assert.Equal(t, params.Get("memory"), uint64(256))
+	assert.Equal(t, params.Get("instances"), 2)
+	assert.Equal(t, params.Get("command"), "bundle exec rackup")
+	assert.Equal(t, params.Get("env").(generic.Map).Get("RAILS_ENV"), "production")
+
+	assert.Equal(t, fixtures.domainRepo.FindByNameInCurrentSpaceName, "example.com")
+	assert.Equal(t, fixtures.routeRepo.CreateInSpaceHost, "my-app")
+	assert.Equal(t, fixtures.routeRepo.CreateInSpaceDomainGuid, "example-domain-guid")
+	assert.Equal(t, fixtures.routeRepo.CreateInSpaceSpaceGuid, "my-space-guid")
+	assert.Equal(t, fixtures.routeRepo.BoundRouteGuid, "my-app-route-guid")
+	assert.Equal(t, fixtures.routeRepo.BoundAppGuid, "my-app-guid")
+
+	assert.Equal(t, fixtures.serviceBindingRepo.CreatedBindings, []string{"my-db-guid my-app-guid"})
+
+	assert.Equal(t, fixtures.appBitsRepo.UploadedAppGuid, "my-app-guid")
+	assert.Equal(t, fixtures.appBitsRepo.UploadedDir, filepath.Join(filepath.Dir(path), "my-app"))
+}
+
+func TestImportSpaceUpdatesExistingResources(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	fixtures.serviceRepo.FindInstanceByNameMap.Set("my-db", existingServiceInstance("my-db", "spark-guid"))
+	fixtures.serviceRepo.FindInstanceByNameMap.Set("my-logger", existingServiceInstance("my-logger", ""))
+
+	existingApp := cf.Application{}
+	existingApp.Name = "my-app"
+	existingApp.Guid = "existing-app-guid"
+	fixtures.appRepo.ReadNotFound = false
+	fixtures.appRepo.ReadApp = existingApp
+	fixtures.appRepo.UpdateAppResult = existingApp
+
+	path := writeSpaceBundleFile(t, spaceBundleYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportSpace(t, []string{path}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"service", "my-db", "skipped, already exists"},
+		{"service", "my-logger", "updated"},
+		{"app", "my-app", "updated, bits uploaded"},
+		{"OK"},
+	})
+
+	assert.Equal(t, fixtures.serviceRepo.CreateServiceInstanceName, "")
+	assert.Equal(t, fixtures.userProvidedRepo.UpdateServiceInstance.Guid, "my-logger-guid")
+	assert.Equal(t, fixtures.userProvidedRepo.UpdateServiceInstance.Params, map[string]interface{}{"password": "secret"})
+	assert.Equal(t, fixtures.appRepo.UpdateAppGuid, "existing-app-guid")
+	assert.Equal(t, fixtures.appRepo.UpdateParams.Get("memory"), uint64(256))
+	assert.Equal(t, len(fixtures.appRepo.CreateAppParams), 0)
+}
+
+func TestImportSpaceWithSkipExisting(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	fixtures.serviceRepo.FindInstanceByNameMap.Set("my-logger", existingServiceInstance("my-logger", ""))
+
+	existingApp := cf.Application{}
+	existingApp.Name = "my-app"
+	existingApp.Guid = "existing-app-guid"
+	fixtures.appRepo.ReadNotFound = false
+	fixtures.appRepo.ReadApp = existingApp
+
+	path := writeSpaceBundleFile(t, spaceBundleYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportSpace(t, []string{"--skip-existing", path}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"service", "my-db", "created"},
+		{"service", "my-logger", "skipped, already exists"},
+		{"app", "my-app", "skipped, already exists"},
+		{"OK"},
+	})
+	assert.Equal(t, fixtures.userProvidedRepo.UpdateServiceInstance.Guid, "")
+	assert.Equal(t, fixtures.appRepo.UpdateAppGuid, "")
+	assert.Equal(t, fixtures.appBitsRepo.UploadedAppGuid, "")
+}
+
+func TestImportSpaceReportsFailuresAndContinues(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	fixtures.serviceRepo.ServiceOfferings = []cf.ServiceOffering{}
+
+	path := writeSpaceBundleFile(t, spaceBundleYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportSpace(t, []string{path}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"service", "my-db", "FAILED", "Plan spark of service cleardb not found"},
+		{"service", "my-logger", "created"},
+		{"app", "my-app", "FAILED"},
+		{"FAILED"},
+		{"2 of 3 resources failed to import"},
+	})
+}
+
+func TestImportSpaceValidatesTheBundle(t *testing.T) {
+	fixtures := createImportSpaceFixtures()
+	path := writeSpaceBundleFile(t, `services:
+- name: my-db
+- label: cleardb
+apps:
+- name: my-app
+  routes:
+  - host: my-app
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportSpace(t, []string{path}, fixtures)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading", path},
+		{"Service my-db needs a label and a plan"},
+		{"Service 2 has no name"},
+		{"App my-app has a route without a domain"},
+	})
+	assert.Equal(t, fixtures.serviceRepo.CreateServiceInstanceName, "")
+}
+
+func existingServiceInstance(name, planGuid string) (instance cf.ServiceInstance) {
+	instance.Name = name
+	instance.Guid = name + "-guid"
+	instance.ServicePlan.Guid = planGuid
+	return
+}
+
+func createImportSpaceFixtures() (fixtures importSpaceFixtures) {
+	plan := cf.ServicePlanFields{}
+	plan.Name = "spark"
+	plan.Guid = "spark-guid"
+
+	offering := cf.ServiceOffering{}
+	offering.Label = "cleardb"
+	offering.Plans = []cf.ServicePlanFields{plan}
+
+	domain := cf.Domain{}
+	domain.Name = "example.com"
+	domain.Guid = "example-domain-guid"
+
+	route := cf.Route{}
+	route.Guid = "my-app-route-guid"
+	route.Host = "my-app"
+
+	fixtures.reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, TargetedSpaceSuccess: true}
+	fixtures.serviceRepo = &testapi.FakeServiceRepo{
+		ServiceOfferings:           []cf.ServiceOffering{offering},
+		FindInstanceByNameMap:      generic.NewMap(),
+		FindInstanceByNameNotFound: true,
+	}
+	fixtures.userProvidedRepo = &testapi.FakeUserProvidedServiceInstanceRepo{}
+	fixtures.appRepo = &testapi.FakeApplicationRepository{ReadNotFound: true}
+	fixtures.domainRepo = &testapi.FakeDomainRepository{FindByNameDomain: domain}
+	fixtures.routeRepo = &testapi.FakeRouteRepository{
+		FindByHostAndDomainNotFound: true,
+		CreateInSpaceCreatedRoute:   route,
+	}
+	fixtures.serviceBindingRepo = &testapi.FakeServiceBindingRepo{}
+	fixtures.appBitsRepo = &testapi.FakeApplicationBitsRepository{}
+	return
+}
+
+func writeSpaceBundleFile(t *testing.T, contents string) (path string) {
+	dir, err := ioutil.TempDir("", "import-space")
+	assert.NoError(t, err)
+
+	path = filepath.Join(dir, "bundle.yml")
+	err = ioutil.WriteFile(path, []byte(contents), 0600)
+	assert.NoError(t, err)
+	return
+}
+
+func callImportSpace(t *testing.T, args []string, fixtures importSpaceFixtures) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	ctxt := testcmd.NewContext("import-space", args)
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	space := cf.SpaceFields{}
+	space.Name = "my-space"
+	space.Guid = "my-space-guid"
+	org := cf.OrganizationFields{}
+	org.Name = "my-org"
+	config := &configuration.Configuration{
+		SpaceFields:        space,
+		OrganizationFields: org,
+		AccessToken:        token,
+	}
+
+	cmd := NewImportSpace(ui, config, fixtures.serviceRepo, fixtures.userProvidedRepo, fixtures.appRepo, fixtures.domainRepo, fixtures.routeRepo, fixtures.serviceBindingRepo, fixtures.appBitsRepo)
+	testcmd.RunCommand(cmd, ctxt, fixtures.reqFactory)
+	return
+}
@@ -1,6 +1,7 @@
 package space
 
 import (
+	"cf"
 	"cf/api"
 	"cf/configuration"
 	"cf/requirements"
@@ -34,24 +35,19 @@ func (cmd ListSpaces) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.OrganizationFields.Name),
 		terminal.EntityNameColor(cmd.config.Username()))
 
-	stopChan := make(chan bool)
-	defer close(stopChan)
-
-	spacesChan, statusChan := cmd.spaceRepo.ListSpaces(stopChan)
-
 	table := cmd.ui.Table([]string{"name"})
 	noSpaces := true
 
-	for spaces := range spacesChan {
+	apiStatus := cmd.spaceRepo.ListSpaces(func(spaces []cf.Space) bool {
 		rows := [][]string{}
 		for _, space := range spaces {
 			rows = append(rows, []string{space.Name})
 		}
 		table.Print(rows)
 		noSpaces = false
-	}
+		return true
+	})
 
-	apiStatus := <-statusChan
 	if apiStatus.IsNotSuccessful() {
 		cmd.ui.Failed("Failed fetching spaces.\n%s", apiStatus.Message)
 		return
@@ -0,0 +1,139 @@
+package space
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"launchpad.net/goyaml"
+	"strings"
+)
+
+// spaceBundle is the file written by export-space and read by import-space.
+// It describes what to recreate, not the guids of the space it came from.
+type spaceBundle struct {
+	Space    string               `yaml:"space,omitempty"`
+	Services []spaceBundleService `yaml:"services,omitempty"`
+	Apps     []spaceBundleApp     `yaml:"apps,omitempty"`
+}
+
+type spaceBundleService struct {
+	Name           string                 `yaml:"name"`
+	Label          string                 `yaml:"label,omitempty"`
+	Provider       string                 `yaml:"provider,omitempty"`
+	Plan           string                 `yaml:"plan,omitempty"`
+	UserProvided   bool                   `yaml:"user_provided,omitempty"`
+	Credentials    map[string]interface{} `yaml:"credentials,omitempty"`
+	SyslogDrainUrl string                 `yaml:"syslog_drain_url,omitempty"`
+}
+
+type spaceBundleApp struct {
+	Name      string                 `yaml:"name"`
+	Memory    uint64                 `yaml:"memory,omitempty"`
+	Instances int                    `yaml:"instances,omitempty"`
+	Command   string                 `yaml:"command,omitempty"`
+	Buildpack string                 `yaml:"buildpack,omitempty"`
+	Env       map[string]interface{} `yaml:"env,omitempty"`
+	Routes    []spaceBundleRoute     `yaml:"routes,omitempty"`
+	Services  []string               `yaml:"services,omitempty"`
+	Path      string                 `yaml:"path,omitempty"`
+}
+
+type spaceBundleRoute struct {
+	Host   string `yaml:"host,omitempty"`
+	Domain string `yaml:"domain"`
+}
+
+func (route spaceBundleRoute) String() string {
+	if route.Host == "" {
+		return route.Domain
+	}
+	return fmt.Sprintf("%s.%s", route.Host, route.Domain)
+}
+
+func writeSpaceBundle(bundle spaceBundle) (contents []byte, err error) {
+	return goyaml.Marshal(bundle)
+}
+
+func readSpaceBundle(path string) (bundle spaceBundle, err error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	err = goyaml.Unmarshal(contents, &bundle)
+	if err != nil {
+		return
+	}
+
+	for index := range bundle.Services {
+		bundle.Services[index].Credentials = stringKeyedMap(bundle.Services[index].Credentials)
+	}
+	for index := range bundle.Apps {
+		bundle.Apps[index].Env = stringKeyedMap(bundle.Apps[index].Env)
+	}
+
+	err = validateSpaceBundle(bundle)
+	return
+}
+
+func validateSpaceBundle(bundle spaceBundle) (err error) {
+	problems := []string{}
+
+	for index, service := range bundle.Services {
+		switch {
+		case service.Name == "":
+			problems = append(problems, fmt.Sprintf("Service %d has no name", index+1))
+		case !service.UserProvided && (service.Label == "" || service.Plan == ""):
+			problems = append(problems, fmt.Sprintf("Service %s needs a label and a plan", service.Name))
+		}
+	}
+
+	for index, app := range bundle.Apps {
+		if app.Name == "" {
+			problems = append(problems, fmt.Sprintf("App %d has no name", index+1))
+			continue
+		}
+		for _, route := range app.Routes {
+			if route.Domain == "" {
+				problems = append(problems, fmt.Sprintf("App %s has a route without a domain", app.Name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		err = errors.New(strings.Join(problems, "\n"))
+	}
+	return
+}
+
+// stringKeyedMap converts the nested maps the yaml parser returns into maps
+// keyed by strings, so that structured values can be sent as json.
+func stringKeyedMap(input map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+	for key, value := range input {
+		result[key] = stringKeyedValue(value)
+	}
+	return result
+}
+
+func stringKeyedValue(input interface{}) interface{} {
+	switch input := input.(type) {
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range input {
+			result[fmt.Sprintf("%v", key)] = stringKeyedValue(value)
+		}
+		return result
+	case []interface{}:
+		result := []interface{}{}
+		for _, value := range input {
+			result = append(result, stringKeyedValue(value))
+		}
+		return result
+	}
+	return input
+}
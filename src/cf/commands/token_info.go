@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"github.com/codegangsta/cli"
+	"strings"
+	"time"
+)
+
+const tokenExpiryFormat = "2006-01-02 03:04:05 PM MST"
+
+type TokenInfo struct {
+	ui     terminal.UI
+	config *configuration.Configuration
+}
+
+func NewTokenInfo(ui terminal.UI, config *configuration.Configuration) (cmd TokenInfo) {
+	cmd.ui = ui
+	cmd.config = config
+	return
+}
+
+func (cmd TokenInfo) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+	}
+	return
+}
+
+func (cmd TokenInfo) Run(c *cli.Context) {
+	cmd.ui.Say("Getting token info...")
+
+	info, err := configuration.NewTokenInfo(cmd.config.GetAccessToken())
+	if err != nil {
+		cmd.ui.Failed("Unable to decode access token.\n%s", err.Error())
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	user := info.Username
+	if user == "" {
+		user = "none"
+	}
+
+	cmd.ui.Say("user:     %s", terminal.EntityNameColor(user))
+	cmd.ui.Say("client:   %s", terminal.EntityNameColor(info.ClientId))
+	cmd.ui.Say("issuer:   %s", terminal.EntityNameColor(info.Issuer))
+	cmd.ui.Say("scopes:   %s", terminal.EntityNameColor(strings.Join(info.Scopes, ", ")))
+	cmd.ui.Say("expires:  %s", terminal.EntityNameColor(cmd.presentExpiry(info)))
+}
+
+func (cmd TokenInfo) presentExpiry(info configuration.TokenInfo) string {
+	if info.ExpiresAt == 0 {
+		return "never"
+	}
+
+	expiry := info.ExpirationTime().Local().Format(tokenExpiryFormat)
+	if info.ExpiresWithin(0) {
+		return expiry + " (expired)"
+	}
+
+	remaining := info.ExpirationTime().Sub(time.Now()) / time.Second * time.Second
+	return expiry + " (in " + remaining.String() + ")"
+}
@@ -0,0 +1,70 @@
+package commands_test
+
+import (
+	. "cf/commands"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+	"time"
+)
+
+func TestTokenInfoRequirements(t *testing.T) {
+	config := &configuration.Configuration{}
+
+	callTokenInfo(config, &testreq.FakeReqFactory{LoginSuccess: false})
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	callTokenInfo(config, &testreq.FakeReqFactory{LoginSuccess: true})
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestTokenInfoShowsTheDecodedToken(t *testing.T) {
+	accessToken, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username:  "user1@example.com",
+		ClientId:  "cf",
+		Issuer:    "https://uaa.example.com/oauth/token",
+		Scopes:    []string{"cloud_controller.read", "cloud_controller.write"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	ui := callTokenInfo(&configuration.Configuration{AccessToken: accessToken}, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting token info"},
+		{"OK"},
+		{"user", "user1@example.com"},
+		{"client", "cf"},
+		{"issuer", "https://uaa.example.com/oauth/token"},
+		{"scopes", "cloud_controller.read, cloud_controller.write"},
+		{"expires", "(in "},
+	})
+}
+
+func TestTokenInfoShowsExpiredTokens(t *testing.T) {
+	accessToken, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		ClientId:  "my-ci-client",
+		ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	ui := callTokenInfo(&configuration.Configuration{AccessToken: accessToken}, &testreq.FakeReqFactory{LoginSuccess: true})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"user", "none"},
+		{"client", "my-ci-client"},
+		{"expires", "(expired)"},
+	})
+}
+
+func callTokenInfo(config *configuration.Configuration, reqFactory *testreq.FakeReqFactory) (ui *testterm.FakeUI) {
+	ui = new(testterm.FakeUI)
+	cmd := NewTokenInfo(ui, config)
+	testcmd.RunCommand(cmd, testcmd.NewContext("token-info", []string{}), reqFactory)
+	return
+}
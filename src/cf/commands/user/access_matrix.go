@@ -0,0 +1,220 @@
+package user
+
+import (
+	"bytes"
+	"cf"
+	"cf/api"
+	"cf/net"
+	"cf/terminal"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"sort"
+	"strings"
+)
+
+// accessEntry holds the roles one user has in one org or space. Space is empty
+// for org roles.
+type accessEntry struct {
+	Username string   `json:"user"`
+	UserGuid string   `json:"user_guid"`
+	Org      string   `json:"org"`
+	Space    string   `json:"space"`
+	Roles    []string `json:"roles"`
+
+	orgGuid   string
+	spaceGuid string
+}
+
+type accessScope struct {
+	org   cf.OrganizationFields
+	space cf.SpaceFields
+}
+
+func (scope accessScope) isOrg() bool {
+	return scope.space.Guid == ""
+}
+
+func (scope accessScope) roles() []string {
+	if scope.isOrg() {
+		return orgRoles
+	}
+	return spaceRoles
+}
+
+type roleQuery struct {
+	scopeIndex  int
+	role        string
+	users       []cf.UserFields
+	apiResponse net.ApiResponse
+}
+
+type accessMatrixBuilder struct {
+	userRepo  api.UserRepository
+	spaceRepo api.SpaceRepository
+}
+
+// build collects the org and space roles of every user in orgs. Entries are
+// sorted by username, then by org and space in the order they were listed.
+func (builder accessMatrixBuilder) build(orgs []cf.OrganizationFields) (entries []accessEntry, apiResponse net.ApiResponse) {
+	scopes, apiResponse := builder.listScopes(orgs)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	queries := []*roleQuery{}
+	for index, scope := range scopes {
+		for _, role := range scope.roles() {
+			queries = append(queries, &roleQuery{scopeIndex: index, role: role})
+		}
+	}
+
+	cf.RunInParallel(len(queries), cf.MAX_PARALLEL_REQUESTS, func(index int) {
+		query := queries[index]
+		query.apiResponse = builder.listUsers(scopes[query.scopeIndex], query.role, func(users []cf.UserFields) bool {
+			query.users = append(query.users, users...)
+			return true
+		})
+	})
+
+	entryIndexes := map[string]int{}
+	for _, query := range queries {
+		if query.apiResponse.IsNotSuccessful() {
+			apiResponse = query.apiResponse
+			return
+		}
+
+		scope := scopes[query.scopeIndex]
+		for _, user := range query.users {
+			key := fmt.Sprintf("%s/%d", user.Guid, query.scopeIndex)
+			index, found := entryIndexes[key]
+			if !found {
+				index = len(entries)
+				entryIndexes[key] = index
+				entries = append(entries, accessEntry{
+					Username: user.Username,
+					UserGuid: user.Guid,
+					Org:      scope.org.Name,
+					Space:    scope.space.Name,
+					Roles:    []string{},
+
+					spaceGuid: scope.space.Guid,
+				})
+			}
+			entries[index].Roles = append(entries[index].Roles, query.role)
+		}
+	}
+
+	sort.Stable(accessEntriesByUsername(entries))
+	return
+}
+
+func (builder accessMatrixBuilder) listScopes(orgs []cf.OrganizationFields) (scopes []accessScope, apiResponse net.ApiResponse) {
+	spacesByOrg := make([][]cf.Space, len(orgs))
+	responses := make([]net.ApiResponse, len(orgs))
+
+	cf.RunInParallel(len(orgs), cf.MAX_PARALLEL_REQUESTS, func(index int) {
+		responses[index] = builder.spaceRepo.ListSpacesInOrg(orgs[index].Guid, func(spaces []cf.Space) bool {
+			spacesByOrg[index] = append(spacesByOrg[index], spaces...)
+			return true
+		})
+	})
+
+	for index, org := range orgs {
+		if responses[index].IsNotSuccessful() {
+			apiResponse = responses[index]
+			return
+		}
+
+		scopes = append(scopes, accessScope{org: org})
+		for _, space := range spacesByOrg[index] {
+			scopes = append(scopes, accessScope{org: org, space: space.SpaceFields})
+		}
+	}
+	return
+}
+
+func (builder accessMatrixBuilder) listUsers(scope accessScope, role string, cb func([]cf.UserFields) bool) net.ApiResponse {
+	if scope.isOrg() {
+		return builder.userRepo.ListUsersInOrgForRole(scope.org.Guid, role, cb)
+	}
+	return builder.userRepo.ListUsersInSpaceForRole(scope.space.Guid, role, cb)
+}
+
+type accessEntriesByUsername []accessEntry
+
+func (entries accessEntriesByUsername) Len() int { return len(entries) }
+func (entries accessEntriesByUsername) Swap(i, j int) {
+	entries[i], entries[j] = entries[j], entries[i]
+}
+func (entries accessEntriesByUsername) Less(i, j int) bool {
+	return entries[i].Username < entries[j].Username
+}
+
+const (
+	accessFormatTable = "table"
+	accessFormatCSV   = "csv"
+	accessFormatJSON  = "json"
+)
+
+func accessFormat(c *cli.Context) string {
+	format := strings.ToLower(c.String("format"))
+	if format == "" {
+		return accessFormatTable
+	}
+	return format
+}
+
+func isValidAccessFormat(format string) bool {
+	return format == accessFormatTable || format == accessFormatCSV || format == accessFormatJSON
+}
+
+// printAccessEntries prints entries in the requested format. columns selects
+// which of user, org and space are shown; roles are always last.
+func printAccessEntries(ui terminal.UI, entries []accessEntry, format string, columns []string) (err error) {
+	switch format {
+	case accessFormatJSON:
+		if entries == nil {
+			entries = []accessEntry{}
+		}
+
+		var output []byte
+		output, err = json.MarshalIndent(entries, "", "   ")
+		if err != nil {
+			return
+		}
+		ui.Say("%s", output)
+	case accessFormatCSV:
+		buffer := new(bytes.Buffer)
+		writer := csv.NewWriter(buffer)
+		writer.Write(append(columns, "roles"))
+		for _, entry := range entries {
+			writer.Write(append(entry.columns(columns), strings.Join(entry.Roles, ",")))
+		}
+		writer.Flush()
+		ui.Say("%s", strings.TrimSuffix(buffer.String(), "\n"))
+	default:
+		table := ui.Table(append(columns, "roles"))
+		rows := [][]string{}
+		for _, entry := range entries {
+			rows = append(rows, append(entry.columns(columns), strings.Join(entry.Roles, ", ")))
+		}
+		table.Print(rows)
+	}
+	return
+}
+
+func (entry accessEntry) columns(names []string) (values []string) {
+	for _, name := range names {
+		switch name {
+		case "user":
+			values = append(values, entry.Username)
+		case "org":
+			values = append(values, entry.Org)
+		case "space":
+			values = append(values, entry.Space)
+		}
+	}
+	return
+}
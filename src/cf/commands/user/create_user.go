@@ -29,7 +29,10 @@ func (cmd CreateUserFields) GetRequirements(reqFactory requirements.Factory, c *
 		cmd.ui.FailWithUsage(c, "create-user")
 	}
 
-	reqs = append(reqs, reqFactory.NewLoginRequirement())
+	reqs = append(reqs,
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	)
 
 	return
 }
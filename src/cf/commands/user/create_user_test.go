@@ -16,7 +16,7 @@ import (
 
 func getCreateUserDefaults() (defaultArgs []string, defaultReqs *testreq.FakeReqFactory, defaultUserRepo *testapi.FakeUserRepository) {
 	defaultArgs = []string{"my-user", "my-password"}
-	defaultReqs = &testreq.FakeReqFactory{LoginSuccess: true}
+	defaultReqs = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 	defaultUserRepo = &testapi.FakeUserRepository{}
 	return
 }
@@ -43,6 +43,11 @@ func TestCreateUserRequirements(t *testing.T) {
 	callCreateUser(t, defaultArgs, notLoggedInReq, defaultUserRepo)
 	assert.False(t, testcmd.CommandDidPassRequirements)
 
+	notAdminReq := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callCreateUser(t, defaultArgs, notAdminReq, defaultUserRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, notAdminReq.Scope, "cloud_controller.admin")
+
 }
 
 func TestCreateUser(t *testing.T) {
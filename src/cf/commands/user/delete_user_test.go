@@ -39,6 +39,11 @@ func TestDeleteUserRequirements(t *testing.T) {
 
 	reqFactory.LoginSuccess = true
 	callDeleteUser(t, args, userRepo, reqFactory)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, "cloud_controller.admin")
+
+	reqFactory.ScopeSuccess = true
+	callDeleteUser(t, args, userRepo, reqFactory)
 	assert.True(t, testcmd.CommandDidPassRequirements)
 }
 
@@ -90,7 +95,7 @@ func TestDeleteUserWithForceOption(t *testing.T) {
 	foundUserFields := cf.UserFields{}
 	foundUserFields.Guid = "my-found-user-guid"
 	userRepo := &testapi.FakeUserRepository{FindByUsernameUserFields: foundUserFields}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	ui := callDeleteUser(t, []string{"-f", "my-user"}, userRepo, reqFactory)
 
@@ -107,7 +112,7 @@ func TestDeleteUserWithForceOption(t *testing.T) {
 
 func TestDeleteUserWhenUserNotFound(t *testing.T) {
 	userRepo := &testapi.FakeUserRepository{FindByUsernameNotFound: true}
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	ui := callDeleteUser(t, []string{"-f", "my-user"}, userRepo, reqFactory)
 
@@ -174,7 +179,7 @@ func deleteWithConfirmation(t *testing.T, confirmation string) (ui *testterm.Fak
 	cmd := NewDeleteUser(ui, config, userRepo)
 
 	ctxt := testcmd.NewContext("delete-user", []string{"my-user"})
-	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true}
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
 
 	testcmd.RunCommand(cmd, ctxt, reqFactory)
 	return
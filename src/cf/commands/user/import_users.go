@@ -0,0 +1,252 @@
+package user
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"strconv"
+	"strings"
+)
+
+type ImportUsers struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	userRepo  api.UserRepository
+	orgRepo   api.OrganizationRepository
+	spaceRepo api.SpaceRepository
+}
+
+func NewImportUsers(ui terminal.UI, config *configuration.Configuration, userRepo api.UserRepository, orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository) (cmd *ImportUsers) {
+	cmd = new(ImportUsers)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.userRepo = userRepo
+	cmd.orgRepo = orgRepo
+	cmd.spaceRepo = spaceRepo
+	return
+}
+
+func (cmd *ImportUsers) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "import-users")
+		return
+	}
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE),
+	}
+	return
+}
+
+func (cmd *ImportUsers) Run(c *cli.Context) {
+	path := c.Args()[0]
+	dryRun := c.Bool("dry-run")
+
+	rows, err := parseUserImportFile(path)
+	if err != nil {
+		cmd.ui.Failed("Error reading %s\n%s", path, err.Error())
+		return
+	}
+
+	message := "Importing users from %s as %s..."
+	if dryRun {
+		message = "Checking users in %s as %s (dry run, nothing will be changed)..."
+	}
+	cmd.ui.Say(message, terminal.EntityNameColor(path), terminal.EntityNameColor(cmd.config.Username()))
+
+	importer := newUserImporter(cmd.userRepo, cmd.orgRepo, cmd.spaceRepo, dryRun)
+
+	table := cmd.ui.Table([]string{"row", "user", "org", "space", "roles", "result"})
+	failures := 0
+
+	for _, row := range rows {
+		actions, err := importer.importRow(row)
+		if err != nil {
+			failures++
+			actions = append(actions, "FAILED: "+err.Error())
+		}
+
+		table.Print([][]string{{
+			strconv.Itoa(row.Number),
+			row.Username,
+			row.Org,
+			row.Space,
+			strings.Join(row.Roles, ", "),
+			strings.Join(actions, ", "),
+		}})
+	}
+
+	cmd.ui.Say("")
+	if failures > 0 {
+		cmd.ui.Failed("%d of %d rows failed", failures, len(rows))
+		return
+	}
+
+	cmd.ui.Ok()
+}
+
+type userImporter struct {
+	userRepo  api.UserRepository
+	orgRepo   api.OrganizationRepository
+	spaceRepo api.SpaceRepository
+	dryRun    bool
+
+	users  map[string]cf.UserFields
+	orgs   map[string]cf.Organization
+	spaces map[string]cf.Space
+}
+
+func newUserImporter(userRepo api.UserRepository, orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository, dryRun bool) (importer *userImporter) {
+	importer = &userImporter{
+		userRepo:  userRepo,
+		orgRepo:   orgRepo,
+		spaceRepo: spaceRepo,
+		dryRun:    dryRun,
+		users:     map[string]cf.UserFields{},
+		orgs:      map[string]cf.Organization{},
+		spaces:    map[string]cf.Space{},
+	}
+	return
+}
+
+// importRow creates the user if needed and assigns the row's roles. Assigning
+// a role the user already has succeeds, so rows can be imported again safely.
+func (importer *userImporter) importRow(row userImportRow) (actions []string, err error) {
+	user, userAction, err := importer.ensureUser(row)
+	if userAction != "" {
+		actions = append(actions, userAction)
+	}
+	if err != nil || row.Org == "" {
+		return
+	}
+
+	org, err := importer.findOrg(row.Org)
+	if err != nil {
+		return
+	}
+
+	var space cf.Space
+	if row.Space != "" {
+		space, err = importer.findSpace(org, row.Space)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, role := range row.Roles {
+		if importer.dryRun {
+			actions = append(actions, "would assign "+role)
+			continue
+		}
+
+		if row.Space == "" {
+			apiResponse := importer.userRepo.SetOrgRole(user.Guid, org.Guid, cf.UserInputToOrgRole[role])
+			if apiResponse.IsNotSuccessful() {
+				err = errors.New(apiResponse.Message)
+				return
+			}
+		} else {
+			apiResponse := importer.userRepo.SetSpaceRole(user.Guid, space.Guid, org.Guid, cf.UserInputToSpaceRole[role])
+			if apiResponse.IsNotSuccessful() {
+				err = errors.New(apiResponse.Message)
+				return
+			}
+		}
+		actions = append(actions, "assigned "+role)
+	}
+
+	if len(actions) == 0 {
+		actions = append(actions, "nothing to do")
+	}
+	return
+}
+
+func (importer *userImporter) ensureUser(row userImportRow) (user cf.UserFields, action string, err error) {
+	user, found := importer.users[row.Username]
+	if found {
+		return
+	}
+
+	user, apiResponse := importer.userRepo.FindByUsername(row.Username)
+	if apiResponse.IsSuccessful() {
+		importer.users[row.Username] = user
+		action = "user exists"
+		return
+	}
+
+	if !apiResponse.IsNotFound() {
+		err = errors.New(apiResponse.Message)
+		return
+	}
+
+	if row.Password == "" {
+		err = fmt.Errorf("user %s does not exist and no password was given", row.Username)
+		return
+	}
+
+	if importer.dryRun {
+		user = cf.UserFields{Username: row.Username}
+		importer.users[row.Username] = user
+		action = "would create user"
+		return
+	}
+
+	action = "created user"
+	apiResponse = importer.userRepo.Create(row.Username, row.Password)
+	if apiResponse.IsNotSuccessful() {
+		if apiResponse.ErrorCode != cf.USER_EXISTS {
+			err = errors.New(apiResponse.Message)
+			return
+		}
+		action = "user exists"
+	}
+
+	user, apiResponse = importer.userRepo.FindByUsername(row.Username)
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New(apiResponse.Message)
+		return
+	}
+
+	importer.users[row.Username] = user
+	return
+}
+
+func (importer *userImporter) findOrg(name string) (org cf.Organization, err error) {
+	org, found := importer.orgs[name]
+	if found {
+		return
+	}
+
+	org, apiResponse := importer.orgRepo.FindByName(name)
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New(apiResponse.Message)
+		return
+	}
+
+	importer.orgs[name] = org
+	return
+}
+
+func (importer *userImporter) findSpace(org cf.Organization, name string) (space cf.Space, err error) {
+	key := org.Guid + "/" + name
+	space, found := importer.spaces[key]
+	if found {
+		return
+	}
+
+	space, apiResponse := importer.spaceRepo.FindByNameInOrg(name, org.Guid)
+	if apiResponse.IsNotSuccessful() {
+		err = errors.New(apiResponse.Message)
+		return
+	}
+
+	importer.spaces[key] = space
+	return
+}
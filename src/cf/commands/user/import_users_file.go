@@ -0,0 +1,183 @@
+package user
+
+import (
+	"cf"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"launchpad.net/goyaml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// userImportRow is one line of the import report: a user, optionally an org
+// or space, and the roles to assign there.
+type userImportRow struct {
+	Number   int
+	Username string
+	Password string
+	Org      string
+	Space    string
+	Roles    []string
+}
+
+type userImportYAML struct {
+	Users []struct {
+		Username string
+		Password string
+		Orgs     []struct {
+			Name   string
+			Roles  []string
+			Spaces []struct {
+				Name  string
+				Roles []string
+			}
+		}
+	}
+}
+
+var userImportCSVColumns = []string{"username", "password", "org", "space", "roles"}
+
+func parseUserImportFile(path string) (rows []userImportRow, err error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = parseUserImportCSV(path)
+	case ".yml", ".yaml":
+		rows, err = parseUserImportYAML(path)
+	default:
+		err = errors.New("Unsupported file type. Use a .csv, .yml or .yaml file.")
+	}
+	if err != nil {
+		return
+	}
+
+	err = validateUserImportRows(rows)
+	return
+}
+
+func parseUserImportCSV(path string) (rows []userImportRow, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return
+	}
+
+	if len(records) == 0 {
+		err = errors.New("File is empty")
+		return
+	}
+
+	columns := map[string]int{}
+	for index, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	if _, found := columns["username"]; !found {
+		err = fmt.Errorf("Missing header row. Expected the columns %s", strings.Join(userImportCSVColumns, ","))
+		return
+	}
+
+	for index, record := range records[1:] {
+		value := func(column string) string {
+			position, found := columns[column]
+			if !found || position >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[position])
+		}
+
+		rows = append(rows, userImportRow{
+			Number:   index + 2,
+			Username: value("username"),
+			Password: value("password"),
+			Org:      value("org"),
+			Space:    value("space"),
+			Roles:    splitRoles(value("roles")),
+		})
+	}
+	return
+}
+
+func splitRoles(roles string) []string {
+	return strings.FieldsFunc(roles, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	})
+}
+
+func parseUserImportYAML(path string) (rows []userImportRow, err error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	document := userImportYAML{}
+	err = goyaml.Unmarshal(contents, &document)
+	if err != nil {
+		return
+	}
+
+	addRow := func(row userImportRow) {
+		row.Number = len(rows) + 1
+		rows = append(rows, row)
+	}
+
+	for _, user := range document.Users {
+		if len(user.Orgs) == 0 {
+			addRow(userImportRow{Username: user.Username, Password: user.Password})
+			continue
+		}
+
+		for _, org := range user.Orgs {
+			if len(org.Roles) > 0 || len(org.Spaces) == 0 {
+				addRow(userImportRow{Username: user.Username, Password: user.Password, Org: org.Name, Roles: org.Roles})
+			}
+			for _, space := range org.Spaces {
+				addRow(userImportRow{Username: user.Username, Password: user.Password, Org: org.Name, Space: space.Name, Roles: space.Roles})
+			}
+		}
+	}
+	return
+}
+
+func validateUserImportRows(rows []userImportRow) (err error) {
+	problems := []string{}
+	addProblem := func(row userImportRow, message string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("Row %d: %s", row.Number, fmt.Sprintf(message, args...)))
+	}
+
+	for _, row := range rows {
+		if row.Username == "" {
+			addProblem(row, "username is required")
+		}
+		if row.Space != "" && row.Org == "" {
+			addProblem(row, "space %s has no org", row.Space)
+		}
+		if len(row.Roles) > 0 && row.Org == "" {
+			addProblem(row, "roles need an org")
+		}
+
+		for _, role := range row.Roles {
+			if row.Space == "" && cf.UserInputToOrgRole[role] == "" {
+				addProblem(row, "invalid org role %s", role)
+			}
+			if row.Space != "" && cf.UserInputToSpaceRole[role] == "" {
+				addProblem(row, "invalid space role %s", role)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		err = errors.New(strings.Join(problems, "\n"))
+	}
+	return
+}
@@ -0,0 +1,245 @@
+package user_test
+
+import (
+	"cf"
+	. "cf/commands/user"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+const importUsersCSV = `username,password,org,space,roles
+alice,secret,my-org,,OrgManager BillingManager
+alice,,my-org,development,SpaceDeveloper
+bob,,my-org,,OrgAuditor
+`
+
+const importUsersYAML = `users:
+- username: alice
+  password: secret
+  orgs:
+  - name: my-org
+    roles: [OrgManager]
+    spaces:
+    - name: development
+      roles: [SpaceDeveloper, SpaceAuditor]
+- username: carol
+  password: secret
+`
+
+func TestImportUsersFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+
+	ui := callImportUsers(t, []string{}, reqFactory, userRepo, orgRepo, spaceRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callImportUsers(t, []string{"users.csv", "more.csv"}, reqFactory, userRepo, orgRepo, spaceRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callImportUsers(t, []string{"users.csv"}, reqFactory, userRepo, orgRepo, spaceRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestImportUsersRequirements(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false, ScopeSuccess: true}
+	callImportUsers(t, []string{"users.csv"}, reqFactory, userRepo, orgRepo, spaceRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callImportUsers(t, []string{"users.csv"}, reqFactory, userRepo, orgRepo, spaceRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, cf.ADMIN_SCOPE)
+}
+
+func TestImportUsersFromCSV(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.csv", importUsersCSV)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, userRepo.CreatedUsernames, []string{"alice"})
+	assert.Equal(t, userRepo.SetOrgRoleCalls, []string{
+		"alice-guid my-org-guid " + cf.ORG_MANAGER,
+		"alice-guid my-org-guid " + cf.BILLING_MANAGER,
+		"bob-guid my-org-guid " + cf.ORG_AUDITOR,
+	})
+	assert.Equal(t, userRepo.SetSpaceRoleCalls, []string{
+		"alice-guid development-guid " + cf.SPACE_DEVELOPER,
+	})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Importing users from", path, "my-user"},
+		{"row", "user", "org", "space", "roles", "result"},
+		{"2", "alice", "my-org", "created user, assigned OrgManager, assigned BillingManager"},
+		{"3", "alice", "development", "assigned SpaceDeveloper"},
+		{"4", "bob", "my-org", "user exists, assigned OrgAuditor"},
+		{"OK"},
+	})
+}
+
+func TestImportUsersFromYAML(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.yml", importUsersYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, userRepo.CreatedUsernames, []string{"alice", "carol"})
+	assert.Equal(t, userRepo.SetOrgRoleCalls, []string{"alice-guid my-org-guid " + cf.ORG_MANAGER})
+	assert.Equal(t, userRepo.SetSpaceRoleCalls, []string{
+		"alice-guid development-guid " + cf.SPACE_DEVELOPER,
+		"alice-guid development-guid " + cf.SPACE_AUDITOR,
+	})
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"1", "alice", "my-org", "created user, assigned OrgManager"},
+		{"2", "alice", "development", "assigned SpaceDeveloper, assigned SpaceAuditor"},
+		{"3", "carol", "created user"},
+		{"OK"},
+	})
+}
+
+func TestImportUsersWhenCreateReportsAnExistingUser(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	userRepo.ExistingUsers = nil
+	userRepo.FindByUsernameNotFound = true
+	userRepo.CreateUserExists = true
+	path := writeImportFile(t, "users.csv", "username,password\ndave,secret\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"2", "dave", "user exists", "FAILED", "not found"},
+	})
+}
+
+func TestImportUsersDryRun(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.csv", importUsersCSV)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{"--dry-run", path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, len(userRepo.CreatedUsernames), 0)
+	assert.Equal(t, len(userRepo.SetOrgRoleCalls), 0)
+	assert.Equal(t, len(userRepo.SetSpaceRoleCalls), 0)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Checking users in", path, "dry run"},
+		{"2", "alice", "would create user, would assign OrgManager, would assign BillingManager"},
+		{"3", "alice", "would assign SpaceDeveloper"},
+		{"4", "bob", "user exists, would assign OrgAuditor"},
+		{"OK"},
+	})
+}
+
+func TestImportUsersReportsFailedRows(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.csv", "username,password,org,roles\ndave,,my-org,OrgManager\nbob,,my-org,OrgAuditor\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, userRepo.SetOrgRoleCalls, []string{"bob-guid my-org-guid " + cf.ORG_AUDITOR})
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"2", "dave", "FAILED: user dave does not exist and no password was given"},
+		{"3", "bob", "assigned OrgAuditor"},
+		{"FAILED"},
+		{"1 of 2 rows failed"},
+	})
+}
+
+func TestImportUsersValidatesTheFile(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.csv", "username,password,org,space,roles\n,secret,my-org,,\nalice,,,development,\nbob,,my-org,,SpaceDeveloper\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	assert.Equal(t, len(userRepo.CreatedUsernames), 0)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Error reading", path},
+		{"Row 2: username is required"},
+		{"Row 3: space development has no org"},
+		{"Row 4: invalid org role SpaceDeveloper"},
+	})
+}
+
+func TestImportUsersWithAnUnsupportedFile(t *testing.T) {
+	userRepo, orgRepo, spaceRepo := createImportFixtures()
+	path := writeImportFile(t, "users.txt", "alice")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	ui := callImportUsers(t, []string{path}, importReqFactory(), userRepo, orgRepo, spaceRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Unsupported file type"},
+	})
+}
+
+func importReqFactory() *testreq.FakeReqFactory {
+	return &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
+}
+
+func createImportFixtures() (userRepo *testapi.FakeUserRepository, orgRepo *testapi.FakeOrgRepository, spaceRepo *testapi.FakeSpaceRepository) {
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+
+	space := cf.Space{}
+	space.Name = "development"
+	space.Guid = "development-guid"
+
+	userRepo = &testapi.FakeUserRepository{
+		ExistingUsers: map[string]cf.UserFields{
+			"bob": {Guid: "bob-guid", Username: "bob"},
+		},
+	}
+	orgRepo = &testapi.FakeOrgRepository{FindByNameOrganization: org}
+	spaceRepo = &testapi.FakeSpaceRepository{FindByNameInOrgSpace: space}
+	return
+}
+
+func writeImportFile(t *testing.T, name, contents string) (path string) {
+	dir, err := ioutil.TempDir("", "import-users")
+	assert.NoError(t, err)
+
+	path = filepath.Join(dir, name)
+	err = ioutil.WriteFile(path, []byte(contents), 0600)
+	assert.NoError(t, err)
+	return
+}
+
+func callImportUsers(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository, orgRepo *testapi.FakeOrgRepository, spaceRepo *testapi.FakeSpaceRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	config := &configuration.Configuration{
+		AccessToken: token,
+	}
+
+	cmd := NewImportUsers(ui, config, userRepo, orgRepo, spaceRepo)
+	ctxt := testcmd.NewContext("import-users", args)
+
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
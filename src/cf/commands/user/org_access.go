@@ -0,0 +1,115 @@
+package user
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type OrgAccess struct {
+	ui        terminal.UI
+	config    *configuration.Configuration
+	orgRepo   api.OrganizationRepository
+	spaceRepo api.SpaceRepository
+	userRepo  api.UserRepository
+	orgReq    requirements.OrganizationRequirement
+}
+
+func NewOrgAccess(ui terminal.UI, config *configuration.Configuration, orgRepo api.OrganizationRepository, spaceRepo api.SpaceRepository, userRepo api.UserRepository) (cmd *OrgAccess) {
+	cmd = new(OrgAccess)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.orgRepo = orgRepo
+	cmd.spaceRepo = spaceRepo
+	cmd.userRepo = userRepo
+	return
+}
+
+func (cmd *OrgAccess) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	expectedArgs := 1
+	if c.Bool("all-orgs") {
+		expectedArgs = 0
+	}
+
+	if len(c.Args()) != expectedArgs || !isValidAccessFormat(accessFormat(c)) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "org-access")
+		return
+	}
+
+	reqs = append(reqs, reqFactory.NewLoginRequirement())
+
+	if c.Bool("all-orgs") {
+		reqs = append(reqs, reqFactory.NewScopeRequirement(cf.ADMIN_SCOPE))
+	} else {
+		cmd.orgReq = reqFactory.NewOrganizationRequirement(c.Args()[0])
+		reqs = append(reqs, cmd.orgReq)
+	}
+	return
+}
+
+func (cmd *OrgAccess) Run(c *cli.Context) {
+	format := accessFormat(c)
+
+	orgs, ok := cmd.orgs(c.Bool("all-orgs"), format)
+	if !ok {
+		return
+	}
+
+	builder := accessMatrixBuilder{userRepo: cmd.userRepo, spaceRepo: cmd.spaceRepo}
+	entries, apiResponse := builder.build(orgs)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed fetching user roles.\n%s", apiResponse.Message)
+		return
+	}
+
+	if format == accessFormatTable {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+
+	if len(entries) == 0 && format == accessFormatTable {
+		cmd.ui.Say("No users found")
+		return
+	}
+
+	err := printAccessEntries(cmd.ui, entries, format, []string{"user", "org", "space"})
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+	}
+}
+
+func (cmd *OrgAccess) orgs(allOrgs bool, format string) (orgs []cf.OrganizationFields, ok bool) {
+	if !allOrgs {
+		org := cmd.orgReq.GetOrganization()
+		if format == accessFormatTable {
+			cmd.ui.Say("Getting user access for org %s as %s...",
+				terminal.EntityNameColor(org.Name),
+				terminal.EntityNameColor(cmd.config.Username()),
+			)
+		}
+		return []cf.OrganizationFields{org.OrganizationFields}, true
+	}
+
+	if format == accessFormatTable {
+		cmd.ui.Say("Getting user access for all orgs as %s...", terminal.EntityNameColor(cmd.config.Username()))
+	}
+
+	apiResponse := cmd.orgRepo.ListOrgs(func(page []cf.Organization) bool {
+		for _, org := range page {
+			orgs = append(orgs, org.OrganizationFields)
+		}
+		return true
+	})
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed fetching orgs.\n%s", apiResponse.Message)
+		return
+	}
+
+	ok = true
+	return
+}
@@ -0,0 +1,200 @@
+package user_test
+
+import (
+	"cf"
+	. "cf/commands/user"
+	"cf/configuration"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestOrgAccessFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	orgRepo, spaceRepo, userRepo := &testapi.FakeOrgRepository{}, &testapi.FakeSpaceRepository{}, &testapi.FakeUserRepository{}
+
+	ui := callOrgAccess(t, []string{}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callOrgAccess(t, []string{"--all-orgs", "my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callOrgAccess(t, []string{"--format", "xml", "my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callOrgAccess(t, []string{"my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.False(t, ui.FailedWithUsage)
+
+	ui = callOrgAccess(t, []string{"--all-orgs"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestOrgAccessRequirements(t *testing.T) {
+	orgRepo, spaceRepo, userRepo := &testapi.FakeOrgRepository{}, &testapi.FakeSpaceRepository{}, &testapi.FakeUserRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callOrgAccess(t, []string{"my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	callOrgAccess(t, []string{"my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.OrganizationName, "my-org")
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: false}
+	callOrgAccess(t, []string{"--all-orgs"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.Scope, cf.ADMIN_SCOPE)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, ScopeSuccess: true}
+	callOrgAccess(t, []string{"--all-orgs"}, reqFactory, orgRepo, spaceRepo, userRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+}
+
+func TestOrgAccessBuildsTheRoleMatrix(t *testing.T) {
+	reqFactory, orgRepo, spaceRepo, userRepo := createAccessFixtures()
+
+	ui := callOrgAccess(t, []string{"my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting user access for org", "my-org", "my-user"},
+		{"OK"},
+		{"user", "org", "space", "roles"},
+		{"alice", "my-org", "OrgManager, OrgAuditor"},
+		{"alice", "my-org", "development", "SpaceDeveloper"},
+		{"bob", "my-org", "production", "SpaceManager, SpaceAuditor"},
+	})
+	assert.Equal(t, len(ui.Outputs), 7)
+}
+
+func TestOrgAccessAsCSV(t *testing.T) {
+	reqFactory, orgRepo, spaceRepo, userRepo := createAccessFixtures()
+
+	ui := callOrgAccess(t, []string{"--format", "csv", "my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+
+	assert.Equal(t, ui.Outputs, []string{
+		"user,org,space,roles",
+		`alice,my-org,,"OrgManager,OrgAuditor"`,
+		"alice,my-org,development,SpaceDeveloper",
+		`bob,my-org,production,"SpaceManager,SpaceAuditor"`,
+	})
+}
+
+func TestOrgAccessAsJSON(t *testing.T) {
+	reqFactory, orgRepo, spaceRepo, userRepo := createAccessFixtures()
+
+	ui := callOrgAccess(t, []string{"--format", "json", "my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+
+	entries := []map[string]interface{}{}
+	err := json.Unmarshal([]byte(strings.Join(ui.Outputs, "\n")), &entries)
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(entries), 3)
+	assert.Equal(t, entries[0]["user"], "alice")
+	assert.Equal(t, entries[0]["user_guid"], "alice-guid")
+	assert.Equal(t, entries[0]["space"], "")
+	assert.Equal(t, entries[0]["roles"], []interface{}{"OrgManager", "OrgAuditor"})
+	assert.Equal(t, entries[2]["user"], "bob")
+	assert.Equal(t, entries[2]["space"], "production")
+}
+
+func TestOrgAccessForAllOrgs(t *testing.T) {
+	reqFactory, orgRepo, spaceRepo, userRepo := createAccessFixtures()
+	reqFactory.ScopeSuccess = true
+
+	otherOrg := cf.Organization{}
+	otherOrg.Name = "other-org"
+	otherOrg.Guid = "other-org-guid"
+	orgRepo.Organizations = []cf.Organization{reqFactory.Organization, otherOrg}
+	userRepo.ListUsersByGuidAndRole["other-org-guid"] = map[string][]cf.UserFields{
+		cf.BILLING_MANAGER: {{Guid: "carol-guid", Username: "carol"}},
+	}
+
+	ui := callOrgAccess(t, []string{"--all-orgs"}, reqFactory, orgRepo, spaceRepo, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting user access for all orgs as", "my-user"},
+		{"alice", "my-org", "OrgManager, OrgAuditor"},
+		{"bob", "my-org", "production"},
+		{"carol", "other-org", "BillingManager"},
+	})
+}
+
+func TestOrgAccessWhenListingFails(t *testing.T) {
+	reqFactory, orgRepo, spaceRepo, userRepo := createAccessFixtures()
+	userRepo.ListUsersErrGuid = "production-guid"
+
+	ui := callOrgAccess(t, []string{"my-org"}, reqFactory, orgRepo, spaceRepo, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed fetching user roles"},
+		{"Error listing users"},
+	})
+}
+
+func createAccessFixtures() (reqFactory *testreq.FakeReqFactory, orgRepo *testapi.FakeOrgRepository, spaceRepo *testapi.FakeSpaceRepository, userRepo *testapi.FakeUserRepository) {
+	org := cf.Organization{}
+	org.Name = "my-org"
+	org.Guid = "my-org-guid"
+
+	development := cf.Space{}
+	development.Name = "development"
+	development.Guid = "development-guid"
+
+	production := cf.Space{}
+	production.Name = "production"
+	production.Guid = "production-guid"
+
+	alice := cf.UserFields{Guid: "alice-guid", Username: "alice"}
+	bob := cf.UserFields{Guid: "bob-guid", Username: "bob"}
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, Organization: org}
+	orgRepo = &testapi.FakeOrgRepository{Organizations: []cf.Organization{org}}
+	spaceRepo = &testapi.FakeSpaceRepository{
+		SpacesByOrgGuid: map[string][]cf.Space{
+			"my-org-guid": {development, production},
+		},
+	}
+	userRepo = &testapi.FakeUserRepository{
+		ListUsersByGuidAndRole: map[string]map[string][]cf.UserFields{
+			"my-org-guid": {
+				cf.ORG_MANAGER: {alice},
+				cf.ORG_AUDITOR: {alice},
+			},
+			"development-guid": {
+				cf.SPACE_DEVELOPER: {alice},
+			},
+			"production-guid": {
+				cf.SPACE_MANAGER: {bob},
+				cf.SPACE_AUDITOR: {bob},
+			},
+		},
+	}
+	return
+}
+
+func callOrgAccess(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, orgRepo *testapi.FakeOrgRepository, spaceRepo *testapi.FakeSpaceRepository, userRepo *testapi.FakeUserRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	config := &configuration.Configuration{
+		AccessToken: token,
+	}
+
+	cmd := NewOrgAccess(ui, config, orgRepo, spaceRepo, userRepo)
+	ctxt := testcmd.NewContext("org-access", args)
+
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
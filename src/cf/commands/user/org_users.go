@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/api"
 	"cf/configuration"
+	"cf/net"
 	"cf/requirements"
 	"cf/terminal"
 	"errors"
@@ -55,24 +56,21 @@ func (cmd *OrgUsers) Run(c *cli.Context) {
 		terminal.EntityNameColor(cmd.config.Username()),
 	)
 
-	for _, role := range orgRoles {
-		stopChan := make(chan bool)
-		defer close(stopChan)
+	results := listUsersByRole(orgRoles, func(role string, cb func([]cf.UserFields) bool) net.ApiResponse {
+		return cmd.userRepo.ListUsersInOrgForRole(org.Guid, role, cb)
+	})
 
+	for index, role := range orgRoles {
 		displayName := orgRoleToDisplayName[role]
 
-		usersChan, statusChan := cmd.userRepo.ListUsersInOrgForRole(org.Guid, role, stopChan)
-
 		cmd.ui.Say("")
 		cmd.ui.Say("%s", terminal.HeaderColor(displayName))
 
-		for users := range usersChan {
-			for _, user := range users {
-				cmd.ui.Say("  %s", user.Username)
-			}
+		for _, user := range results[index].users {
+			cmd.ui.Say("  %s", user.Username)
 		}
 
-		apiStatus := <-statusChan
+		apiStatus := results[index].apiResponse
 		if apiStatus.IsNotSuccessful() {
 			cmd.ui.Failed("Failed fetching org-users for role %s.\n%s", apiStatus.Message, displayName)
 			return
@@ -80,6 +80,27 @@ func TestOrgUsers(t *testing.T) {
 	})
 }
 
+func TestOrgUsersListsRolesConcurrently(t *testing.T) {
+	org := cf.Organization{}
+	org.Name = "Found Org"
+	org.Guid = "found-org-guid"
+
+	userRepo := &testapi.FakeUserRepository{ListUsersWaitForCalls: 3}
+	reqFactory := &testreq.FakeReqFactory{
+		LoginSuccess: true,
+		Organization: org,
+	}
+
+	ui := callOrgUsers(t, []string{"Org1"}, reqFactory, userRepo)
+
+	assert.Equal(t, userRepo.ListUsersMaxInProgress, 3)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"ORG MANAGER"},
+		{"BILLING MANAGER"},
+		{"ORG AUDITOR"},
+	})
+}
+
 func callOrgUsers(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository) (ui *testterm.FakeUI) {
 	ui = &testterm.FakeUI{}
 
@@ -0,0 +1,124 @@
+package user
+
+import (
+	"cf/api"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type RemoveUserFromOrg struct {
+	ui       terminal.UI
+	config   *configuration.Configuration
+	userRepo api.UserRepository
+	userReq  requirements.UserRequirement
+	orgReq   requirements.OrganizationRequirement
+}
+
+func NewRemoveUserFromOrg(ui terminal.UI, config *configuration.Configuration, userRepo api.UserRepository) (cmd *RemoveUserFromOrg) {
+	cmd = new(RemoveUserFromOrg)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.userRepo = userRepo
+	return
+}
+
+func (cmd *RemoveUserFromOrg) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 2 {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "remove-user-from-org")
+		return
+	}
+
+	cmd.userReq = reqFactory.NewUserRequirement(c.Args()[0])
+	cmd.orgReq = reqFactory.NewOrganizationRequirement(c.Args()[1])
+
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		cmd.userReq,
+		cmd.orgReq,
+	}
+	return
+}
+
+func (cmd *RemoveUserFromOrg) Run(c *cli.Context) {
+	user := cmd.userReq.GetUser()
+	org := cmd.orgReq.GetOrganization()
+
+	cmd.ui.Say("Removing user %s from org %s as %s...",
+		terminal.EntityNameColor(user.Username),
+		terminal.EntityNameColor(org.Name),
+		terminal.EntityNameColor(cmd.config.Username()),
+	)
+
+	allEntries, apiResponse := listUserRoleEntries(cmd.userRepo, user)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed fetching user roles.\n%s", apiResponse.Message)
+		return
+	}
+
+	entries := []accessEntry{}
+	for _, entry := range allEntries {
+		if entry.orgGuid == org.Guid {
+			entries = append(entries, entry)
+		}
+	}
+
+	// Space roles go first; the cloud controller will not drop an org member
+	// who still has roles in one of its spaces.
+	for _, entry := range entries {
+		if entry.spaceGuid == "" {
+			continue
+		}
+		for _, role := range entry.Roles {
+			apiResponse = cmd.userRepo.UnsetSpaceRole(user.Guid, entry.spaceGuid, role)
+			if apiResponse.IsNotSuccessful() {
+				cmd.failRemovingRole(role, entry, apiResponse)
+				return
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.spaceGuid != "" {
+			continue
+		}
+		for _, role := range entry.Roles {
+			apiResponse = cmd.userRepo.UnsetOrgRole(user.Guid, org.Guid, role)
+			if apiResponse.IsNotSuccessful() {
+				cmd.failRemovingRole(role, entry, apiResponse)
+				return
+			}
+		}
+	}
+
+	apiResponse = cmd.userRepo.RemoveFromOrg(user.Guid, org.Guid)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed removing org membership.\n%s", apiResponse.Message)
+		return
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(entries) == 0 {
+		cmd.ui.Say("User %s had no roles in org %s", terminal.EntityNameColor(user.Username), terminal.EntityNameColor(org.Name))
+	} else {
+		cmd.ui.Say("Removed roles:")
+		printAccessEntries(cmd.ui, entries, accessFormatTable, []string{"org", "space"})
+		cmd.ui.Say("")
+	}
+
+	cmd.ui.Say("User %s is no longer a member of org %s", terminal.EntityNameColor(user.Username), terminal.EntityNameColor(org.Name))
+}
+
+func (cmd *RemoveUserFromOrg) failRemovingRole(role string, entry accessEntry, apiResponse net.ApiResponse) {
+	scope := "org " + entry.Org
+	if entry.Space != "" {
+		scope = "space " + entry.Space
+	}
+	cmd.ui.Failed("Failed removing role %s in %s.\n%s", role, scope, apiResponse.Message)
+}
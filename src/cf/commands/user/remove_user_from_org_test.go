@@ -0,0 +1,152 @@
+package user_test
+
+import (
+	"cf"
+	. "cf/commands/user"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestRemoveUserFromOrgFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	userRepo := &testapi.FakeUserRepository{}
+
+	ui := callRemoveUserFromOrg(t, []string{}, reqFactory, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRemoveUserFromOrg(t, []string{"alice"}, reqFactory, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestRemoveUserFromOrgRequirements(t *testing.T) {
+	userRepo := &testapi.FakeUserRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.UserUsername, "alice")
+	assert.Equal(t, reqFactory.OrganizationName, "my-org")
+}
+
+func TestRemoveUserFromOrgRemovesEveryRoleInTheOrg(t *testing.T) {
+	reqFactory, userRepo := createRemoveUserFromOrgFixtures()
+
+	ui := callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+
+	assert.Equal(t, userRepo.ListUserRolesUserGuid, "alice-guid")
+	assert.Equal(t, userRepo.UnsetSpaceRoleCalls, []string{
+		"alice-guid development-guid " + cf.SPACE_MANAGER,
+		"alice-guid development-guid " + cf.SPACE_DEVELOPER,
+		"alice-guid production-guid " + cf.SPACE_AUDITOR,
+	})
+	assert.Equal(t, userRepo.UnsetOrgRoleCalls, []string{
+		"alice-guid my-org-guid " + cf.ORG_MANAGER,
+		"alice-guid my-org-guid " + cf.ORG_AUDITOR,
+	})
+	assert.Equal(t, userRepo.RemoveFromOrgUserGuid, "alice-guid")
+	assert.Equal(t, userRepo.RemoveFromOrgOrgGuid, "my-org-guid")
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Removing user", "alice", "my-org", "my-user"},
+		{"OK"},
+		{"Removed roles:"},
+		{"org", "space", "roles"},
+		{"my-org", "OrgManager, OrgAuditor"},
+		{"my-org", "development", "SpaceManager, SpaceDeveloper"},
+		{"my-org", "production", "SpaceAuditor"},
+		{"User", "alice", "is no longer a member of org", "my-org"},
+	})
+	testassert.SliceDoesNotContain(t, ui.Outputs, testassert.Lines{
+		{"staging"},
+	})
+}
+
+func TestRemoveUserFromOrgWhenTheUserHasNoRoles(t *testing.T) {
+	reqFactory, userRepo := createRemoveUserFromOrgFixtures()
+	reqFactory.Organization = cf.Organization{}
+	reqFactory.Organization.Name = "empty-org"
+	reqFactory.Organization.Guid = "empty-org-guid"
+
+	ui := callRemoveUserFromOrg(t, []string{"alice", "empty-org"}, reqFactory, userRepo)
+
+	assert.Equal(t, len(userRepo.UnsetSpaceRoleCalls), 0)
+	assert.Equal(t, len(userRepo.UnsetOrgRoleCalls), 0)
+	assert.Equal(t, userRepo.RemoveFromOrgUserGuid, "alice-guid")
+	assert.Equal(t, userRepo.RemoveFromOrgOrgGuid, "empty-org-guid")
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"OK"},
+		{"User", "alice", "had no roles in org", "empty-org"},
+		{"User", "alice", "is no longer a member of org", "empty-org"},
+	})
+}
+
+func TestRemoveUserFromOrgWhenListingFails(t *testing.T) {
+	reqFactory, userRepo := createRemoveUserFromOrgFixtures()
+	userRepo.ListUserRolesErr = true
+
+	ui := callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+
+	assert.Equal(t, len(userRepo.UnsetSpaceRoleCalls), 0)
+	assert.Equal(t, len(userRepo.UnsetOrgRoleCalls), 0)
+	assert.Equal(t, userRepo.RemoveFromOrgUserGuid, "")
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed fetching user roles"},
+	})
+}
+
+func TestRemoveUserFromOrgWhenRemovingTheMembershipFails(t *testing.T) {
+	reqFactory, userRepo := createRemoveUserFromOrgFixtures()
+	userRepo.RemoveFromOrgErr = true
+
+	ui := callRemoveUserFromOrg(t, []string{"alice", "my-org"}, reqFactory, userRepo)
+
+	assert.Equal(t, len(userRepo.UnsetSpaceRoleCalls), 3)
+	assert.Equal(t, len(userRepo.UnsetOrgRoleCalls), 2)
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed removing org membership"},
+		{"Error removing user from org"},
+	})
+}
+
+func createRemoveUserFromOrgFixtures() (reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository) {
+	reqFactory, userRepo = createUserRolesFixtures()
+	reqFactory.Organization = cf.Organization{}
+	reqFactory.Organization.Name = "my-org"
+	reqFactory.Organization.Guid = "my-org-guid"
+	return
+}
+
+func callRemoveUserFromOrg(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	config := &configuration.Configuration{
+		AccessToken: token,
+	}
+
+	cmd := NewRemoveUserFromOrg(ui, config, userRepo)
+	ctxt := testcmd.NewContext("remove-user-from-org", args)
+
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"cf"
+	"cf/api"
+	"cf/configuration"
+	"cf/net"
+	"cf/requirements"
+	"cf/terminal"
+	"errors"
+	"github.com/codegangsta/cli"
+)
+
+type UserRoles struct {
+	ui       terminal.UI
+	config   *configuration.Configuration
+	userRepo api.UserRepository
+	userReq  requirements.UserRequirement
+}
+
+func NewUserRoles(ui terminal.UI, config *configuration.Configuration, userRepo api.UserRepository) (cmd *UserRoles) {
+	cmd = new(UserRoles)
+	cmd.ui = ui
+	cmd.config = config
+	cmd.userRepo = userRepo
+	return
+}
+
+func (cmd *UserRoles) GetRequirements(reqFactory requirements.Factory, c *cli.Context) (reqs []requirements.Requirement, err error) {
+	if len(c.Args()) != 1 || !isValidAccessFormat(accessFormat(c)) {
+		err = errors.New("Incorrect Usage")
+		cmd.ui.FailWithUsage(c, "user-roles")
+		return
+	}
+
+	cmd.userReq = reqFactory.NewUserRequirement(c.Args()[0])
+	reqs = []requirements.Requirement{
+		reqFactory.NewLoginRequirement(),
+		cmd.userReq,
+	}
+	return
+}
+
+func (cmd *UserRoles) Run(c *cli.Context) {
+	user := cmd.userReq.GetUser()
+	format := accessFormat(c)
+
+	if format == accessFormatTable {
+		cmd.ui.Say("Getting roles of user %s as %s...",
+			terminal.EntityNameColor(user.Username),
+			terminal.EntityNameColor(cmd.config.Username()),
+		)
+	}
+
+	entries, apiResponse := listUserRoleEntries(cmd.userRepo, user)
+	if apiResponse.IsNotSuccessful() {
+		cmd.ui.Failed("Failed fetching user roles.\n%s", apiResponse.Message)
+		return
+	}
+
+	if format == accessFormatTable {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+
+	if len(entries) == 0 && format == accessFormatTable {
+		cmd.ui.Say("User %s has no roles in any org or space", terminal.EntityNameColor(user.Username))
+		return
+	}
+
+	err := printAccessEntries(cmd.ui, entries, format, []string{"org", "space"})
+	if err != nil {
+		cmd.ui.Failed(err.Error())
+	}
+}
+
+type userRoleQuery struct {
+	role        string
+	isSpaceRole bool
+	orgs        []cf.OrganizationFields
+	spaces      []cf.Space
+	apiResponse net.ApiResponse
+}
+
+// listUserRoleEntries asks for the orgs and spaces of the user for every role.
+// Each org entry is followed by the entries of its spaces.
+func listUserRoleEntries(userRepo api.UserRepository, user cf.UserFields) (entries []accessEntry, apiResponse net.ApiResponse) {
+	queries := []*userRoleQuery{}
+	for _, role := range orgRoles {
+		queries = append(queries, &userRoleQuery{role: role})
+	}
+	for _, role := range spaceRoles {
+		queries = append(queries, &userRoleQuery{role: role, isSpaceRole: true})
+	}
+
+	cf.RunInParallel(len(queries), cf.MAX_PARALLEL_REQUESTS, func(index int) {
+		query := queries[index]
+		if query.isSpaceRole {
+			query.apiResponse = userRepo.ListSpacesForUserRole(user.Guid, query.role, func(spaces []cf.Space) bool {
+				query.spaces = append(query.spaces, spaces...)
+				return true
+			})
+		} else {
+			query.apiResponse = userRepo.ListOrgsForUserRole(user.Guid, query.role, func(orgs []cf.OrganizationFields) bool {
+				query.orgs = append(query.orgs, orgs...)
+				return true
+			})
+		}
+	})
+
+	orgGuids := []string{}
+	orgEntries := map[string]*accessEntry{}
+	spaceEntries := map[string][]*accessEntry{}
+	spaceEntriesByGuid := map[string]*accessEntry{}
+
+	addOrg := func(org cf.OrganizationFields) {
+		if _, found := spaceEntries[org.Guid]; !found {
+			orgGuids = append(orgGuids, org.Guid)
+			spaceEntries[org.Guid] = []*accessEntry{}
+		}
+	}
+	newEntry := func(org cf.OrganizationFields, space string) *accessEntry {
+		return &accessEntry{Username: user.Username, UserGuid: user.Guid, Org: org.Name, Space: space, Roles: []string{}, orgGuid: org.Guid}
+	}
+
+	for _, query := range queries {
+		if query.apiResponse.IsNotSuccessful() {
+			apiResponse = query.apiResponse
+			return
+		}
+
+		for _, org := range query.orgs {
+			addOrg(org)
+			entry, found := orgEntries[org.Guid]
+			if !found {
+				entry = newEntry(org, "")
+				orgEntries[org.Guid] = entry
+			}
+			entry.Roles = append(entry.Roles, query.role)
+		}
+
+		for _, space := range query.spaces {
+			addOrg(space.Organization)
+			entry, found := spaceEntriesByGuid[space.Guid]
+			if !found {
+				entry = newEntry(space.Organization, space.Name)
+				entry.spaceGuid = space.Guid
+				spaceEntriesByGuid[space.Guid] = entry
+				spaceEntries[space.Organization.Guid] = append(spaceEntries[space.Organization.Guid], entry)
+			}
+			entry.Roles = append(entry.Roles, query.role)
+		}
+	}
+
+	entries = []accessEntry{}
+	for _, orgGuid := range orgGuids {
+		if entry, found := orgEntries[orgGuid]; found {
+			entries = append(entries, *entry)
+		}
+		for _, entry := range spaceEntries[orgGuid] {
+			entries = append(entries, *entry)
+		}
+	}
+	return
+}
@@ -0,0 +1,153 @@
+package user_test
+
+import (
+	"cf"
+	. "cf/commands/user"
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testapi "testhelpers/api"
+	testassert "testhelpers/assert"
+	testcmd "testhelpers/commands"
+	testconfig "testhelpers/configuration"
+	testreq "testhelpers/requirements"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestUserRolesFailsWithUsage(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{}
+	userRepo := &testapi.FakeUserRepository{}
+
+	ui := callUserRoles(t, []string{}, reqFactory, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUserRoles(t, []string{"--format", "yaml", "alice"}, reqFactory, userRepo)
+	assert.True(t, ui.FailedWithUsage)
+
+	ui = callUserRoles(t, []string{"alice"}, reqFactory, userRepo)
+	assert.False(t, ui.FailedWithUsage)
+}
+
+func TestUserRolesRequirements(t *testing.T) {
+	userRepo := &testapi.FakeUserRepository{}
+
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: false}
+	callUserRoles(t, []string{"alice"}, reqFactory, userRepo)
+	assert.False(t, testcmd.CommandDidPassRequirements)
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+	callUserRoles(t, []string{"alice"}, reqFactory, userRepo)
+	assert.True(t, testcmd.CommandDidPassRequirements)
+	assert.Equal(t, reqFactory.UserUsername, "alice")
+}
+
+func TestUserRolesShowsEveryScopeOfTheUser(t *testing.T) {
+	reqFactory, userRepo := createUserRolesFixtures()
+
+	ui := callUserRoles(t, []string{"alice"}, reqFactory, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"Getting roles of user", "alice", "my-user"},
+		{"OK"},
+		{"org", "space", "roles"},
+		{"my-org", "OrgManager, OrgAuditor"},
+		{"my-org", "development", "SpaceManager, SpaceDeveloper"},
+		{"my-org", "production", "SpaceAuditor"},
+		{"other-org", "staging", "SpaceDeveloper"},
+	})
+	assert.Equal(t, userRepo.ListUserRolesUserGuid, "alice-guid")
+}
+
+func TestUserRolesAsCSV(t *testing.T) {
+	reqFactory, userRepo := createUserRolesFixtures()
+
+	ui := callUserRoles(t, []string{"--format", "csv", "alice"}, reqFactory, userRepo)
+
+	assert.Equal(t, ui.Outputs, []string{
+		"org,space,roles",
+		`my-org,,"OrgManager,OrgAuditor"`,
+		`my-org,development,"SpaceManager,SpaceDeveloper"`,
+		"my-org,production,SpaceAuditor",
+		"other-org,staging,SpaceDeveloper",
+	})
+}
+
+func TestUserRolesWhenTheUserHasNoRoles(t *testing.T) {
+	reqFactory := &testreq.FakeReqFactory{LoginSuccess: true, UserFields: cf.UserFields{Guid: "dave-guid", Username: "dave"}}
+	userRepo := &testapi.FakeUserRepository{}
+
+	ui := callUserRoles(t, []string{"dave"}, reqFactory, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"User", "dave", "has no roles"},
+	})
+}
+
+func TestUserRolesWhenListingRolesFails(t *testing.T) {
+	reqFactory, userRepo := createUserRolesFixtures()
+	userRepo.ListUserRolesErr = true
+
+	ui := callUserRoles(t, []string{"alice"}, reqFactory, userRepo)
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"Failed fetching user roles", "Error listing user roles"},
+	})
+}
+
+func createUserRolesFixtures() (reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository) {
+	myOrg := cf.OrganizationFields{}
+	myOrg.Name = "my-org"
+	myOrg.Guid = "my-org-guid"
+
+	otherOrg := cf.OrganizationFields{}
+	otherOrg.Name = "other-org"
+	otherOrg.Guid = "other-org-guid"
+
+	development := cf.Space{}
+	development.Name = "development"
+	development.Guid = "development-guid"
+	development.Organization = myOrg
+
+	production := cf.Space{}
+	production.Name = "production"
+	production.Guid = "production-guid"
+	production.Organization = myOrg
+
+	staging := cf.Space{}
+	staging.Name = "staging"
+	staging.Guid = "staging-guid"
+	staging.Organization = otherOrg
+
+	reqFactory = &testreq.FakeReqFactory{LoginSuccess: true, UserFields: cf.UserFields{Guid: "alice-guid", Username: "alice"}}
+	userRepo = &testapi.FakeUserRepository{
+		OrgsByUserRole: map[string][]cf.OrganizationFields{
+			cf.ORG_MANAGER: {myOrg},
+			cf.ORG_AUDITOR: {myOrg},
+		},
+		SpacesByUserRole: map[string][]cf.Space{
+			cf.SPACE_MANAGER:   {development},
+			cf.SPACE_DEVELOPER: {development, staging},
+			cf.SPACE_AUDITOR:   {production},
+		},
+	}
+	return
+}
+
+func callUserRoles(t *testing.T, args []string, reqFactory *testreq.FakeReqFactory, userRepo *testapi.FakeUserRepository) (ui *testterm.FakeUI) {
+	ui = &testterm.FakeUI{}
+
+	token, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username: "my-user",
+	})
+	assert.NoError(t, err)
+	config := &configuration.Configuration{
+		AccessToken: token,
+	}
+
+	cmd := NewUserRoles(ui, config, userRepo)
+	ctxt := testcmd.NewContext("user-roles", args)
+
+	testcmd.RunCommand(cmd, ctxt, reqFactory)
+	return
+}
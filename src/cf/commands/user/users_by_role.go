@@ -0,0 +1,28 @@
+package user
+
+import (
+	"cf"
+	"cf/net"
+)
+
+type usersInRole struct {
+	users       []cf.UserFields
+	apiResponse net.ApiResponse
+}
+
+type listUsersInRoleFunc func(role string, cb func([]cf.UserFields) bool) net.ApiResponse
+
+// listUsersByRole fetches the users for every role concurrently. Results are
+// returned in the same order as roles.
+func listUsersByRole(roles []string, listUsers listUsersInRoleFunc) (results []usersInRole) {
+	results = make([]usersInRole, len(roles))
+
+	cf.RunInParallel(len(roles), cf.MAX_PARALLEL_REQUESTS, func(index int) {
+		result := &results[index]
+		result.apiResponse = listUsers(roles[index], func(users []cf.UserFields) bool {
+			result.users = append(result.users, users...)
+			return true
+		})
+	})
+	return
+}
@@ -76,6 +76,7 @@ func (repo ConfigurationDiskRepository) Delete() {
 	}
 
 	os.Remove(file)
+	deleteCredentials()
 	singleton = nil
 }
 
@@ -92,8 +93,9 @@ func (repo ConfigurationDiskRepository) ClearTokens() (err error) {
 	if err != nil {
 		return
 	}
-	c.AccessToken = ""
-	c.RefreshToken = ""
+	c.SetTokens("", "")
+	c.ClientId = ""
+	c.ClientSecret = ""
 	return
 }
 
@@ -169,10 +171,35 @@ func load() (c *Configuration, parseError error) {
 	}
 
 	parseError = json.Unmarshal(data, c)
+	if parseError != nil {
+		return
+	}
+
+	creds, found, parseError := loadCredentials()
+	if parseError != nil {
+		return
+	}
 
+	if !found {
+		return c, migrateLegacyCredentials(data, c)
+	}
+
+	creds.applyTo(c)
 	return
 }
 
+// older versions kept tokens in config.json; move them into the credentials file
+func migrateLegacyCredentials(data []byte, c *Configuration) (err error) {
+	creds := credentials{}
+	err = json.Unmarshal(data, &creds)
+	if err != nil || creds.isEmpty() {
+		return
+	}
+
+	creds.applyTo(c)
+	return saveConfiguration(c)
+}
+
 func saveConfiguration(config *Configuration) (err error) {
 	bytes, err := json.Marshal(config)
 	if err != nil {
@@ -184,7 +211,18 @@ func saveConfiguration(config *Configuration) (err error) {
 	if err != nil {
 		return
 	}
-	err = ioutil.WriteFile(file, bytes, filePermissions)
 
-	return
+	// save the tokens before replacing a config file that may still hold
+	// legacy ones, so they are never missing from both
+	creds := credentialsFromConfig(config)
+	if creds.isEmpty() {
+		err = deleteCredentials()
+	} else {
+		err = saveCredentials(creds)
+	}
+	if err != nil {
+		return
+	}
+
+	return replaceFile(file, bytes, filePermissions)
 }
@@ -2,58 +2,125 @@ package configuration
 
 import (
 	"cf"
-	"encoding/json"
+	"sync"
 	"time"
 )
 
+// tokenLock guards the tokens of every configuration. The gateway refreshes
+// them when they expire, which can happen while other goroutines are sending
+// requests with them.
+var tokenLock sync.RWMutex
+
 type Configuration struct {
-	Target                  string
-	ApiVersion              string
-	AuthorizationEndpoint   string
-	AccessToken             string
-	RefreshToken            string
-	OrganizationFields      cf.OrganizationFields
-	SpaceFields             cf.SpaceFields
-	ApplicationStartTimeout time.Duration // will be used as seconds
+	Target                   string
+	ApiVersion               string
+	AuthorizationEndpoint    string
+	UaaEndpoint              string
+	LoggregatorEndpoint      string
+	DopplerEndpoint          string
+	RoutingApiEndpoint       string
+	MinCliVersion            string
+	MinRecommendedCliVersion string
+	InsecureHttpTargets      []string
+	AccessToken              string `json:"-"`
+	RefreshToken             string `json:"-"`
+	ClientId                 string `json:"-"`
+	ClientSecret             string `json:"-"`
+	OrganizationFields       cf.OrganizationFields
+	SpaceFields              cf.SpaceFields
+	ApplicationStartTimeout  time.Duration // will be used as seconds
 }
 
-func (c Configuration) UserEmail() (email string) {
+func (c *Configuration) UserEmail() (email string) {
 	return c.getTokenInfo().Email
 }
 
-func (c Configuration) UserGuid() (guid string) {
+func (c *Configuration) UserGuid() (guid string) {
 	return c.getTokenInfo().UserGuid
 }
 
-func (c Configuration) Username() (guid string) {
+func (c *Configuration) Username() (guid string) {
 	return c.getTokenInfo().Username
 }
 
-func (c Configuration) IsLoggedIn() bool {
-	return c.AccessToken != ""
+func (c *Configuration) IsLoggedIn() bool {
+	return c.GetAccessToken() != ""
+}
+
+func (c *Configuration) GetAccessToken() string {
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+	return c.AccessToken
+}
+
+func (c *Configuration) GetRefreshToken() string {
+	tokenLock.RLock()
+	defer tokenLock.RUnlock()
+	return c.RefreshToken
 }
 
-func (c Configuration) HasOrganization() bool {
+func (c *Configuration) SetTokens(accessToken, refreshToken string) {
+	tokenLock.Lock()
+	defer tokenLock.Unlock()
+	c.AccessToken = accessToken
+	c.RefreshToken = refreshToken
+}
+
+func (c *Configuration) HasClientCredentials() bool {
+	return c.ClientId != "" && c.ClientSecret != ""
+}
+
+func (c *Configuration) IsInsecureHttpAllowed(target string) bool {
+	for _, t := range c.InsecureHttpTargets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Configuration) HasOrganization() bool {
 	return c.OrganizationFields.Guid != "" && c.OrganizationFields.Name != ""
 }
 
-func (c Configuration) HasSpace() bool {
+func (c *Configuration) HasSpace() bool {
 	return c.SpaceFields.Guid != "" && c.SpaceFields.Name != ""
 }
 
+func (c *Configuration) HasScope(scope string) bool {
+	for _, s := range c.getTokenInfo().Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 type TokenInfo struct {
-	Username string `json:"user_name"`
-	Email    string `json:"email"`
-	UserGuid string `json:"user_id"`
+	Username  string   `json:"user_name"`
+	Email     string   `json:"email"`
+	UserGuid  string   `json:"user_id"`
+	ClientId  string   `json:"client_id,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Scopes    []string `json:"scope,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
 }
 
-func (c Configuration) getTokenInfo() (info TokenInfo) {
-	clearInfo, err := DecodeTokenInfo(c.AccessToken)
+func (info TokenInfo) ExpirationTime() time.Time {
+	if info.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(info.ExpiresAt, 0)
+}
 
-	if err != nil {
-		return
+func (info TokenInfo) ExpiresWithin(duration time.Duration) bool {
+	if info.ExpiresAt == 0 {
+		return false
 	}
-	info = TokenInfo{}
-	err = json.Unmarshal(clearInfo, &info)
+	return !time.Now().Add(duration).Before(info.ExpirationTime())
+}
+
+func (c *Configuration) getTokenInfo() (info TokenInfo) {
+	info, _ = NewTokenInfo(c.GetAccessToken())
 	return
 }
@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 )
 
@@ -40,3 +41,27 @@ func TestUserGuidWithInvalidAccessToken(t *testing.T) {
 	config.AccessToken = "bearer eyJhbGciOiJSUzI1NiJ9"
 	assert.Empty(t, config.UserGuid())
 }
+
+func TestTokensCanBeReadWhileTheyAreRefreshed(t *testing.T) {
+	config := &Configuration{}
+	config.SetTokens("bearer old-token", "old-refresh-token")
+
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			config.SetTokens("bearer new-token", "new-refresh-token")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, config.GetAccessToken(), "bearer")
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, config.GetAccessToken(), "bearer new-token")
+	assert.Equal(t, config.GetRefreshToken(), "new-refresh-token")
+}
@@ -0,0 +1,326 @@
+package configuration
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	credentialsFilePermissions = 0600
+	credentialsKeyEnvVar       = "CF_CREDENTIALS_KEY"
+	credentialsKeyFileEnvVar   = "CF_CREDENTIALS_KEY_FILE"
+	keyDerivationIterations    = 10000
+	saltLength                 = 16
+)
+
+type credentials struct {
+	AccessToken  string
+	RefreshToken string
+	ClientId     string
+	ClientSecret string
+}
+
+func (creds credentials) isEmpty() bool {
+	return creds == credentials{}
+}
+
+func credentialsFromConfig(c *Configuration) credentials {
+	return credentials{
+		AccessToken:  c.GetAccessToken(),
+		RefreshToken: c.GetRefreshToken(),
+		ClientId:     c.ClientId,
+		ClientSecret: c.ClientSecret,
+	}
+}
+
+func (creds credentials) applyTo(c *Configuration) {
+	c.SetTokens(creds.AccessToken, creds.RefreshToken)
+	c.ClientId = creds.ClientId
+	c.ClientSecret = creds.ClientSecret
+}
+
+type encryptedCredentials struct {
+	Salt       []byte
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+func credentialsFile() (file string, err error) {
+	configFile, err := ConfigFile()
+	if err != nil {
+		return
+	}
+
+	file = filepath.Join(filepath.Dir(configFile), "credentials.json")
+	return
+}
+
+func loadCredentials() (creds credentials, found bool, err error) {
+	file, err := credentialsFile()
+	if err != nil {
+		return
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	found = true
+
+	err = tightenPermissions(file)
+	if err != nil {
+		return
+	}
+
+	envelope := encryptedCredentials{}
+	err = json.Unmarshal(data, &envelope)
+	if err != nil {
+		return
+	}
+
+	if envelope.Ciphertext != nil {
+		data, err = decryptCredentials(envelope)
+		if err != nil {
+			return
+		}
+	}
+
+	err = json.Unmarshal(data, &creds)
+	return
+}
+
+func saveCredentials(creds credentials) (err error) {
+	file, err := credentialsFile()
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return
+	}
+
+	passphrase, err := credentialsPassphrase()
+	if err != nil {
+		return
+	}
+
+	if passphrase != "" {
+		var envelope encryptedCredentials
+		envelope, err = encryptCredentials(data, passphrase)
+		if err != nil {
+			return
+		}
+
+		data, err = json.Marshal(envelope)
+		if err != nil {
+			return
+		}
+	}
+
+	return replaceFile(file, data, credentialsFilePermissions)
+}
+
+func deleteCredentials() (err error) {
+	file, err := credentialsFile()
+	if err != nil {
+		return
+	}
+	return wipeFile(file)
+}
+
+func tightenPermissions(file string) (err error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return
+	}
+
+	if info.Mode().Perm()&^credentialsFilePermissions != 0 {
+		err = os.Chmod(file, credentialsFilePermissions)
+	}
+	return
+}
+
+// replaceFile writes data to a temporary file in the same directory and renames
+// it over file, so a crash leaves either the old or the new contents in place.
+// The replaced file is kept open across the rename and zeroed afterwards, so
+// tokens do not linger in its freed blocks.
+func replaceFile(file string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "."+filepath.Base(file))
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	err = tmp.Chmod(perm)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	old, openErr := os.OpenFile(file, os.O_WRONLY, 0)
+	if openErr == nil {
+		defer old.Close()
+	}
+
+	err = os.Rename(tmp.Name(), file)
+	if err != nil || openErr != nil {
+		return
+	}
+
+	return zeroFile(old)
+}
+
+// wipeFile overwrites the file with zeros before removing it, so tokens do not
+// linger in the freed blocks of the old file.
+func wipeFile(file string) (err error) {
+	f, err := os.OpenFile(file, os.O_WRONLY, 0)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = zeroFile(f)
+	f.Close()
+	if err != nil {
+		return
+	}
+
+	return os.Remove(file)
+}
+
+func zeroFile(f *os.File) (err error) {
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+
+	_, err = f.WriteAt(make([]byte, info.Size()), 0)
+	if err != nil {
+		return
+	}
+	return f.Sync()
+}
+
+func credentialsPassphrase() (passphrase string, err error) {
+	passphrase = os.Getenv(credentialsKeyEnvVar)
+	if passphrase != "" {
+		return
+	}
+
+	keyFile := os.Getenv(credentialsKeyFileEnvVar)
+	if keyFile == "" {
+		return
+	}
+
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		err = fmt.Errorf("Error reading credentials key file %s: %s", keyFile, err.Error())
+		return
+	}
+
+	passphrase = strings.TrimSpace(string(key))
+	return
+}
+
+func encryptCredentials(data []byte, passphrase string) (envelope encryptedCredentials, err error) {
+	envelope.Salt = make([]byte, saltLength)
+	_, err = io.ReadFull(rand.Reader, envelope.Salt)
+	if err != nil {
+		return
+	}
+
+	aead, err := newCredentialsCipher(passphrase, envelope.Salt)
+	if err != nil {
+		return
+	}
+
+	envelope.Nonce = make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, envelope.Nonce)
+	if err != nil {
+		return
+	}
+
+	envelope.Ciphertext = aead.Seal(nil, envelope.Nonce, data, nil)
+	return
+}
+
+func decryptCredentials(envelope encryptedCredentials) (data []byte, err error) {
+	passphrase, err := credentialsPassphrase()
+	if err != nil {
+		return
+	}
+
+	if passphrase == "" {
+		err = fmt.Errorf("Credentials are encrypted. Set %s or %s to decrypt them.", credentialsKeyEnvVar, credentialsKeyFileEnvVar)
+		return
+	}
+
+	aead, err := newCredentialsCipher(passphrase, envelope.Salt)
+	if err != nil {
+		return
+	}
+
+	data, err = aead.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		err = errors.New("Could not decrypt credentials. Check the credentials key and try again.")
+	}
+	return
+}
+
+func newCredentialsCipher(passphrase string, salt []byte) (aead cipher.AEAD, err error) {
+	block, err := aes.NewCipher(deriveKey(passphrase, salt))
+	if err != nil {
+		return
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey is PBKDF2-HMAC-SHA256 producing a single 32 byte block
+func deriveKey(passphrase string, salt []byte) []byte {
+	prf := hmac.New(sha256.New, []byte(passphrase))
+	prf.Write(salt)
+	prf.Write([]byte{0, 0, 0, 1})
+	u := prf.Sum(nil)
+
+	key := make([]byte, len(u))
+	copy(key, u)
+
+	for i := 1; i < keyDerivationIterations; i++ {
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
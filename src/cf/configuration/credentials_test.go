@@ -0,0 +1,202 @@
+package configuration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTokensAreSavedToACredentialsFile(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.Target = "https://api.example.com"
+	config.AccessToken = "bearer my_access_token"
+	config.RefreshToken = "my_refresh_token"
+	err := repo.Save()
+	assert.NoError(t, err)
+
+	configContents := readConfigFile(t)
+	assert.Contains(t, configContents, "https://api.example.com")
+	assert.False(t, strings.Contains(configContents, "my_access_token"))
+	assert.False(t, strings.Contains(configContents, "my_refresh_token"))
+
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+
+	info, err := os.Stat(credsFile)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.AccessToken, "bearer my_access_token")
+	assert.Equal(t, savedConfig.RefreshToken, "my_refresh_token")
+}
+
+func TestLegacyTokensAreMovedOutOfTheConfigFile(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	file, err := ConfigFile()
+	assert.NoError(t, err)
+
+	err = ioutil.WriteFile(file, []byte(`{"Target":"https://api.example.com","AccessToken":"bearer legacy_token","RefreshToken":"legacy_refresh"}`), 0644)
+	assert.NoError(t, err)
+	deleteCredentials()
+
+	singleton = nil
+	config, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, config.Target, "https://api.example.com")
+	assert.Equal(t, config.AccessToken, "bearer legacy_token")
+	assert.Equal(t, config.RefreshToken, "legacy_refresh")
+
+	assert.False(t, strings.Contains(readConfigFile(t), "legacy_token"))
+
+	creds, found, err := loadCredentials()
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, creds.AccessToken, "bearer legacy_token")
+}
+
+func TestLoadingTightensCredentialsFilePermissions(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.AccessToken = "bearer my_access_token"
+	repo.Save()
+
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+	err = os.Chmod(credsFile, 0644)
+	assert.NoError(t, err)
+
+	singleton = nil
+	_, err = repo.Get()
+	assert.NoError(t, err)
+
+	info, err := os.Stat(credsFile)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+}
+
+func TestEncryptedCredentials(t *testing.T) {
+	os.Setenv("CF_CREDENTIALS_KEY", "my secret passphrase")
+	defer os.Setenv("CF_CREDENTIALS_KEY", "")
+
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.AccessToken = "bearer my_access_token"
+	err := repo.Save()
+	assert.NoError(t, err)
+
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+	contents, err := ioutil.ReadFile(credsFile)
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(contents), "my_access_token"))
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.AccessToken, "bearer my_access_token")
+
+	os.Setenv("CF_CREDENTIALS_KEY", "the wrong passphrase")
+	singleton = nil
+	_, err = repo.Get()
+	assert.Error(t, err)
+
+	os.Setenv("CF_CREDENTIALS_KEY", "")
+	singleton = nil
+	_, err = repo.Get()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CF_CREDENTIALS_KEY")
+}
+
+func TestEncryptedCredentialsWithAKeyFile(t *testing.T) {
+	keyFile, err := ioutil.TempFile("", "cf-credentials-key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	keyFile.WriteString("key file passphrase\n")
+	keyFile.Close()
+
+	os.Setenv("CF_CREDENTIALS_KEY_FILE", keyFile.Name())
+	defer os.Setenv("CF_CREDENTIALS_KEY_FILE", "")
+
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.RefreshToken = "my_refresh_token"
+	err = repo.Save()
+	assert.NoError(t, err)
+
+	singleton = nil
+	savedConfig, err := repo.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, savedConfig.RefreshToken, "my_refresh_token")
+}
+
+func TestClearSessionRemovesTheCredentialsFile(t *testing.T) {
+	repo := NewConfigurationDiskRepository()
+	config := repo.loadDefaultConfig(t)
+	defer repo.restoreConfig(t)
+
+	config.AccessToken = "bearer my_access_token"
+	repo.Save()
+
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+	_, err = os.Stat(credsFile)
+	assert.NoError(t, err)
+
+	err = repo.ClearSession()
+	assert.NoError(t, err)
+
+	_, err = os.Stat(credsFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func readConfigFile(t *testing.T) string {
+	file, err := ConfigFile()
+	assert.NoError(t, err)
+
+	contents, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	return strings.TrimSpace(string(contents))
+}
+
+func TestReplaceFileLeavesOnlyTheNewContents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replace-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "credentials.json")
+	err = ioutil.WriteFile(file, []byte(`{"AccessToken":"old_token"}`), 0644)
+	assert.NoError(t, err)
+
+	err = replaceFile(file, []byte(`{"AccessToken":"new_token"}`), 0600)
+	assert.NoError(t, err)
+
+	contents, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, string(contents), `{"AccessToken":"new_token"}`)
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	entries, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, len(entries), 1)
+}
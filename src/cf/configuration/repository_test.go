@@ -136,13 +136,13 @@ func TestClearSession(t *testing.T) {
 func (repo ConfigurationDiskRepository) loadDefaultConfig(t *testing.T) (config *Configuration) {
 	file, err := ConfigFile()
 	assert.NoError(t, err)
+	backupFile(t, file)
 
-	_, err = os.Stat(file)
-	if !os.IsNotExist(err) {
-		err = os.Rename(file, file+"test-backup")
-		assert.NoError(t, err)
-	}
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+	backupFile(t, credsFile)
 
+	singleton = nil
 	config, err = repo.Get()
 	assert.NoError(t, err)
 
@@ -155,12 +155,30 @@ func (repo ConfigurationDiskRepository) restoreConfig(t *testing.T) {
 
 	err = os.Remove(file)
 	assert.NoError(t, err)
+	restoreFile(t, file)
 
-	_, err = os.Stat(file + "test-backup")
+	credsFile, err := credentialsFile()
+	assert.NoError(t, err)
+
+	os.Remove(credsFile)
+	restoreFile(t, credsFile)
+
+	singleton = nil
+	return
+}
+
+func backupFile(t *testing.T, file string) {
+	_, err := os.Stat(file)
 	if !os.IsNotExist(err) {
-		err = os.Rename(file+"test-backup", file)
+		err = os.Rename(file, file+"test-backup")
 		assert.NoError(t, err)
 	}
+}
 
-	return
+func restoreFile(t *testing.T, file string) {
+	_, err := os.Stat(file + "test-backup")
+	if !os.IsNotExist(err) {
+		err = os.Rename(file+"test-backup", file)
+		assert.NoError(t, err)
+	}
 }
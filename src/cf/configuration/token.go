@@ -2,9 +2,20 @@ package configuration
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"strings"
 )
 
+func NewTokenInfo(accessToken string) (info TokenInfo, err error) {
+	clearInfo, err := DecodeTokenInfo(accessToken)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(clearInfo, &info)
+	return
+}
+
 func DecodeTokenInfo(accessToken string) (clearTokenInfo []byte, err error) {
 	tokenParts := strings.Split(accessToken, " ")
 
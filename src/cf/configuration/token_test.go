@@ -3,6 +3,7 @@ package configuration
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestDecodeTokenInfoWithoutRestoringPadding(t *testing.T) {
@@ -20,3 +21,27 @@ func TestDecodeTokenInfoWhenRestoringPadding(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, string(decodedInfo), "tlang@gopivotal.com")
 }
+
+func TestNewTokenInfoReadsTheExpirationTime(t *testing.T) {
+	accessToken := "bearer eyJhbGciOiJSUzI1NiJ9.eyJqdGkiOiJjNDE4OTllNS1kZTE1LTQ5NGQtYWFiNC04ZmNlYzUxN2UwMDUiLCJzdWIiOiI3NzJkZGEzZi02NjlmLTQyNzYtYjJiZC05MDQ4NmFiZTFmNmYiLCJzY29wZSI6WyJjbG91ZF9jb250cm9sbGVyLnJlYWQiLCJjbG91ZF9jb250cm9sbGVyLndyaXRlIiwib3BlbmlkIiwicGFzc3dvcmQud3JpdGUiXSwiY2xpZW50X2lkIjoiY2YiLCJjaWQiOiJjZiIsImdyYW50X3R5cGUiOiJwYXNzd29yZCIsInVzZXJfaWQiOiI3NzJkZGEzZi02NjlmLTQyNzYtYjJiZC05MDQ4NmFiZTFmNmYiLCJ1c2VyX25hbWUiOiJ1c2VyMUBleGFtcGxlLmNvbSIsImVtYWlsIjoidXNlcjFAZXhhbXBsZS5jb20iLCJpYXQiOjEzNzcwMjgzNTYsImV4cCI6MTM3NzAzNTU1NiwiaXNzIjoiaHR0cHM6Ly91YWEuYXJib3JnbGVuLmNmLWFwcC5jb20vb2F1dGgvdG9rZW4iLCJhdWQiOlsib3BlbmlkIiwiY2xvdWRfY29udHJvbGxlciIsInBhc3N3b3JkIl19.kjFJHi0Qir9kfqi2eyhHy6kdewhicAFu8hrPR1a5AxFvxGB45slKEjuP0_72cM_vEYICgZn3PcUUkHU9wghJO9wjZ6kiIKK1h5f2K9g-Iprv9BbTOWUODu1HoLIvg2TtGsINxcRYy_8LW1RtvQc1b4dBPoopaEH4no-BIzp0E5E"
+	info, err := NewTokenInfo(accessToken)
+
+	assert.NoError(t, err)
+	assert.Equal(t, info.ExpiresAt, int64(1377035556))
+	assert.Equal(t, info.ExpirationTime(), time.Unix(1377035556, 0))
+	assert.True(t, info.ExpiresWithin(0))
+}
+
+func TestTokenWithoutExpirationNeverExpires(t *testing.T) {
+	info := TokenInfo{Username: "user1@example.com"}
+
+	assert.True(t, info.ExpirationTime().IsZero())
+	assert.False(t, info.ExpiresWithin(time.Hour))
+}
+
+func TestTokenExpiresWithin(t *testing.T) {
+	info := TokenInfo{ExpiresAt: time.Now().Add(time.Minute).Unix()}
+
+	assert.False(t, info.ExpiresWithin(30*time.Second))
+	assert.True(t, info.ExpiresWithin(2*time.Minute))
+}
@@ -20,6 +20,14 @@ const (
 	InstanceDown                   = "down"
 )
 
+const (
+	HealthCheckPort = "port"
+	HealthCheckHttp = "http"
+	HealthCheckNone = "none"
+)
+
+var HealthCheckTypes = []string{HealthCheckPort, HealthCheckHttp, HealthCheckNone}
+
 type BasicFields struct {
 	Guid string
 	Name string
@@ -54,15 +62,18 @@ type Space struct {
 
 type ApplicationFields struct {
 	BasicFields
-	BuildpackUrl     string
-	Command          string
-	DiskQuota        uint64 // in Megabytes
-	EnvironmentVars  map[string]string
-	InstanceCount    int
-	Memory           uint64 // in Megabytes
-	RunningInstances int
-	State            string
-	SpaceGuid        string
+	BuildpackUrl            string
+	Command                 string
+	DiskQuota               uint64 // in Megabytes
+	DockerImage             string
+	EnvironmentVars         map[string]interface{}
+	HealthCheckType         string
+	HealthCheckHttpEndpoint string
+	InstanceCount           int
+	Memory                  uint64 // in Megabytes
+	RunningInstances        int
+	State                   string
+	SpaceGuid               string
 }
 
 type ApplicationSet []Application
@@ -78,7 +89,6 @@ func (model Application) ToParams() (params AppParams) {
 		"name":       model.Name,
 		"buildpack":  model.BuildpackUrl,
 		"command":    model.Command,
-		"disk_quota": model.DiskQuota,
 		"instances":  model.InstanceCount,
 		"memory":     model.Memory,
 		"state":      strings.ToUpper(model.State),
@@ -87,6 +97,19 @@ func (model Application) ToParams() (params AppParams) {
 		"env":        generic.NewMap(model.EnvironmentVars),
 	})
 
+	if model.DiskQuota != 0 {
+		params.Set("disk_quota", model.DiskQuota)
+	}
+	if model.HealthCheckType != "" {
+		params.Set("health_check_type", model.HealthCheckType)
+	}
+	if model.HealthCheckHttpEndpoint != "" {
+		params.Set("health_check_http_endpoint", model.HealthCheckHttpEndpoint)
+	}
+	if model.DockerImage != "" {
+		params.Set("docker_image", model.DockerImage)
+	}
+
 	return
 }
 
@@ -126,6 +149,14 @@ func NewAppParams(data interface{}) (params AppParams) {
 		}
 		params.Map.Set("memory", memory)
 	}
+
+	if params.Map.NotNil("disk_quota") {
+		diskQuota, err := formatters.ToMegabytes(params.Map.Get("disk_quota"))
+		if err != nil {
+			panic(err)
+		}
+		params.Map.Set("disk_quota", diskQuota)
+	}
 	return
 }
 
@@ -148,6 +179,15 @@ func NewAppParamsFromContext(c *cli.Context) (appParams AppParams, err error) {
 		}
 		appParams.Set("memory", memory)
 	}
+	if c.String("k") != "" {
+		var diskQuota uint64
+		diskQuota, err = formatters.ToMegabytes(c.String("k"))
+		if err != nil {
+			err = errors.New(fmt.Sprintf("Invalid disk quota param: %s\n%s", c.String("k"), err))
+			return
+		}
+		appParams.Set("disk_quota", diskQuota)
+	}
 	if c.String("c") != "" {
 		appParams.Set("command", c.String("c"))
 	}
@@ -173,10 +213,62 @@ func NewAppParamsFromContext(c *cli.Context) (appParams AppParams, err error) {
 
 		appParams.Set("health_check_timeout", timeout)
 	}
+	if c.String("route") != "" {
+		routes := []RouteSpec{}
+		for _, url := range strings.Split(c.String("route"), ",") {
+			url = strings.TrimSpace(url)
+			if url != "" {
+				routes = append(routes, RouteSpec{Url: url})
+			}
+		}
+		appParams.Set("routes", routes)
+	}
+	if c.String("health-check-type") != "" {
+		appParams.Set("health_check_type", c.String("health-check-type"))
+	}
+	if c.String("health-check-http-endpoint") != "" {
+		appParams.Set("health_check_http_endpoint", c.String("health-check-http-endpoint"))
+	}
+	if c.String("docker-image") != "" {
+		appParams.Set("docker_image", c.String("docker-image"))
+	}
+
+	err = ValidateHealthCheck(appParams)
+	return
+}
+
+// ValidateHealthCheck checks the health check type is a known one, and that
+// an http endpoint only comes with the http type. An endpoint on its own
+// selects the http type.
+func ValidateHealthCheck(appParams AppParams) (err error) {
+	if !appParams.NotNil("health_check_type") {
+		if appParams.NotNil("health_check_http_endpoint") {
+			appParams.Set("health_check_type", HealthCheckHttp)
+		}
+		return
+	}
 
+	healthCheckType, ok := appParams.Get("health_check_type").(string)
+	if !ok || !stringInSlice(healthCheckType, HealthCheckTypes) {
+		err = errors.New(fmt.Sprintf("Invalid health check type: %v\nExpected one of %s", appParams.Get("health_check_type"), strings.Join(HealthCheckTypes, ", ")))
+		return
+	}
+
+	if appParams.NotNil("health_check_http_endpoint") && healthCheckType != HealthCheckHttp {
+		err = errors.New(fmt.Sprintf("A health check endpoint can only be set with the %s health check type", HealthCheckHttp))
+	}
 	return
 }
 
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
 type AppSet []AppParams
 
 func NewEmptyAppSet() AppSet {
@@ -219,6 +311,10 @@ func (model DomainFields) UrlForHost(host string) string {
 	return fmt.Sprintf("%s.%s", host, model.Name)
 }
 
+func (model DomainFields) UrlForHostAndPath(host, path string) string {
+	return model.UrlForHost(host) + path
+}
+
 type Domain struct {
 	DomainFields
 	Spaces []SpaceFields
@@ -234,6 +330,7 @@ type EventFields struct {
 type RouteFields struct {
 	Guid string
 	Host string
+	Path string
 }
 
 type Route struct {
@@ -248,10 +345,18 @@ type RouteSummary struct {
 }
 
 func (model RouteSummary) URL() string {
-	if model.Host == "" {
-		return model.Domain.Name
-	}
-	return fmt.Sprintf("%s.%s", model.Host, model.Domain.Name)
+	return model.Domain.UrlForHostAndPath(model.Host, model.Path)
+}
+
+// RouteSpec is a route an app should be bound to, as listed in a manifest or
+// on the command line. An empty Domain stands for the default shared domain.
+// Routes given on the command line only have a Url, split into a host and a
+// domain once the domains are known.
+type RouteSpec struct {
+	Host   string
+	Domain string
+	Path   string
+	Url    string
 }
 
 type Stack struct {
@@ -262,6 +367,9 @@ type Stack struct {
 type AppInstanceFields struct {
 	State     InstanceState
 	Since     time.Time
+	Host      string
+	Port      int
+	Uptime    time.Duration
 	CpuUsage  float64 // percentage
 	DiskQuota uint64  // in bytes
 	DiskUsage uint64
@@ -309,7 +417,29 @@ type ServiceInstanceFields struct {
 	BasicFields
 	SysLogDrainUrl   string
 	ApplicationNames []string
-	Params           map[string]string
+	Params           map[string]interface{}
+	LastOperation    LastOperationFields
+}
+
+const (
+	LastOperationInProgress = "in progress"
+	LastOperationSucceeded  = "succeeded"
+	LastOperationFailed     = "failed"
+)
+
+type LastOperationFields struct {
+	Type        string
+	State       string
+	Description string
+}
+
+// ServiceInstanceDeclaration describes a service instance a manifest expects,
+// so push can create it when it does not exist yet.
+type ServiceInstanceDeclaration struct {
+	Name   string
+	Label  string
+	Plan   string
+	Params map[string]interface{}
 }
 
 type ServiceInstanceSet []ServiceInstance
@@ -26,3 +26,15 @@ func TestRouteURLWithoutHost(t *testing.T) {
 
 	assert.Equal(t, route.URL(), "example.com")
 }
+
+func TestRouteURLWithPath(t *testing.T) {
+	route := Route{}
+	route.Host = "foo"
+	route.Path = "/bar"
+
+	domain := DomainFields{}
+	domain.Name = "example.com"
+	route.Domain = domain
+
+	assert.Equal(t, route.URL(), "foo.example.com/bar")
+}
@@ -6,4 +6,6 @@ const (
 	UaaEndpointKey             EndpointType = "uaa"
 	LoggregatorEndpointKey                  = "loggregator"
 	CloudControllerEndpointKey              = "cloud_controller"
+	DopplerEndpointKey                      = "doppler"
+	RoutingApiEndpointKey                   = "routing_api"
 )
@@ -90,6 +90,13 @@ func ToMegabytes(val interface{}) (megabytes uint64, err error) {
 	case uint64:
 		megabytes = val
 		return
+	case int:
+		if val >= 0 {
+			megabytes = uint64(val)
+			return
+		}
+		err = errors.New(fmt.Sprintf("Cannot parse memory size from input:\n%#v", val))
+		return
 	default:
 		err = errors.New(fmt.Sprintf("Cannot parse memory size from input:\n%#v", val))
 		return
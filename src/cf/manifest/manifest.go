@@ -6,8 +6,9 @@ import (
 )
 
 type Manifest struct {
-	data         generic.Map
-	Applications cf.AppSet
+	data             generic.Map
+	Applications     cf.AppSet
+	DeclaredServices []cf.ServiceInstanceDeclaration
 }
 
 func NewEmptyManifest() (m *Manifest) {
@@ -25,6 +26,7 @@ func NewManifest(data generic.Map) (m *Manifest, errs ManifestErrors) {
 	}
 
 	m.Applications = components.Applications
+	m.DeclaredServices = components.DeclaredServices
 
 	for _, app := range m.Applications {
 		localEnv := generic.NewMap(app.Get("env"))
@@ -3,19 +3,23 @@ package manifest
 import (
 	"cf"
 	"errors"
+	"fmt"
 	"generic"
+	"strings"
 )
 
 type manifestComponents struct {
-	Applications   cf.AppSet
-	GlobalServices []string
-	GlobalEnvVars  generic.Map
+	Applications     cf.AppSet
+	GlobalServices   []string
+	GlobalEnvVars    generic.Map
+	DeclaredServices []cf.ServiceInstanceDeclaration
 }
 
 func newManifestComponents(data generic.Map) (m manifestComponents, errs ManifestErrors) {
 	m.Applications = cf.NewEmptyAppSet()
 	m.GlobalEnvVars = generic.NewMap()
 	m.GlobalServices = []string{}
+	m.DeclaredServices = []cf.ServiceInstanceDeclaration{}
 
 	if data.Has("applications") {
 		m.Applications = cf.NewAppSet(data.Get("applications"))
@@ -36,6 +40,34 @@ func newManifestComponents(data generic.Map) (m manifestComponents, errs Manifes
 				app.Set("services", []string{})
 			}
 
+			if app.Has("depends_on") {
+				dependencies, err := servicesComponent(app.Get("depends_on"))
+				if err != nil {
+					errs = append(errs, errors.New("Expected depends_on to be an array of app names."))
+				} else {
+					app.Set("depends_on", dependencies)
+				}
+			}
+
+			if app.Has("docker") {
+				image, err := dockerComponent(app.Get("docker"))
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					app.Delete("docker")
+					app.Set("docker_image", image)
+				}
+			}
+
+			if app.Has("routes") {
+				appRoutes, err := routesComponent(app.Get("routes"))
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					app.Set("routes", appRoutes)
+				}
+			}
+
 			if app.Has("env") {
 				env, ok := app.Get("env").(map[interface{}]interface{})
 				if !ok {
@@ -67,6 +99,15 @@ func newManifestComponents(data generic.Map) (m manifestComponents, errs Manifes
 		}
 	}
 
+	if data.Has("declared-services") {
+		declaredServices, err := declaredServicesComponent(data.Get("declared-services"))
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			m.DeclaredServices = declaredServices
+		}
+	}
+
 	return
 }
 
@@ -78,6 +119,11 @@ func validateAppParams(appParams cf.AppParams) (errs ManifestErrors) {
 		}
 	}
 
+	err := cf.ValidateHealthCheck(appParams)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	return
 }
 
@@ -99,6 +145,141 @@ func servicesComponent(input interface{}) (result []string, err error) {
 	return
 }
 
+func routesComponent(input interface{}) (routes []cf.RouteSpec, err error) {
+	invalid := errors.New("Expected routes to be an array of host, domain and optional path.")
+
+	values, ok := input.([]interface{})
+	if !ok {
+		err = invalid
+		return
+	}
+
+	routes = []cf.RouteSpec{}
+	for _, value := range values {
+		fields, ok := value.(map[interface{}]interface{})
+		if !ok {
+			err = invalid
+			return
+		}
+
+		route := cf.RouteSpec{}
+		for key, field := range fields {
+			stringField, ok := field.(string)
+			if !ok {
+				err = invalid
+				return
+			}
+
+			switch key {
+			case "host":
+				route.Host = stringField
+			case "domain":
+				route.Domain = stringField
+			case "path":
+				route.Path = stringField
+			default:
+				err = invalid
+				return
+			}
+		}
+
+		if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
+			err = errors.New(fmt.Sprintf("Route path %s should start with /", route.Path))
+			return
+		}
+		routes = append(routes, route)
+	}
+	return
+}
+
+func dockerComponent(input interface{}) (image string, err error) {
+	fields, ok := input.(map[interface{}]interface{})
+	if ok {
+		image, ok = fields["image"].(string)
+	}
+	if !ok || image == "" {
+		err = errors.New("Expected docker to be a set with an image.")
+	}
+	return
+}
+
+func declaredServicesComponent(input interface{}) (declarations []cf.ServiceInstanceDeclaration, err error) {
+	invalid := errors.New("Expected declared-services to be an array of name, label, plan and optional parameters.")
+
+	values, ok := input.([]interface{})
+	if !ok {
+		err = invalid
+		return
+	}
+
+	declarations = []cf.ServiceInstanceDeclaration{}
+	for _, value := range values {
+		fields, ok := value.(map[interface{}]interface{})
+		if !ok {
+			err = invalid
+			return
+		}
+
+		declaration := cf.ServiceInstanceDeclaration{}
+		for key, field := range fields {
+			if key == "parameters" {
+				params, ok := stringKeyedValue(field).(map[string]interface{})
+				if !ok {
+					err = errors.New("Expected service parameters to be a set of key => value.")
+					return
+				}
+				declaration.Params = params
+				continue
+			}
+
+			stringField, ok := field.(string)
+			if !ok {
+				err = invalid
+				return
+			}
+
+			switch key {
+			case "name":
+				declaration.Name = stringField
+			case "label":
+				declaration.Label = stringField
+			case "plan":
+				declaration.Plan = stringField
+			default:
+				err = invalid
+				return
+			}
+		}
+
+		if declaration.Name == "" || declaration.Label == "" || declaration.Plan == "" {
+			err = invalid
+			return
+		}
+		declarations = append(declarations, declaration)
+	}
+	return
+}
+
+// stringKeyedValue converts the maps the yaml parser returns into maps keyed
+// by strings, so that they can be encoded as json.
+func stringKeyedValue(input interface{}) interface{} {
+	switch input := input.(type) {
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range input {
+			result[fmt.Sprintf("%v", key)] = stringKeyedValue(value)
+		}
+		return result
+	case []interface{}:
+		result := []interface{}{}
+		for _, value := range input {
+			result = append(result, stringKeyedValue(value))
+		}
+		return result
+	}
+	return input
+}
+
 func mergeSets(set1, set2 []string) (result []string) {
 	for _, aString := range set1 {
 		result = append(result, aString)
@@ -22,6 +22,22 @@ applications:
 - name: db-backed-app
 `
 
+var manifestWithDeclaredServices = `
+---
+declared-services:
+- name: my-db
+  label: cleardb
+  plan: spark
+  parameters:
+    max_connections: 10
+    backup:
+      schedule: daily
+applications:
+- name: db-backed-app
+  services:
+  - my-db
+`
+
 func TestParsingApplicationName(t *testing.T) {
 	manifest, err := Parse(strings.NewReader(simpleManifest))
 	assert.NoError(t, err)
@@ -38,3 +54,27 @@ func TestParsingManifestServices(t *testing.T) {
 	assert.Equal(t, services[1], "new-service")
 	assert.Equal(t, services[2], "cool-service")
 }
+
+func TestParsingDeclaredServices(t *testing.T) {
+	manifest, err := Parse(strings.NewReader(manifestWithDeclaredServices))
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(manifest.DeclaredServices), 1)
+	declaration := manifest.DeclaredServices[0]
+	assert.Equal(t, declaration.Name, "my-db")
+	assert.Equal(t, declaration.Label, "cleardb")
+	assert.Equal(t, declaration.Plan, "spark")
+	assert.Equal(t, declaration.Params["max_connections"], 10)
+	assert.Equal(t, declaration.Params["backup"], map[string]interface{}{"schedule": "daily"})
+}
+
+func TestParsingInvalidDeclaredServices(t *testing.T) {
+	_, errs := Parse(strings.NewReader(`
+---
+declared-services:
+- name: my-db
+  plan: spark
+`))
+	assert.Equal(t, len(errs), 1)
+	assert.Contains(t, errs[0].Error(), "Expected declared-services to be an array")
+}
@@ -1,7 +1,9 @@
 package net
 
 import (
+	"bytes"
 	"cf"
+	"cf/configuration"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +12,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,6 +21,7 @@ const (
 	JOB_FINISHED             = "finished"
 	JOB_FAILED               = "failed"
 	DEFAULT_POLLING_THROTTLE = 5 * time.Second
+	TOKEN_EXPIRY_MARGIN      = 30 * time.Second
 )
 
 type JobEntity struct {
@@ -47,6 +51,12 @@ type tokenRefresher interface {
 	RefreshAuthToken() (string, ApiResponse)
 }
 
+type tokenRefreshState struct {
+	sync.Mutex
+	staleToken string
+	freshToken string
+}
+
 type Request struct {
 	HttpReq      *http.Request
 	SeekableBody io.ReadSeeker
@@ -57,11 +67,13 @@ type Gateway struct {
 	errHandler      errorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
+	refreshState    *tokenRefreshState
 }
 
 func newGateway(errHandler errorHandler) (gateway Gateway) {
 	gateway.errHandler = errHandler
 	gateway.PollingThrottle = DEFAULT_POLLING_THROTTLE
+	gateway.refreshState = new(tokenRefreshState)
 	return
 }
 
@@ -173,6 +185,41 @@ func (gateway Gateway) PerformRequestForResponseBytes(request *Request) (bytes [
 	return
 }
 
+// PerformRequestForRawResponse returns the response whatever its status. The
+// api response is only unsuccessful when no response was received at all.
+func (gateway Gateway) PerformRequestForRawResponse(request *Request) (statusCode int, headers http.Header, body []byte, apiResponse ApiResponse) {
+	rawResponse, apiResponse := gateway.doRequestHandlingAuth(request)
+	if rawResponse == nil {
+		return
+	}
+	defer rawResponse.Body.Close()
+
+	body, err := ioutil.ReadAll(rawResponse.Body)
+	if err != nil {
+		apiResponse = NewApiResponseWithError("Error reading response", err)
+		return
+	}
+
+	statusCode = rawResponse.StatusCode
+	headers = rawResponse.Header
+	apiResponse = NewApiResponseWithStatusCode(statusCode)
+	return
+}
+
+// PerformRequestForBody leaves reading the response to the caller, who must
+// close the body. It is meant for downloads too large to hold in memory.
+func (gateway Gateway) PerformRequestForBody(request *Request) (body io.ReadCloser, contentLength int64, headers http.Header, apiResponse ApiResponse) {
+	rawResponse, apiResponse := gateway.doRequestHandlingAuth(request)
+	if apiResponse.IsNotSuccessful() {
+		return
+	}
+
+	body = rawResponse.Body
+	contentLength = rawResponse.ContentLength
+	headers = rawResponse.Header
+	return
+}
+
 func (gateway Gateway) PerformRequestForTextResponse(request *Request) (response string, headers http.Header, apiResponse ApiResponse) {
 	bytes, headers, apiResponse := gateway.PerformRequestForResponseBytes(request)
 	response = string(bytes)
@@ -268,6 +315,14 @@ func (gateway Gateway) waitForJob(jobUrl, accessToken string) (apiResponse ApiRe
 func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
 	httpReq := request.HttpReq
 
+	// refresh ahead of time so large or streamed bodies are only sent once
+	if gateway.authenticator != nil && tokenExpiresSoon(httpReq.Header.Get("Authorization")) {
+		apiResponse = gateway.refreshToken(request)
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+	}
+
 	// perform request
 	rawResponse, apiResponse = gateway.doRequestAndHandlerError(request)
 	if apiResponse.IsSuccessful() || gateway.authenticator == nil {
@@ -279,13 +334,12 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *htt
 	}
 
 	// refresh the auth token
-	newToken, apiResponse := gateway.authenticator.RefreshAuthToken()
+	apiResponse = gateway.refreshToken(request)
 	if apiResponse.IsNotSuccessful() {
 		return
 	}
 
-	// reset the auth token and request body
-	httpReq.Header.Set("Authorization", newToken)
+	// reset the request body
 	if request.SeekableBody != nil {
 		request.SeekableBody.Seek(0, 0)
 		httpReq.Body = ioutil.NopCloser(request.SeekableBody)
@@ -296,6 +350,37 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (rawResponse *htt
 	return
 }
 
+func (gateway Gateway) refreshToken(request *Request) (apiResponse ApiResponse) {
+	staleToken := request.HttpReq.Header.Get("Authorization")
+
+	state := gateway.refreshState
+	state.Lock()
+	defer state.Unlock()
+
+	// another request may already have refreshed this token while we were waiting
+	if state.staleToken != staleToken || state.freshToken == "" {
+		var newToken string
+		newToken, apiResponse = gateway.authenticator.RefreshAuthToken()
+		if apiResponse.IsNotSuccessful() {
+			return
+		}
+
+		state.staleToken = staleToken
+		state.freshToken = newToken
+	}
+
+	request.HttpReq.Header.Set("Authorization", state.freshToken)
+	return
+}
+
+func tokenExpiresSoon(accessToken string) bool {
+	info, err := configuration.NewTokenInfo(accessToken)
+	if err != nil {
+		return false
+	}
+	return info.ExpiresWithin(TOKEN_EXPIRY_MARGIN)
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (rawResponse *http.Response, apiResponse ApiResponse) {
 	rawResponse, err := doRequest(request.HttpReq)
 	if err != nil {
@@ -304,7 +389,14 @@ func (gateway Gateway) doRequestAndHandlerError(request *Request) (rawResponse *
 	}
 
 	if rawResponse.StatusCode > 299 {
+		// keep the body readable for callers that want the raw error response
+		body, _ := ioutil.ReadAll(rawResponse.Body)
+		rawResponse.Body.Close()
+		rawResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
+
 		errorResponse := gateway.errHandler(rawResponse)
+		rawResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
+
 		message := fmt.Sprintf(
 			"Server error, status code: %d, error code: %s, message: %s",
 			rawResponse.StatusCode,
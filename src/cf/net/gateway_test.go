@@ -13,9 +13,11 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	testconfig "testhelpers/configuration"
 	testnet "testhelpers/net"
 	"testing"
+	"time"
 )
 
 func TestNewRequest(t *testing.T) {
@@ -166,3 +168,103 @@ func createAuthenticationRepository(t *testing.T, apiServer *httptest.Server, au
 
 	return config, authenticator
 }
+
+type countingTokenRefresher struct {
+	sync.Mutex
+	token string
+	calls int
+}
+
+func (refresher *countingTokenRefresher) RefreshAuthToken() (string, ApiResponse) {
+	refresher.Lock()
+	defer refresher.Unlock()
+
+	refresher.calls++
+	time.Sleep(10 * time.Millisecond)
+	return refresher.token, NewSuccessfulApiResponse()
+}
+
+func expiringAccessToken(t *testing.T, expiresIn time.Duration) string {
+	accessToken, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Username:  "user1@example.com",
+		ExpiresAt: time.Now().Add(expiresIn).Unix(),
+	})
+	assert.NoError(t, err)
+	return accessToken
+}
+
+func TestTokenIsRefreshedBeforeItExpires(t *testing.T) {
+	requestCount := 0
+	endpoint := func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		bodyBytes, _ := ioutil.ReadAll(request.Body)
+		assert.Equal(t, string(bodyBytes), "expected body")
+
+		if request.Header.Get("Authorization") != "bearer new-access-token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(endpoint))
+	defer apiServer.Close()
+
+	refresher := &countingTokenRefresher{token: "bearer new-access-token"}
+	gateway := NewCloudControllerGateway()
+	gateway.SetTokenRefresher(refresher)
+
+	request, apiResponse := gateway.NewRequest("PUT", apiServer.URL+"/v2/foo", expiringAccessToken(t, 5*time.Second), strings.NewReader("expected body"))
+	assert.True(t, apiResponse.IsSuccessful())
+
+	apiResponse = gateway.PerformRequest(request)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, refresher.calls, 1)
+	assert.Equal(t, requestCount, 1)
+}
+
+func TestTokenIsNotRefreshedWhenFarFromExpiry(t *testing.T) {
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	refresher := &countingTokenRefresher{token: "bearer new-access-token"}
+	gateway := NewCloudControllerGateway()
+	gateway.SetTokenRefresher(refresher)
+
+	request, _ := gateway.NewRequest("GET", apiServer.URL+"/v2/foo", expiringAccessToken(t, time.Hour), nil)
+	apiResponse := gateway.PerformRequest(request)
+
+	assert.True(t, apiResponse.IsSuccessful())
+	assert.Equal(t, refresher.calls, 0)
+}
+
+func TestConcurrentRequestsOnlyRefreshTheTokenOnce(t *testing.T) {
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	refresher := &countingTokenRefresher{token: "bearer new-access-token"}
+	gateway := NewCloudControllerGateway()
+	gateway.SetTokenRefresher(refresher)
+
+	staleToken := expiringAccessToken(t, time.Second)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request, _ := gateway.NewRequest("GET", apiServer.URL+"/v2/foo", staleToken, nil)
+			apiResponse := gateway.PerformRequest(request)
+			assert.True(t, apiResponse.IsSuccessful())
+			assert.Equal(t, request.HttpReq.Header.Get("Authorization"), "bearer new-access-token")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, refresher.calls, 1)
+}
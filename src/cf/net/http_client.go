@@ -87,10 +87,22 @@ func dumpRequest(req *http.Request) {
 }
 
 func dumpResponse(res *http.Response) {
-	dumpedResponse, err := httputil.DumpResponse(res, true)
+	// binary bodies such as droplets are streamed to disk and never held in memory
+	shouldDisplayBody := !isBinaryContentType(res.Header.Get("Content-Type"))
+	dumpedResponse, err := httputil.DumpResponse(res, shouldDisplayBody)
 	if err != nil {
 		trace.Logger.Printf("Error dumping response\n%s\n", err)
 	} else {
 		trace.Logger.Printf("\n%s\n%s\n", terminal.HeaderColor("RESPONSE:"), Sanitize(string(dumpedResponse)))
+		if !shouldDisplayBody {
+			trace.Logger.Println("[BINARY CONTENT HIDDEN]")
+		}
 	}
 }
+
+func isBinaryContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/octet-stream") ||
+		strings.HasPrefix(contentType, "application/zip") ||
+		strings.HasPrefix(contentType, "application/x-gzip") ||
+		strings.HasPrefix(contentType, "application/x-tar")
+}
@@ -0,0 +1,39 @@
+package cf
+
+import "sync"
+
+// MAX_PARALLEL_REQUESTS bounds how many independent API requests a single
+// command keeps in flight.
+const MAX_PARALLEL_REQUESTS = 4
+
+// RunInParallel calls task once for every index below count, using at most
+// maxWorkers goroutines, and returns when every task has finished. Tasks should
+// store their results by index so callers can present them in a fixed order.
+func RunInParallel(count, maxWorkers int, task func(index int)) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	if maxWorkers > count {
+		maxWorkers = count
+	}
+
+	indexes := make(chan int)
+	wg := new(sync.WaitGroup)
+	wg.Add(maxWorkers)
+
+	for i := 0; i < maxWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				task(index)
+			}
+		}()
+	}
+
+	for index := 0; index < count; index++ {
+		indexes <- index
+	}
+	close(indexes)
+
+	wg.Wait()
+}
@@ -0,0 +1,49 @@
+package cf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunInParallelRunsEveryTask(t *testing.T) {
+	results := make([]int, 10)
+
+	RunInParallel(len(results), 3, func(index int) {
+		results[index] = index * index
+	})
+
+	assert.Equal(t, results, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81})
+}
+
+func TestRunInParallelBoundsTheNumberOfWorkers(t *testing.T) {
+	mutex := new(sync.Mutex)
+	running, maxRunning := 0, 0
+
+	RunInParallel(12, 3, func(index int) {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+	})
+
+	assert.True(t, maxRunning > 1)
+	assert.True(t, maxRunning <= 3)
+}
+
+func TestRunInParallelWithNoTasks(t *testing.T) {
+	called := false
+	RunInParallel(0, 3, func(index int) {
+		called = true
+	})
+	assert.False(t, called)
+}
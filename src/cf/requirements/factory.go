@@ -22,6 +22,7 @@ type Factory interface {
 	NewDomainRequirement(name string) DomainRequirement
 	NewUserRequirement(username string) UserRequirement
 	NewBuildpackRequirement(buildpack string) BuildpackRequirement
+	NewScopeRequirement(scope string) Requirement
 }
 
 type apiRequirementFactory struct {
@@ -116,3 +117,11 @@ func (f apiRequirementFactory) NewBuildpackRequirement(buildpack string) Buildpa
 		f.repoLocator.GetBuildpackRepository(),
 	)
 }
+
+func (f apiRequirementFactory) NewScopeRequirement(scope string) Requirement {
+	return newScopeRequirement(
+		scope,
+		f.ui,
+		f.config,
+	)
+}
@@ -0,0 +1,26 @@
+package requirements
+
+import (
+	"cf/configuration"
+	"cf/terminal"
+)
+
+type ScopeRequirement struct {
+	scope  string
+	ui     terminal.UI
+	config *configuration.Configuration
+}
+
+func newScopeRequirement(scope string, ui terminal.UI, config *configuration.Configuration) ScopeRequirement {
+	return ScopeRequirement{scope, ui, config}
+}
+
+func (req ScopeRequirement) Execute() (success bool) {
+	if !req.config.HasScope(req.scope) {
+		req.ui.Failed("You are not authorized to perform this action.\nThis command requires the %s scope, which your current token does not have.",
+			terminal.EntityNameColor(req.scope),
+		)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package requirements
+
+import (
+	"cf/configuration"
+	"github.com/stretchr/testify/assert"
+	testassert "testhelpers/assert"
+	testconfig "testhelpers/configuration"
+	testterm "testhelpers/terminal"
+	"testing"
+)
+
+func TestScopeRequirement(t *testing.T) {
+	accessToken, err := testconfig.CreateAccessTokenWithTokenInfo(configuration.TokenInfo{
+		Scopes: []string{"cloud_controller.read", "cloud_controller.admin"},
+	})
+	assert.NoError(t, err)
+
+	ui := new(testterm.FakeUI)
+	config := &configuration.Configuration{AccessToken: accessToken}
+
+	req := newScopeRequirement("cloud_controller.admin", ui, config)
+	assert.True(t, req.Execute())
+	assert.Empty(t, ui.Outputs)
+
+	req = newScopeRequirement("scim.write", ui, config)
+	assert.False(t, req.Execute())
+
+	testassert.SliceContains(t, ui.Outputs, testassert.Lines{
+		{"FAILED"},
+		{"not authorized"},
+		{"scim.write"},
+	})
+}
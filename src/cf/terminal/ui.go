@@ -116,6 +116,8 @@ func (ui terminalUI) ShowConfiguration(config *configuration.Configuration) {
 
 	if !config.IsLoggedIn() {
 		ui.Say(NotLoggedInText())
+	} else if config.HasClientCredentials() {
+		ui.Say("Client:       %s", EntityNameColor(config.ClientId))
 	} else {
 		ui.Say("User:         %s", EntityNameColor(config.UserEmail()))
 	}
@@ -1,5 +1,7 @@
 package cf
 
+const ADMIN_SCOPE = "cloud_controller.admin"
+
 const (
 	ORG_MANAGER     = "OrgManager"
 	BILLING_MANAGER = "BillingManager"
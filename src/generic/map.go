@@ -105,6 +105,12 @@ func NewMap(data ...interface {}) Map {
 			stringMap.Set(key, val)
 		}
 		return stringMap
+	case map[string]interface{}:
+		stringMap := newEmptyMap()
+		for key, val := range data {
+			stringMap.Set(key, val)
+		}
+		return stringMap
 	case map[interface {}]interface{}:
 		mapp := ConcreteMap(data)
 		return &mapp
@@ -2,15 +2,36 @@ package api
 
 import (
 	"cf/net"
+	"io"
+	"sync"
 )
 
 type FakeApplicationBitsRepository struct {
 	UploadedAppGuid string
 	UploadedDir string
 	UploadAppErr bool
+
+	CopyBitsSourceAppGuid string
+	CopyBitsTargetAppGuid string
+	CopyBitsErr bool
+
+	DownloadedDropletAppGuid string
+	DownloadedBitsAppGuid string
+	DownloadContent string
+	DownloadSha1 string
+	DownloadErr bool
+
+	UploadedDropletAppGuid string
+	UploadedDropletPath string
+	UploadDropletErr bool
+
+	mutex sync.Mutex
 }
 
 func (repo *FakeApplicationBitsRepository) UploadApp(appGuid, dir string) (apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.UploadedDir = dir
 	repo.UploadedAppGuid = appGuid
 
@@ -20,3 +41,54 @@ func (repo *FakeApplicationBitsRepository) UploadApp(appGuid, dir string) (apiRe
 
 	return
 }
+
+func (repo *FakeApplicationBitsRepository) CopyBits(sourceAppGuid, targetAppGuid string) (apiResponse net.ApiResponse) {
+	repo.CopyBitsSourceAppGuid = sourceAppGuid
+	repo.CopyBitsTargetAppGuid = targetAppGuid
+
+	if repo.CopyBitsErr {
+		apiResponse = net.NewApiResponseWithMessage("Error copying bits")
+	}
+
+	return
+}
+
+func (repo *FakeApplicationBitsRepository) DownloadDroplet(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	repo.DownloadedDropletAppGuid = appGuid
+	return repo.download(destination, progress)
+}
+
+func (repo *FakeApplicationBitsRepository) DownloadBits(appGuid string, destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	repo.DownloadedBitsAppGuid = appGuid
+	return repo.download(destination, progress)
+}
+
+func (repo *FakeApplicationBitsRepository) download(destination io.Writer, progress func(downloaded, total int64)) (sha1Sum string, apiResponse net.ApiResponse) {
+	if repo.DownloadErr {
+		apiResponse = net.NewApiResponseWithMessage("Error downloading")
+		return
+	}
+
+	total := int64(len(repo.DownloadContent))
+	half := len(repo.DownloadContent) / 2
+	written := int64(0)
+	for _, chunk := range []string{repo.DownloadContent[:half], repo.DownloadContent[half:]} {
+		io.WriteString(destination, chunk)
+		written += int64(len(chunk))
+		progress(written, total)
+	}
+
+	sha1Sum = repo.DownloadSha1
+	return
+}
+
+func (repo *FakeApplicationBitsRepository) UploadDroplet(appGuid, dropletPath string) (apiResponse net.ApiResponse) {
+	repo.UploadedDropletAppGuid = appGuid
+	repo.UploadedDropletPath = dropletPath
+
+	if repo.UploadDropletErr {
+		apiResponse = net.NewApiResponseWithMessage("Error uploading droplet")
+	}
+
+	return
+}
@@ -11,19 +11,13 @@ type FakeAppEventsRepo struct{
 }
 
 
-func (repo FakeAppEventsRepo)ListEvents(appGuid string) (events chan []cf.EventFields, statusChan chan net.ApiResponse) {
+func (repo FakeAppEventsRepo) ListEvents(appGuid string, cb func([]cf.EventFields) bool) (apiResponse net.ApiResponse) {
 	repo.AppGuid = appGuid
 
-	events = make(chan []cf.EventFields, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		for _, event := range repo.Events {
-			events <- []cf.EventFields{event}
+	for _, event := range repo.Events {
+		if !cb([]cf.EventFields{event}) {
+			break
 		}
-		close(events)
-		close(statusChan)
-	}()
-
+	}
 	return
 }
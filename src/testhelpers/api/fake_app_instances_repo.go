@@ -11,6 +11,10 @@ type FakeAppInstancesRepo struct{
 	GetInstancesAppGuid    string
 	GetInstancesResponses  [][]cf.AppInstanceFields
 	GetInstancesErrorCodes []string
+
+	DeleteInstanceAppGuid string
+	DeleteInstanceIndex   int
+	DeleteInstanceErr     bool
 }
 
 func (repo *FakeAppInstancesRepo) GetInstances(appGuid string) (instances[]cf.AppInstanceFields, apiResponse net.ApiResponse) {
@@ -32,3 +36,12 @@ func (repo *FakeAppInstancesRepo) GetInstances(appGuid string) (instances[]cf.Ap
 
 	return
 }
+
+func (repo *FakeAppInstancesRepo) DeleteInstance(appGuid string, index int) (apiResponse net.ApiResponse) {
+	repo.DeleteInstanceAppGuid = appGuid
+	repo.DeleteInstanceIndex = index
+	if repo.DeleteInstanceErr {
+		apiResponse = net.NewApiResponseWithMessage("Error stopping instance.")
+	}
+	return
+}
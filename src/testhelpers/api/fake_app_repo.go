@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/net"
 	"generic"
+	"sync"
 )
 
 type FakeApplicationRepository struct {
@@ -11,10 +12,13 @@ type FakeApplicationRepository struct {
 
 	ReadName      string
 	ReadApp       cf.Application
+	ReadAppResponses []cf.Application
 	ReadErr       bool
 	ReadAuthErr   bool
 	ReadNotFound  bool
 
+	ReadFromSpaceGuid string
+
 	CreateAppParams    []cf.AppParams
 
 	UpdateParams    cf.AppParams
@@ -22,12 +26,25 @@ type FakeApplicationRepository struct {
 	UpdateAppResult cf.Application
 	UpdateErr       bool
 
+	RestageAppGuid   string
+	RestageAppResult cf.Application
+	RestageErr       bool
+
 	DeletedAppGuid string
+
+	mutex sync.Mutex
 }
 
 func (repo *FakeApplicationRepository) Read(name string) (app cf.Application, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.ReadName = name
 	app = repo.ReadApp
+	if len(repo.ReadAppResponses) > 0 {
+		app = repo.ReadAppResponses[0]
+		repo.ReadAppResponses = repo.ReadAppResponses[1:]
+	}
 
 	if repo.ReadErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding app by name.")
@@ -42,6 +59,14 @@ func (repo *FakeApplicationRepository) Read(name string) (app cf.Application, ap
 	return
 }
 
+func (repo *FakeApplicationRepository) ReadFromSpace(name, spaceGuid string) (app cf.Application, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	repo.ReadFromSpaceGuid = spaceGuid
+	repo.mutex.Unlock()
+
+	return repo.Read(name)
+}
+
 func (repo *FakeApplicationRepository) CreatedAppParams() (params cf.AppParams) {
 	if (len(repo.CreateAppParams) > 0) {
 		params = repo.CreateAppParams[0]
@@ -50,6 +75,9 @@ func (repo *FakeApplicationRepository) CreatedAppParams() (params cf.AppParams)
 }
 
 func (repo *FakeApplicationRepository) Create(params cf.AppParams) (resultApp cf.Application, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	if repo.CreateAppParams == nil {
 		repo.CreateAppParams = []cf.AppParams{}
 	}
@@ -59,7 +87,7 @@ func (repo *FakeApplicationRepository) Create(params cf.AppParams) (resultApp cf
 	resultApp.Guid = params.Get("name").(string) + "-guid"
 	resultApp.Name = params.Get("name").(string)
 	resultApp.State = "stopped"
-	resultApp.EnvironmentVars = map[string]string{}
+	resultApp.EnvironmentVars = map[string]interface{}{}
 
 	if params.NotNil("space_guid") {
 		resultApp.SpaceGuid = params.Get("space_guid").(string)
@@ -83,13 +111,16 @@ func (repo *FakeApplicationRepository) Create(params cf.AppParams) (resultApp cf
 	if params.NotNil("env") {
 		envVars := params.Get("env").(generic.Map)
 		generic.Each(envVars,func(key,val interface {}){
-			resultApp.EnvironmentVars[key.(string)] = val.(string)
+			resultApp.EnvironmentVars[key.(string)] = val
 		})
 	}
 	return
 }
 
 func (repo *FakeApplicationRepository) Update(appGuid string, params cf.AppParams) (updatedApp cf.Application, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.UpdateAppGuid = appGuid
 	repo.UpdateParams = params
 	updatedApp = repo.UpdateAppResult
@@ -99,7 +130,22 @@ func (repo *FakeApplicationRepository) Update(appGuid string, params cf.AppParam
 	return
 }
 
+func (repo *FakeApplicationRepository) Restage(appGuid string) (restagedApp cf.Application, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.RestageAppGuid = appGuid
+	restagedApp = repo.RestageAppResult
+	if repo.RestageErr {
+		apiResponse = net.NewApiResponseWithMessage("Error restaging app.")
+	}
+	return
+}
+
 func (repo *FakeApplicationRepository) Delete(appGuid string) (apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.DeletedAppGuid = appGuid
 	return
 }
@@ -8,6 +8,7 @@ import (
 
 type FakeAppSummaryRepo struct{
 	GetSummariesInCurrentSpaceApps []cf.AppSummary
+	GetSummariesInSpaceGuid string
 
 	GetSummaryErrorCode string
 	GetSummaryAppGuid string
@@ -19,6 +20,12 @@ func (repo *FakeAppSummaryRepo)GetSummariesInCurrentSpace() (apps []cf.AppSummar
 	return
 }
 
+func (repo *FakeAppSummaryRepo)GetSummariesInSpace(spaceGuid string) (apps []cf.AppSummary, apiResponse net.ApiResponse) {
+	repo.GetSummariesInSpaceGuid = spaceGuid
+	apps = repo.GetSummariesInCurrentSpaceApps
+	return
+}
+
 func (repo *FakeAppSummaryRepo)GetSummary(appGuid string) (summary cf.AppSummary, apiResponse net.ApiResponse) {
 	repo.GetSummaryAppGuid= appGuid
 	summary = repo.GetSummarySummary
@@ -12,16 +12,41 @@ type FakeAuthenticationRepository struct {
 	Config *configuration.Configuration
 	Email string
 	Password string
+	Passcode string
+	ClientId string
+	ClientSecret string
 
 	AuthError bool
 	AccessToken string
 	RefreshToken string
+	RefreshTokenCalled bool
 }
 
 func (auth *FakeAuthenticationRepository) Authenticate(email string, password string) (apiResponse net.ApiResponse) {
-	auth.Config, _ = auth.ConfigRepo.Get()
 	auth.Email = email
 	auth.Password = password
+	return auth.saveTokens()
+}
+
+func (auth *FakeAuthenticationRepository) AuthenticateWithPasscode(passcode string) (apiResponse net.ApiResponse) {
+	auth.Passcode = passcode
+	return auth.saveTokens()
+}
+
+func (auth *FakeAuthenticationRepository) AuthenticateWithClientCredentials(clientId, clientSecret string) (apiResponse net.ApiResponse) {
+	auth.ClientId = clientId
+	auth.ClientSecret = clientSecret
+	apiResponse = auth.saveTokens()
+	if apiResponse.IsSuccessful() {
+		auth.Config.ClientId = clientId
+		auth.Config.ClientSecret = clientSecret
+		auth.ConfigRepo.Save()
+	}
+	return
+}
+
+func (auth *FakeAuthenticationRepository) saveTokens() (apiResponse net.ApiResponse) {
+	auth.Config, _ = auth.ConfigRepo.Get()
 
 	if auth.AuthError {
 		apiResponse =  net.NewApiResponseWithMessage("Error authenticating.")
@@ -32,13 +57,19 @@ func (auth *FakeAuthenticationRepository) Authenticate(email string, password st
 		auth.AccessToken = "BEARER some_access_token"
 	}
 
-	auth.Config.AccessToken = auth.AccessToken
-	auth.Config.RefreshToken = auth.RefreshToken
+	auth.Config.SetTokens(auth.AccessToken, auth.RefreshToken)
 	auth.ConfigRepo.Save()
-
 	return
 }
 
 func (auth *FakeAuthenticationRepository) RefreshAuthToken() (updatedToken string, apiResponse net.ApiResponse) {
+	auth.RefreshTokenCalled = true
+
+	if auth.AuthError {
+		apiResponse =  net.NewApiResponseWithMessage("Error refreshing token.")
+		return
+	}
+
+	updatedToken = auth.AccessToken
 	return
 }
@@ -23,31 +23,17 @@ type FakeBuildpackRepository struct {
 	UpdateBuildpack cf.Buildpack
 }
 
-func (repo *FakeBuildpackRepository) ListBuildpacks(stop chan bool) (buildpacksChan chan []cf.Buildpack, statusChan chan net.ApiResponse) {
-	buildpacksChan = make(chan []cf.Buildpack, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		buildpackCount := len(repo.Buildpacks)
-		for i:= 0; i < buildpackCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if buildpackCount - i > 1 {
-					buildpacksChan <- repo.Buildpacks[i:i+2]
-				} else {
-					buildpacksChan <- repo.Buildpacks[i:]
-				}
-			}
+func (repo *FakeBuildpackRepository) ListBuildpacks(cb func([]cf.Buildpack) bool) (apiResponse net.ApiResponse) {
+	count := len(repo.Buildpacks)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
-
-		close(buildpacksChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
-
+		if !cb(repo.Buildpacks[i:end]) {
+			break
+		}
+	}
 	return
 }
 
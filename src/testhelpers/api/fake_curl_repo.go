@@ -0,0 +1,46 @@
+package api
+
+import (
+	"cf/net"
+)
+
+type FakeCurlRepository struct {
+	Method  string
+	Path    string
+	Headers []string
+	Body    string
+
+	RequestedPaths []string
+
+	ResponseHeader string
+	ResponseBody   string
+	ResponseBodies []string
+	ResponseStatus int
+	Error          bool
+}
+
+func (repo *FakeCurlRepository) Request(method, path string, headers []string, body string) (resHeaders, resBody string, apiResponse net.ApiResponse) {
+	repo.Method = method
+	repo.Path = path
+	repo.Headers = headers
+	repo.Body = body
+	repo.RequestedPaths = append(repo.RequestedPaths, path)
+
+	if repo.Error {
+		apiResponse = net.NewApiResponseWithMessage("Error performing request: connection refused")
+		return
+	}
+
+	apiResponse = net.NewApiResponseWithStatusCode(200)
+	if repo.ResponseStatus != 0 {
+		apiResponse = net.NewApiResponseWithStatusCode(repo.ResponseStatus)
+	}
+
+	resHeaders = repo.ResponseHeader
+	resBody = repo.ResponseBody
+	if len(repo.ResponseBodies) > 0 {
+		resBody = repo.ResponseBodies[0]
+		repo.ResponseBodies = repo.ResponseBodies[1:]
+	}
+	return
+}
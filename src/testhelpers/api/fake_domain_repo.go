@@ -3,6 +3,7 @@ package api
 import (
 	"cf"
 	"cf/net"
+	"sync"
 )
 
 type FakeDomainRepository struct {
@@ -15,6 +16,8 @@ type FakeDomainRepository struct {
 	FindByNameInOrgApiResponse net.ApiResponse
 
 	FindByNameInCurrentSpaceName string
+	// when set, only these domains are found in the current space
+	FindByNameInCurrentSpaceDomains []cf.Domain
 
 	FindByNameName string
 	FindByNameDomain cf.Domain
@@ -23,6 +26,7 @@ type FakeDomainRepository struct {
 
 	CreateDomainName string
 	CreateDomainOwningOrgGuid string
+	CreatedDomains []string
 
 	CreateSharedDomainName string
 
@@ -36,35 +40,26 @@ type FakeDomainRepository struct {
 
 	DeleteDomainGuid string
 	DeleteApiResponse net.ApiResponse
+
+	mutex sync.Mutex
 }
 
-func (repo *FakeDomainRepository) ListDomainsForOrg(orgGuid string, stop chan bool) (domainsChan chan []cf.Domain, statusChan chan net.ApiResponse){
+func (repo *FakeDomainRepository) ListDomainsForOrg(orgGuid string, cb func([]cf.Domain) bool) (apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.ListDomainsForOrgDomainsGuid = orgGuid
 
-	domainsChan = make(chan []cf.Domain, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		domainsCount := len(repo.ListDomainsForOrgDomains)
-		for i:= 0; i < domainsCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if domainsCount - i > 1 {
-					domainsChan <- repo.ListDomainsForOrgDomains[i:i+2]
-				} else {
-					domainsChan <- repo.ListDomainsForOrgDomains[i:]
-				}
-			}
+	count := len(repo.ListDomainsForOrgDomains)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
-
-		close(domainsChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
-
+		if !cb(repo.ListDomainsForOrgDomains[i:end]) {
+			break
+		}
+	}
 	return
 }
 
@@ -84,9 +79,24 @@ func (repo *FakeDomainRepository) FindByName(name string) (domain cf.Domain, api
 
 
 func (repo *FakeDomainRepository) FindByNameInCurrentSpace(name string) (domain cf.Domain, apiResponse net.ApiResponse){
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.FindByNameInCurrentSpaceName = name
 	domain = repo.FindByNameDomain
 
+	if repo.FindByNameInCurrentSpaceDomains != nil {
+		for _, spaceDomain := range repo.FindByNameInCurrentSpaceDomains {
+			if spaceDomain.Name == name {
+				domain = spaceDomain
+				return
+			}
+		}
+		domain = cf.Domain{}
+		apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Domain", name)
+		return
+	}
+
 	if repo.FindByNameNotFound {
 		apiResponse = net.NewNotFoundApiResponse("%s %s not found","Domain", name)
 	}
@@ -106,6 +116,7 @@ func (repo *FakeDomainRepository) FindByNameInOrg(name string, owningOrgGuid str
 func (repo *FakeDomainRepository) Create(domainName string, owningOrgGuid string) (createdDomain cf.DomainFields, apiResponse net.ApiResponse){
 	repo.CreateDomainName = domainName
 	repo.CreateDomainOwningOrgGuid = owningOrgGuid
+	repo.CreatedDomains = append(repo.CreatedDomains, domainName+" "+owningOrgGuid)
 	return
 }
 
@@ -12,13 +12,15 @@ type FakeEndpointRepo struct {
 	Config *configuration.Configuration
 
 	UpdateEndpointEndpoint string
+	UpdateEndpointAllowInsecureHttp bool
 	UpdateEndpointError bool
 
 	GetEndpointEndpoints map[cf.EndpointType]string
 }
 
-func (repo *FakeEndpointRepo) UpdateEndpoint(endpoint string) (finalEndpoint string, apiResponse net.ApiResponse) {
+func (repo *FakeEndpointRepo) UpdateEndpoint(endpoint string, allowInsecureHttp bool) (finalEndpoint string, apiResponse net.ApiResponse) {
 	repo.UpdateEndpointEndpoint = endpoint
+	repo.UpdateEndpointAllowInsecureHttp = allowInsecureHttp
 
 	if repo.UpdateEndpointError {
 		apiResponse = net.NewApiResponseWithMessage("Server error")
@@ -7,6 +7,7 @@ import (
 
 type FakeOrgRepository struct {
 	Organizations []cf.Organization
+	OrganizationsByName map[string]cf.Organization
 
 	CreateName      string
 	CreateOrgExists bool
@@ -20,33 +21,23 @@ type FakeOrgRepository struct {
 	RenameNewName      string
 
 	DeletedOrganizationGuid string
+
+	MemoryUsageOrgGuid string
+	MemoryUsage        uint64
+	MemoryUsageErr     bool
 }
 
-func (repo FakeOrgRepository) ListOrgs(stop chan bool) (orgsChan chan []cf.Organization, statusChan chan net.ApiResponse) {
-	orgsChan = make(chan []cf.Organization, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		orgsCount := len(repo.Organizations)
-		for i:= 0; i < orgsCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if orgsCount - i > 1 {
-					orgsChan <- repo.Organizations[i:i+2]
-				} else {
-					orgsChan <- repo.Organizations[i:]
-				}
-			}
+func (repo FakeOrgRepository) ListOrgs(cb func([]cf.Organization) bool) (apiResponse net.ApiResponse) {
+	count := len(repo.Organizations)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
-
-		close(orgsChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
-
+		if !cb(repo.Organizations[i:end]) {
+			break
+		}
+	}
 	return
 }
 
@@ -54,6 +45,15 @@ func (repo *FakeOrgRepository) FindByName(name string) (org cf.Organization, api
 	repo.FindByNameName = name
 	org = repo.FindByNameOrganization
 
+	if repo.OrganizationsByName != nil {
+		var found bool
+		org, found = repo.OrganizationsByName[name]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("%s %s not found", "Org", name)
+		}
+		return
+	}
+
 	if repo.FindByNameErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding organization by name.")
 	}
@@ -71,6 +71,12 @@ func (repo *FakeOrgRepository) Create(name string) (apiResponse net.ApiResponse)
 		return
 	}
 	repo.CreateName = name
+	if repo.OrganizationsByName != nil {
+		org := cf.Organization{}
+		org.Name = name
+		org.Guid = name + "-guid"
+		repo.OrganizationsByName[name] = org
+	}
 	return
 }
 
@@ -84,3 +90,12 @@ func (repo *FakeOrgRepository) Delete(orgGuid string) (apiResponse net.ApiRespon
 	repo.DeletedOrganizationGuid = orgGuid
 	return
 }
+
+func (repo *FakeOrgRepository) GetMemoryUsage(orgGuid string) (usage uint64, apiResponse net.ApiResponse) {
+	repo.MemoryUsageOrgGuid = orgGuid
+	usage = repo.MemoryUsage
+	if repo.MemoryUsageErr {
+		apiResponse = net.NewApiResponseWithMessage("Error getting memory usage.")
+	}
+	return
+}
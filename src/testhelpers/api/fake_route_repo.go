@@ -3,6 +3,7 @@ package api
 import (
 	"cf"
 	"cf/net"
+	"sync"
 )
 
 type FakeRouteRepository struct {
@@ -15,9 +16,17 @@ type FakeRouteRepository struct {
 	FindByHostAndDomainRoute    cf.Route
 	FindByHostAndDomainErr      bool
 	FindByHostAndDomainNotFound bool
+	FindByHostAndDomainPath     string
+
+	// the first FindByHostAndDomainTakenCount lookups find a route, whatever
+	// the other settings; every host looked up is kept in order
+	FindByHostAndDomainTakenCount int
+	FindByHostAndDomainHosts      []string
 
 	CreatedHost       string
 	CreatedDomainGuid string
+	CreatedPath       string
+	CreatedHosts      []string
 
 	CreateInSpaceHost string
 	CreateInSpaceDomainGuid string
@@ -25,51 +34,37 @@ type FakeRouteRepository struct {
 	CreateInSpaceCreatedRoute cf.Route
 	CreateInSpaceErr bool
 
-	BoundRouteGuid string
-	BoundAppGuid   string
+	BoundRouteGuid  string
+	BoundAppGuid    string
+	BoundRouteGuids []string
 
-	UnboundRouteGuid string
-	UnboundAppGuid   string
+	UnboundRouteGuid  string
+	UnboundAppGuid    string
+	UnboundRouteGuids []string
 
 	ListErr    bool
 	Routes []cf.Route
 
 	DeleteRouteGuid string
-}
 
-func (repo *FakeRouteRepository) ListRoutes(stop chan bool) (routesChan chan []cf.Route, statusChan chan net.ApiResponse) {
-	routesChan = make(chan []cf.Route, 4)
-	statusChan = make(chan net.ApiResponse, 1)
+	mutex sync.Mutex
+}
 
+func (repo *FakeRouteRepository) ListRoutes(cb func([]cf.Route) bool) (apiResponse net.ApiResponse) {
 	if repo.ListErr {
-		statusChan <- net.NewApiResponseWithMessage("Error finding all routes")
-		close(routesChan)
-		close(statusChan)
-		return
+		return net.NewApiResponseWithMessage("Error finding all routes")
 	}
 
-
-	go func() {
-		routesCount := len(repo.Routes)
-		for i:= 0; i < routesCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if routesCount - i > 1 {
-					routesChan <- repo.Routes[i:i+2]
-				} else {
-					routesChan <- repo.Routes[i:]
-				}
-			}
+	count := len(repo.Routes)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
-
-		close(routesChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
-
+		if !cb(repo.Routes[i:end]) {
+			break
+		}
+	}
 	return
 }
 
@@ -85,8 +80,23 @@ func (repo *FakeRouteRepository) FindByHost(host string) (route cf.Route, apiRes
 }
 
 func (repo *FakeRouteRepository) FindByHostAndDomain(host, domain string) (route cf.Route, apiResponse net.ApiResponse) {
+	return repo.FindByHostDomainAndPath(host, domain, "")
+}
+
+func (repo *FakeRouteRepository) FindByHostDomainAndPath(host, domain, path string) (route cf.Route, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.FindByHostAndDomainHost = host
 	repo.FindByHostAndDomainDomain = domain
+	repo.FindByHostAndDomainPath = path
+	repo.FindByHostAndDomainHosts = append(repo.FindByHostAndDomainHosts, host)
+
+	if len(repo.FindByHostAndDomainHosts) <= repo.FindByHostAndDomainTakenCount {
+		route.Guid = host + "-taken-route-guid"
+		route.Host = host
+		return
+	}
 
 	if repo.FindByHostAndDomainErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding Route")
@@ -101,10 +111,21 @@ func (repo *FakeRouteRepository) FindByHostAndDomain(host, domain string) (route
 }
 
 func (repo *FakeRouteRepository) Create(host, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
+	return repo.CreateWithPath(host, "", domainGuid)
+}
+
+func (repo *FakeRouteRepository) CreateWithPath(host, path, domainGuid string) (createdRoute cf.Route, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.CreatedHost = host
 	repo.CreatedDomainGuid = domainGuid
+	repo.CreatedPath = path
+	repo.CreatedHosts = append(repo.CreatedHosts, host)
 
 	createdRoute.Guid = host + "-route-guid"
+	createdRoute.Host = host
+	createdRoute.Path = path
 
 	return
 }
@@ -124,14 +145,22 @@ func (repo *FakeRouteRepository) CreateInSpace(host, domainGuid, spaceGuid strin
 }
 
 func (repo *FakeRouteRepository) Bind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.BoundRouteGuid = routeGuid
 	repo.BoundAppGuid = appGuid
+	repo.BoundRouteGuids = append(repo.BoundRouteGuids, routeGuid)
 	return
 }
 
 func (repo *FakeRouteRepository) Unbind(routeGuid, appGuid string) (apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.UnboundRouteGuid = routeGuid
 	repo.UnboundAppGuid = appGuid
+	repo.UnboundRouteGuids = append(repo.UnboundRouteGuids, routeGuid)
 	return
 }
 
@@ -10,6 +10,7 @@ type FakeServiceBindingRepo struct {
 	CreateServiceInstanceGuid string
 	CreateApplicationGuid string
 	CreateErrorCode string
+	CreatedBindings []string
 
 	DeleteServiceInstance cf.ServiceInstance
 	DeleteApplicationGuid string
@@ -19,6 +20,7 @@ type FakeServiceBindingRepo struct {
 func (repo *FakeServiceBindingRepo) Create(instanceGuid, appGuid string) (apiResponse net.ApiResponse) {
 	repo.CreateServiceInstanceGuid = instanceGuid
 	repo.CreateApplicationGuid = appGuid
+	repo.CreatedBindings = append(repo.CreatedBindings, instanceGuid+" "+appGuid)
 
 	if repo.CreateErrorCode != "" {
 		apiResponse = net.NewApiResponse("Error binding service", repo.CreateErrorCode, http.StatusBadRequest)
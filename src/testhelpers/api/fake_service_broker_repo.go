@@ -35,39 +35,21 @@ func (repo *FakeServiceBrokerRepo) FindByName(name string) (serviceBroker cf.Ser
 	return
 }
 
-func (repo *FakeServiceBrokerRepo) ListServiceBrokers(stop chan bool) (serviceBrokersChan chan []cf.ServiceBroker, statusChan chan net.ApiResponse) {
-	serviceBrokersChan = make(chan []cf.ServiceBroker, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
+func (repo *FakeServiceBrokerRepo) ListServiceBrokers(cb func([]cf.ServiceBroker) bool) (apiResponse net.ApiResponse) {
 	if repo.ListErr {
-		statusChan <- net.NewApiResponseWithMessage("Error finding all routes")
-		close(serviceBrokersChan)
-		close(statusChan)
-		return
+		return net.NewApiResponseWithMessage("Error finding service brokers")
 	}
 
-
-	go func() {
-		serviceBrokersCount := len(repo.ServiceBrokers)
-		for i := 0; i < serviceBrokersCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if serviceBrokersCount - i > 1 {
-					serviceBrokersChan <- repo.ServiceBrokers[i:i + 2]
-				} else {
-					serviceBrokersChan <- repo.ServiceBrokers[i:]
-				}
-			}
+	count := len(repo.ServiceBrokers)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
-
-		close(serviceBrokersChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
-
+		if !cb(repo.ServiceBrokers[i:end]) {
+			break
+		}
+	}
 	return
 }
 
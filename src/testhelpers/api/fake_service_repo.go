@@ -4,6 +4,7 @@ import (
 	"cf"
 	"cf/net"
 	"generic"
+	"sync"
 )
 
 type FakeServiceRepo struct {
@@ -11,6 +12,9 @@ type FakeServiceRepo struct {
 
 	CreateServiceInstanceName string
 	CreateServiceInstancePlanGuid string
+	CreateServiceInstanceParams map[string]interface{}
+	CreateServiceInstanceNames []string
+	CreateServiceInstanceAcceptsIncomplete bool
 	CreateServiceAlreadyExists bool
 
 	FindInstanceByNameName string
@@ -19,35 +23,63 @@ type FakeServiceRepo struct {
 	FindInstanceByNameNotFound bool
 
 	FindInstanceByNameMap generic.Map
+	FindInstanceByNameLastOperationStates []string
 
 	DeleteServiceServiceInstance cf.ServiceInstance
 
 	RenameServiceServiceInstance cf.ServiceInstance
 	RenameServiceNewName string
+
+	mutex sync.Mutex
 }
 
 func (repo *FakeServiceRepo) GetServiceOfferings() (offerings cf.ServiceOfferings, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	offerings = repo.ServiceOfferings
 	return
 }
 
-func (repo *FakeServiceRepo) CreateServiceInstance(name, planGuid string) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
+func (repo *FakeServiceRepo) CreateServiceInstance(name, planGuid string, params map[string]interface{}, acceptsIncomplete bool) (identicalAlreadyExists bool, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.CreateServiceInstanceName = name
 	repo.CreateServiceInstancePlanGuid = planGuid
+	repo.CreateServiceInstanceParams = params
+	repo.CreateServiceInstanceAcceptsIncomplete = acceptsIncomplete
+	repo.CreateServiceInstanceNames = append(repo.CreateServiceInstanceNames, name)
 	identicalAlreadyExists = repo.CreateServiceAlreadyExists
 
+	if repo.FindInstanceByNameMap != nil {
+		instance := cf.ServiceInstance{}
+		instance.Name = name
+		instance.Guid = name + "-guid"
+		instance.ServicePlan.Guid = planGuid
+		repo.FindInstanceByNameMap.Set(name, instance)
+	}
+
 	return
 }
 
 func (repo *FakeServiceRepo) FindInstanceByName(name string) (instance cf.ServiceInstance, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.FindInstanceByNameName = name
 
 	if repo.FindInstanceByNameMap != nil && repo.FindInstanceByNameMap.Has(name) {
 		instance = repo.FindInstanceByNameMap.Get(name).(cf.ServiceInstance)
-	} else {
-		instance = repo.FindInstanceByNameServiceInstance
+		if len(repo.FindInstanceByNameLastOperationStates) > 0 {
+			instance.LastOperation.State = repo.FindInstanceByNameLastOperationStates[0]
+			repo.FindInstanceByNameLastOperationStates = repo.FindInstanceByNameLastOperationStates[1:]
+		}
+		return
 	}
 
+	instance = repo.FindInstanceByNameServiceInstance
+
 	if repo.FindInstanceByNameErr {
 		apiResponse = net.NewApiResponseWithMessage("Error finding instance")
 	}
@@ -7,9 +7,16 @@ import (
 
 type FakeServiceSummaryRepo struct{
 	GetSummariesInCurrentSpaceInstances []cf.ServiceInstance
+	GetSummariesInSpaceGuid string
 }
 
 func (repo *FakeServiceSummaryRepo)GetSummariesInCurrentSpace() (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
 	instances = repo.GetSummariesInCurrentSpaceInstances
 	return
 }
+
+func (repo *FakeServiceSummaryRepo)GetSummariesInSpace(spaceGuid string) (instances []cf.ServiceInstance, apiResponse net.ApiResponse) {
+	repo.GetSummariesInSpaceGuid = spaceGuid
+	instances = repo.GetSummariesInCurrentSpaceInstances
+	return
+}
@@ -9,6 +9,8 @@ type FakeSpaceRepository struct {
 	CurrentSpace cf.Space
 
 	Spaces []cf.Space
+	SpacesByOrgGuid map[string][]cf.Space
+	ListSpacesInOrgErr bool
 
 	FindByNameName string
 	FindByNameSpace cf.Space
@@ -25,6 +27,7 @@ type FakeSpaceRepository struct {
 	CreateSpaceOrgGuid string
 	CreateSpaceExists bool
 	CreateSpaceSpace cf.Space
+	CreatedSpaces []string
 
 	RenameSpaceGuid string
 	RenameNewName string
@@ -36,31 +39,29 @@ func (repo FakeSpaceRepository) GetCurrentSpace() (space cf.Space) {
 	return repo.CurrentSpace
 }
 
-func (repo FakeSpaceRepository) ListSpaces(stop chan bool) (spacesChan chan []cf.Space, statusChan chan net.ApiResponse) {
-	spacesChan = make(chan []cf.Space, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		spacesCount := len(repo.Spaces)
-		for i:= 0; i < spacesCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if spacesCount - i > 1 {
-					spacesChan <- repo.Spaces[i:i+2]
-				} else {
-					spacesChan <- repo.Spaces[i:]
-				}
-			}
+func (repo FakeSpaceRepository) ListSpaces(cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	count := len(repo.Spaces)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
 		}
+		if !cb(repo.Spaces[i:end]) {
+			break
+		}
+	}
+	return
+}
 
-		close(spacesChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
-	}()
+func (repo FakeSpaceRepository) ListSpacesInOrg(orgGuid string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	if repo.ListSpacesInOrgErr {
+		return net.NewApiResponseWithMessage("Error listing spaces")
+	}
 
+	spaces := repo.SpacesByOrgGuid[orgGuid]
+	if len(spaces) > 0 {
+		cb(spaces)
+	}
 	return
 }
 
@@ -99,7 +100,12 @@ func (repo *FakeSpaceRepository) Create(name string, orgGuid string) (space cf.S
 	}
 	repo.CreateSpaceName = name
 	repo.CreateSpaceOrgGuid = orgGuid
+	repo.CreatedSpaces = append(repo.CreatedSpaces, name+" "+orgGuid)
 	space = repo.CreateSpaceSpace
+	if space.Guid == "" {
+		space.Name = name
+		space.Guid = name + "-guid"
+	}
 	return
 }
 
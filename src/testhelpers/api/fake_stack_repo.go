@@ -3,6 +3,7 @@ package api
 import (
 	"cf"
 	"cf/net"
+	"sync"
 )
 
 type FakeStackRepository struct {
@@ -10,9 +11,14 @@ type FakeStackRepository struct {
 	FindByNameName string
 
 	FindAllStacks []cf.Stack
+
+	mutex sync.Mutex
 }
 
 func (repo *FakeStackRepository) FindByName(name string) (stack cf.Stack, apiResponse net.ApiResponse) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
 	repo.FindByNameName = name
 	stack = repo.FindByNameStack
 
@@ -8,12 +8,14 @@ import (
 type FakeUserProvidedServiceInstanceRepo struct {
 	CreateName string
 	CreateDrainUrl string
-	CreateParams map[string]string
+	CreateParams map[string]interface{}
 
 	UpdateServiceInstance cf.ServiceInstanceFields
+
+	FindByGuidInstances map[string]cf.ServiceInstanceFields
 }
 
-func (repo *FakeUserProvidedServiceInstanceRepo) Create(name, drainUrl string, params map[string]string) (apiResponse net.ApiResponse) {
+func (repo *FakeUserProvidedServiceInstanceRepo) Create(name, drainUrl string, params map[string]interface{}) (apiResponse net.ApiResponse) {
 	repo.CreateName = name
 	repo.CreateDrainUrl = drainUrl
 	repo.CreateParams = params
@@ -24,3 +26,11 @@ func (repo *FakeUserProvidedServiceInstanceRepo) Update(serviceInstance cf.Servi
 	repo.UpdateServiceInstance = serviceInstance
 	return
 }
+
+func (repo *FakeUserProvidedServiceInstanceRepo) FindByGuid(guid string) (serviceInstance cf.ServiceInstanceFields, apiResponse net.ApiResponse) {
+	serviceInstance, found := repo.FindByGuidInstances[guid]
+	if !found {
+		apiResponse = net.NewNotFoundApiResponse("Service instance %s not found", guid)
+	}
+	return
+}
@@ -3,45 +3,79 @@ package api
 import (
 	"cf/net"
 	"cf"
+	"sync"
+	"time"
 )
 
 type FakeUserRepository struct {
 	FindByUsernameUsername string
 	FindByUsernameUserFields cf.UserFields
 	FindByUsernameNotFound bool
+	ExistingUsers map[string]cf.UserFields
 
 	ListUsersOrganizationGuid string
 	ListUsersSpaceGuid string
 	ListUsersByRole map[string][]cf.UserFields
+	ListUsersByGuidAndRole map[string]map[string][]cf.UserFields
+	ListUsersErrGuid string
+	ListUsersWaitForCalls int
+	ListUsersMaxInProgress int
+	listUsersInProgress int
+	listUsersAllInFlight chan bool
+	listUsersReleased bool
+	listUsersMutex sync.Mutex
+
+	ListUserRolesUserGuid string
+	OrgsByUserRole map[string][]cf.OrganizationFields
+	SpacesByUserRole map[string][]cf.Space
+	ListUserRolesErr bool
 
 	CreateUserUsername string
 	CreateUserPassword string
 	CreateUserExists bool
+	CreatedUsernames []string
 
 	DeleteUserGuid string
 
 	SetOrgRoleUserGuid string
 	SetOrgRoleOrganizationGuid string
 	SetOrgRoleRole string
+	SetOrgRoleCalls []string
 
 	UnsetOrgRoleUserGuid string
 	UnsetOrgRoleOrganizationGuid string
 	UnsetOrgRoleRole string
+	UnsetOrgRoleCalls []string
 
 	SetSpaceRoleUserGuid string
 	SetSpaceRoleOrgGuid string
 	SetSpaceRoleSpaceGuid string
 	SetSpaceRoleRole string
+	SetSpaceRoleCalls []string
 
 	UnsetSpaceRoleUserGuid string
 	UnsetSpaceRoleSpaceGuid string
 	UnsetSpaceRoleRole string
+	UnsetSpaceRoleCalls []string
+
+	RemoveFromOrgUserGuid string
+	RemoveFromOrgOrgGuid string
+	RemoveFromOrgErr bool
 }
 
 func (repo *FakeUserRepository) FindByUsername(username string) (user cf.UserFields, apiResponse net.ApiResponse) {
 	repo.FindByUsernameUsername = username
 	user = repo.FindByUsernameUserFields
 
+	if repo.ExistingUsers != nil {
+		var found bool
+		user, found = repo.ExistingUsers[username]
+		if !found {
+			apiResponse = net.NewNotFoundApiResponse("UserFields not found")
+		}
+		return
+	}
+
 	if repo.FindByUsernameNotFound {
 		apiResponse = net.NewNotFoundApiResponse("UserFields not found")
 	}
@@ -49,41 +83,101 @@ func (repo *FakeUserRepository) FindByUsername(username string) (user cf.UserFie
 	return
 }
 
-func (repo *FakeUserRepository) ListUsersInOrgForRole(orgGuid string, roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse) {
+func (repo *FakeUserRepository) ListUsersInOrgForRole(orgGuid string, roleName string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
+	repo.listUsersMutex.Lock()
 	repo.ListUsersOrganizationGuid = orgGuid
-	return repo.listUsersForRole(roleName,stop)
+	repo.listUsersMutex.Unlock()
+	return repo.listUsersForRole(orgGuid, roleName, cb)
 }
 
-func (repo *FakeUserRepository) ListUsersInSpaceForRole(spaceGuid string, roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse){
+func (repo *FakeUserRepository) ListUsersInSpaceForRole(spaceGuid string, roleName string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
+	repo.listUsersMutex.Lock()
 	repo.ListUsersSpaceGuid = spaceGuid
-	return repo.listUsersForRole(roleName,stop)
+	repo.listUsersMutex.Unlock()
+	return repo.listUsersForRole(spaceGuid, roleName, cb)
 }
 
-func (repo *FakeUserRepository) listUsersForRole(roleName string, stop chan bool) (usersChan chan []cf.UserFields, statusChan chan net.ApiResponse){
-	usersChan = make(chan []cf.UserFields, 4)
-	statusChan = make(chan net.ApiResponse, 1)
-
-	go func() {
-		usersCount := len(repo.ListUsersByRole[roleName])
-		for i:= 0; i < usersCount; i += 2 {
-			select {
-			case <-stop:
-				break
-			default:
-				if usersCount - i > 1 {
-					usersChan <- repo.ListUsersByRole[roleName][i:i+2]
-				} else {
-					usersChan <- repo.ListUsersByRole[roleName][i:]
-				}
-			}
+func (repo *FakeUserRepository) listUsersForRole(guid string, roleName string, cb func([]cf.UserFields) bool) (apiResponse net.ApiResponse) {
+	repo.listUsersMutex.Lock()
+	if repo.listUsersAllInFlight == nil {
+		repo.listUsersAllInFlight = make(chan bool)
+	}
+	allInFlight := repo.listUsersAllInFlight
+	repo.listUsersInProgress++
+	if repo.listUsersInProgress > repo.ListUsersMaxInProgress {
+		repo.ListUsersMaxInProgress = repo.listUsersInProgress
+	}
+	if repo.listUsersInProgress == repo.ListUsersWaitForCalls && !repo.listUsersReleased {
+		repo.listUsersReleased = true
+		close(allInFlight)
+	}
+	repo.listUsersMutex.Unlock()
+
+	// hold every call until the expected number are in flight together; give up
+	// after a while so sequential callers fail the test instead of hanging it
+	if repo.ListUsersWaitForCalls > 0 {
+		select {
+		case <-allInFlight:
+		case <-time.After(time.Second):
 		}
+	}
 
-		close(usersChan)
-		close(statusChan)
-
-		cf.WaitForClose(stop)
+	defer func() {
+		repo.listUsersMutex.Lock()
+		repo.listUsersInProgress--
+		repo.listUsersMutex.Unlock()
 	}()
 
+	if guid != "" && guid == repo.ListUsersErrGuid {
+		return net.NewApiResponseWithMessage("Error listing users")
+	}
+
+	users := repo.ListUsersByRole[roleName]
+	if repo.ListUsersByGuidAndRole != nil {
+		users = repo.ListUsersByGuidAndRole[guid][roleName]
+	}
+	count := len(users)
+	for i := 0; i < count; i += 2 {
+		end := i + 2
+		if end > count {
+			end = count
+		}
+		if !cb(users[i:end]) {
+			break
+		}
+	}
+	return
+}
+
+func (repo *FakeUserRepository) ListOrgsForUserRole(userGuid string, roleName string, cb func([]cf.OrganizationFields) bool) (apiResponse net.ApiResponse) {
+	repo.listUsersMutex.Lock()
+	defer repo.listUsersMutex.Unlock()
+
+	repo.ListUserRolesUserGuid = userGuid
+	if repo.ListUserRolesErr {
+		return net.NewApiResponseWithMessage("Error listing user roles")
+	}
+
+	orgs := repo.OrgsByUserRole[roleName]
+	if len(orgs) > 0 {
+		cb(orgs)
+	}
+	return
+}
+
+func (repo *FakeUserRepository) ListSpacesForUserRole(userGuid string, roleName string, cb func([]cf.Space) bool) (apiResponse net.ApiResponse) {
+	repo.listUsersMutex.Lock()
+	defer repo.listUsersMutex.Unlock()
+
+	repo.ListUserRolesUserGuid = userGuid
+	if repo.ListUserRolesErr {
+		return net.NewApiResponseWithMessage("Error listing user roles")
+	}
+
+	spaces := repo.SpacesByUserRole[roleName]
+	if len(spaces) > 0 {
+		cb(spaces)
+	}
 	return
 }
 
@@ -93,6 +187,12 @@ func (repo *FakeUserRepository) Create(username, password string) (apiResponse n
 
 	if repo.CreateUserExists {
 		apiResponse = net.NewApiResponse("UserFields already exists", cf.USER_EXISTS, 400)
+		return
+	}
+
+	repo.CreatedUsernames = append(repo.CreatedUsernames, username)
+	if repo.ExistingUsers != nil {
+		repo.ExistingUsers[username] = cf.UserFields{Guid: username + "-guid", Username: username}
 	}
 
 	return
@@ -107,6 +207,7 @@ func (repo *FakeUserRepository) SetOrgRole(userGuid, orgGuid, role string) (apiR
 	repo.SetOrgRoleUserGuid = userGuid
 	repo.SetOrgRoleOrganizationGuid = orgGuid
 	repo.SetOrgRoleRole = role
+	repo.SetOrgRoleCalls = append(repo.SetOrgRoleCalls, userGuid+" "+orgGuid+" "+role)
 	return
 }
 
@@ -114,6 +215,7 @@ func (repo *FakeUserRepository) UnsetOrgRole(userGuid, orgGuid, role string) (ap
 	repo.UnsetOrgRoleUserGuid = userGuid
 	repo.UnsetOrgRoleOrganizationGuid = orgGuid
 	repo.UnsetOrgRoleRole = role
+	repo.UnsetOrgRoleCalls = append(repo.UnsetOrgRoleCalls, userGuid+" "+orgGuid+" "+role)
 	return
 }
 
@@ -122,6 +224,7 @@ func (repo *FakeUserRepository) SetSpaceRole(userGuid, spaceGuid, orgGuid, role
 	repo.SetSpaceRoleOrgGuid = orgGuid
 	repo.SetSpaceRoleSpaceGuid = spaceGuid
 	repo.SetSpaceRoleRole = role
+	repo.SetSpaceRoleCalls = append(repo.SetSpaceRoleCalls, userGuid+" "+spaceGuid+" "+role)
 	return
 }
 
@@ -129,5 +232,16 @@ func (repo *FakeUserRepository) UnsetSpaceRole(userGuid, spaceGuid, role string)
 	repo.UnsetSpaceRoleUserGuid = userGuid
 	repo.UnsetSpaceRoleSpaceGuid = spaceGuid
 	repo.UnsetSpaceRoleRole = role
+	repo.UnsetSpaceRoleCalls = append(repo.UnsetSpaceRoleCalls, userGuid+" "+spaceGuid+" "+role)
+	return
+}
+
+func (repo *FakeUserRepository) RemoveFromOrg(userGuid, orgGuid string) (apiResponse net.ApiResponse) {
+	repo.RemoveFromOrgUserGuid = userGuid
+	repo.RemoveFromOrgOrgGuid = orgGuid
+
+	if repo.RemoveFromOrgErr {
+		apiResponse = net.NewApiResponseWithMessage("Error removing user from org")
+	}
 	return
 }
@@ -1,10 +1,12 @@
 package commands
 
 type FakeApiEndpointSetter struct {
-	SetEndpoint string
+	SetEndpoint       string
+	AllowInsecureHttp bool
 }
 
-func (setter *FakeApiEndpointSetter) SetApiEndpoint(endpoint string) {
+func (setter *FakeApiEndpointSetter) SetApiEndpoint(endpoint string, allowInsecureHttp bool) {
 	setter.SetEndpoint = endpoint
+	setter.AllowInsecureHttp = allowInsecureHttp
 	return
 }
@@ -3,14 +3,20 @@ package commands
 import (
 	"cf"
 	"cf/net"
+	"sync"
 )
 
 type FakeAppBinder struct {
 	AppsToBind cf.ApplicationSet
 	InstancesToBindTo cf.ServiceInstanceSet
+
+	mutex sync.Mutex
 }
 
 func (binder *FakeAppBinder) BindApplication(app cf.Application, service cf.ServiceInstance) (apiResponse net.ApiResponse) {
+	binder.mutex.Lock()
+	defer binder.mutex.Unlock()
+
 	binder.AppsToBind = append(binder.AppsToBind, app)
 	binder.InstancesToBindTo = append(binder.InstancesToBindTo, service)
 
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"cf"
+	"cf/commands/application"
+	"cf/terminal"
 )
 
 type FakeAppDisplayer struct {
@@ -11,3 +13,7 @@ type FakeAppDisplayer struct {
 func (displayer *FakeAppDisplayer) ShowApp(app cf.Application) {
 	displayer.AppToDisplay = app
 }
+
+func (displayer *FakeAppDisplayer) WithUI(ui terminal.UI) application.ApplicationDisplayer {
+	return displayer
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"cf"
+)
+
+type FakeAppRestager struct {
+	AppToRestage   cf.Application
+	StagingTimeout int
+	StartupTimeout int
+}
+
+func (restager *FakeAppRestager) SetStagingTimeoutMinutes(timeout int) {
+	restager.StagingTimeout = timeout
+}
+
+func (restager *FakeAppRestager) SetStartupTimeoutMinutes(timeout int) {
+	restager.StartupTimeout = timeout
+}
+
+func (restager *FakeAppRestager) ApplicationRestage(app cf.Application) (updatedApp cf.Application, err error) {
+	restager.AppToRestage = app
+	updatedApp = app
+	return
+}
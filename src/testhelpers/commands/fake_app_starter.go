@@ -2,23 +2,45 @@ package commands
 
 import (
 	"cf"
+	"cf/commands/application"
+	"cf/terminal"
+	"sync"
 )
 
 type FakeAppStarter struct {
-	AppToStart cf.Application
-	Timeout int
+	AppToStart  cf.Application
+	Timeout     int
+	StartedApps []string
+	UIs         []terminal.UI
+
+	mutex sync.Mutex
 }
 
 func (starter *FakeAppStarter) ApplicationStart(appToStart cf.Application) (startedApp cf.Application, err error) {
+	starter.mutex.Lock()
+	defer starter.mutex.Unlock()
+
 	starter.AppToStart = appToStart
+	starter.StartedApps = append(starter.StartedApps, appToStart.Name)
 	startedApp = appToStart
 	return
 }
 
 func (starter *FakeAppStarter) SetStartTimeoutSeconds(timeout int) {
+	starter.mutex.Lock()
+	defer starter.mutex.Unlock()
+
 	starter.Timeout = timeout
 }
 
+func (starter *FakeAppStarter) WithUI(ui terminal.UI) application.ApplicationStarter {
+	starter.mutex.Lock()
+	defer starter.mutex.Unlock()
+
+	starter.UIs = append(starter.UIs, ui)
+	return starter
+}
+
 func (starter *FakeAppStarter) ApplicationStartWithBuildpack(app cf.Application, buildpackUrl string) (startedApp cf.Application, err error){
 	starter.AppToStart = app
 	startedApp = app
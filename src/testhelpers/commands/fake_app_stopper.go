@@ -2,14 +2,26 @@ package commands
 
 import (
 	"cf"
+	"cf/commands/application"
+	"cf/terminal"
+	"sync"
 )
 
 type FakeAppStopper struct {
 	AppToStop cf.Application
+
+	mutex sync.Mutex
 }
 
 func (stopper *FakeAppStopper) ApplicationStop(app cf.Application) (updatedApp cf.Application, err error) {
+	stopper.mutex.Lock()
+	defer stopper.mutex.Unlock()
+
 	stopper.AppToStop = app
 	updatedApp = app
 	return
 }
+
+func (stopper *FakeAppStopper) WithUI(ui terminal.UI) application.ApplicationStopper {
+	return stopper
+}
@@ -56,8 +56,9 @@ func (repo FakeConfigRepository) Save() (err error) {
 
 func (repo FakeConfigRepository) ClearTokens() (err error) {
 	c, _ := repo.Get()
-	c.AccessToken = ""
-	c.RefreshToken = ""
+	c.SetTokens("", "")
+	c.ClientId = ""
+	c.ClientSecret = ""
 
 	return nil
 }
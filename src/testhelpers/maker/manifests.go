@@ -72,6 +72,121 @@ applications:
   path: ../../fixtures/example-app
   env:
     FOO: baz
+`,
+	"health check": `
+---
+applications:
+- name: health-checked-app
+  disk_quota: 2G
+  health_check_type: http
+  health_check_http_endpoint: /health
+`,
+	"multiple routes": `
+---
+applications:
+- name: routed-app
+  routes:
+  - host: www
+    domain: example.com
+  - host: api
+    domain: example.com
+    path: /v1
+  - domain: example.com
+`,
+	"invalid routes": `
+---
+applications:
+- name: badly-routed-app
+  routes:
+  - www.example.com
+- name: bad-path-app
+  routes:
+  - host: www
+    domain: example.com
+    path: v1
+`,
+	"invalid health check": `
+---
+applications:
+- name: badly-checked-app
+  health_check_type: process
+- name: port-checked-app
+  health_check_type: port
+  health_check_http_endpoint: /health
+`,
+	"dependent apps": `
+---
+applications:
+- name: frontend
+  depends_on:
+  - backend
+- name: backend
+  services:
+  - backend-db
+- name: worker
+`,
+	"invalid dependencies": `
+---
+applications:
+- name: frontend
+  depends_on: backend
+- name: backend
+`,
+	"unknown dependency": `
+---
+applications:
+- name: frontend
+  depends_on:
+  - backend
+`,
+	"circular dependencies": `
+---
+applications:
+- name: frontend
+  depends_on:
+  - backend
+- name: backend
+  depends_on:
+  - frontend
+`,
+	"declared services": `
+---
+declared-services:
+- name: new-db
+  label: cleardb
+  plan: spark
+  parameters:
+    max_connections: 10
+- name: existing-db
+  label: cleardb
+  plan: spark
+applications:
+- name: db-app
+  services:
+  - new-db
+  - existing-db
+`,
+	"docker image": `
+---
+applications:
+- name: docker-app
+  buildpack: some-buildpack
+  docker:
+    image: repo/docker-app:1.0
+`,
+	"invalid docker": `
+---
+applications:
+- name: docker-app
+  docker: repo/docker-app:1.0
+`,
+	"docker image with path": `
+---
+applications:
+- name: docker-app-with-path
+  path: ./app
+  docker:
+    image: repo/docker-app:1.0
 `,
 	"invalid": `
 ---
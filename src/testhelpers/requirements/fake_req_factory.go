@@ -17,6 +17,7 @@ type FakeReqFactory struct {
 	TargetedSpaceSuccess    bool
 	TargetedOrgSuccess      bool
 	BuildpackSuccess		bool
+	ScopeSuccess		bool
 
 	SpaceName string
 	Space     cf.Space
@@ -36,6 +37,8 @@ type FakeReqFactory struct {
 	UserFields         cf.UserFields
 
 	Buildpack     cf.Buildpack
+
+	Scope string
 }
 
 func (f *FakeReqFactory) NewApplicationRequirement(name string) requirements.ApplicationRequirement {
@@ -89,6 +92,11 @@ func (f *FakeReqFactory) NewBuildpackRequirement(buildpack string) requirements.
 	return FakeRequirement{f, f.BuildpackSuccess}
 }
 
+func (f *FakeReqFactory) NewScopeRequirement(scope string) requirements.Requirement {
+	f.Scope = scope
+	return FakeRequirement{f, f.ScopeSuccess}
+}
+
 type FakeRequirement struct {
 	factory *FakeReqFactory
 	success bool